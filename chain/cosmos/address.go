@@ -31,3 +31,13 @@ func (c *CosmosChain) AccAddressFromBech32(address string) (addr sdk.AccAddress,
 func (c *CosmosChain) AccAddressToBech32(addr sdk.AccAddress) (string, error) {
 	return bech32.ConvertAndEncode(c.Config().Bech32Prefix, addr)
 }
+
+// ValAddressToBech32 encodes addr with the chain's validator operator prefix.
+func (c *CosmosChain) ValAddressToBech32(addr sdk.ValAddress) (string, error) {
+	return bech32.ConvertAndEncode(c.Config().Bech32Prefix+sdk.PrefixValidator+sdk.PrefixOperator, addr)
+}
+
+// ConsAddressToBech32 encodes addr with the chain's validator consensus prefix.
+func (c *CosmosChain) ConsAddressToBech32(addr sdk.ConsAddress) (string, error) {
+	return bech32.ConvertAndEncode(c.Config().Bech32Prefix+sdk.PrefixValidator+sdk.PrefixConsensus, addr)
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
-	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 
 	"github.com/cosmos/interchaintest/v11/dockerutil"
 	"github.com/cosmos/interchaintest/v11/testutil"
@@ -233,22 +232,11 @@ func BroadcastTx(ctx context.Context, broadcaster *Broadcaster, broadcastingUser
 		return sdk.TxResponse{}, err
 	}
 
-	return getFullyPopulatedResponse(cc, respWithTxHash.TxHash)
-}
-
-// getFullyPopulatedResponse returns a fully populated sdk.TxResponse.
-// the QueryTx function is periodically called until a tx with the given hash
-// has been included in a block.
-func getFullyPopulatedResponse(cc client.Context, txHash string) (sdk.TxResponse, error) {
-	var resp sdk.TxResponse
-	err := testutil.WaitForCondition(time.Second*60, time.Second*5, func() (bool, error) {
-		fullyPopulatedTxResp, err := authtx.QueryTx(cc, txHash)
-		if err != nil {
-			return false, nil
-		}
-
-		resp = *fullyPopulatedTxResp
-		return true, nil
-	})
-	return resp, err
+	node := broadcaster.chain.GetFullNode()
+	res, err := node.WaitForTx(ctx, respWithTxHash.TxHash)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	node.trackGas(res)
+	return *res, nil
 }
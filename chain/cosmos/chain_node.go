@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"math/rand"
 	"os"
 	"path"
@@ -69,6 +70,10 @@ type ChainNode struct {
 	lock sync.Mutex
 	log  *zap.Logger
 
+	// In-memory keyring of keys imported from the node's test keyring, used to sign gRPC transactions.
+	txKeyring   keyring.Keyring
+	txKeyringMu sync.Mutex
+
 	containerLifecycle *dockerutil.ContainerLifecycle
 
 	// Ports set during StartContainer.
@@ -77,6 +82,9 @@ type ChainNode struct {
 	hostGRPCPort  string
 	hostP2PPort   string
 	cometHostname string
+
+	// Set once the cosmovisor directory layout has been populated.
+	cosmovisorReady bool
 }
 
 func NewChainNode(log *zap.Logger, validator bool, chain *CosmosChain, dockerClient *dockerclient.Client, networkID string, testName string, image ibc.DockerImage, index int) *ChainNode {
@@ -260,6 +268,15 @@ func (tn *ChainNode) OverwriteGenesisFile(ctx context.Context, content []byte) e
 	return nil
 }
 
+// OverwriteGenesisFileFrom overwrites genesis.json with the size bytes read from r, streaming them into the node's volume.
+func (tn *ChainNode) OverwriteGenesisFileFrom(ctx context.Context, r io.Reader, size int64) error {
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFileFrom(ctx, tn.VolumeName, "config/genesis.json", r, size); err != nil {
+		return fmt.Errorf("overwriting genesis.json: %w", err)
+	}
+	return nil
+}
+
 func (tn *ChainNode) PrivValFileContent(ctx context.Context) ([]byte, error) {
 	gen, err := tn.ReadFile(ctx, "config/priv_validator_key.json")
 	if err != nil {
@@ -385,6 +402,16 @@ func (tn *ChainNode) SetTestConfig(ctx context.Context) error {
 
 	a["api"] = api
 
+	if snapshots := tn.Chain.Config().Snapshots; snapshots != nil {
+		stateSync := make(testutil.Toml)
+		stateSync["snapshot-interval"] = snapshots.Interval
+		stateSync["snapshot-keep-recent"] = snapshots.KeepRecent
+		if snapshots.KeepRecent == 0 {
+			stateSync["snapshot-keep-recent"] = 2
+		}
+		a["state-sync"] = stateSync
+	}
+
 	return testutil.ModifyTomlConfigFile(
 		ctx,
 		tn.logger(),
@@ -538,58 +565,53 @@ func (tn *ChainNode) TxCommand(keyName string, command ...string) []string {
 	)...)
 }
 
-// ExecTx executes a transaction, waits for 2 blocks if successful, then returns the tx hash.
+// ExecTx executes a transaction, waits for it to be committed, then returns the tx hash.
 func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...string) (string, error) {
+	res, err := tn.execTxCLI(ctx, keyName, command...)
+	if res == nil {
+		return "", err
+	}
+	return res.TxHash, err
+}
+
+// execTxCLI executes a transaction with the chain binary and returns its committed response.
+// If the transaction failed, the response holds at least its hash, code and log.
+func (tn *ChainNode) execTxCLI(ctx context.Context, keyName string, command ...string) (*sdk.TxResponse, error) {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
 	stdout, _, err := tn.Exec(ctx, tn.TxCommand(keyName, command...), tn.Chain.Config().Env)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	output := CosmosTx{}
 	err = json.Unmarshal(stdout, &output)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if output.Code != 0 {
-		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
-	}
-	if err := testutil.WaitForBlocks(ctx, 2, tn); err != nil {
-		return "", err
+		res := &sdk.TxResponse{TxHash: output.TxHash, Code: uint32(output.Code), RawLog: output.RawLog}
+		return res, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
 	}
 	// The transaction can at first appear to succeed, but then fail when it's actually included in a block.
-	stdout, _, err = tn.ExecQuery(ctx, "tx", output.TxHash)
+	res, err := tn.WaitForTx(ctx, output.TxHash)
 	if err != nil {
-		return "", err
-	}
-	output = CosmosTx{}
-	err = json.Unmarshal(stdout, &output)
-	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if output.Code != 0 {
-		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
+	tn.trackGas(res)
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction failed with code %d: %s", res.Code, res.RawLog)
 	}
-	return output.TxHash, nil
+	return res, nil
 }
 
 // TxHashToResponse returns the sdk transaction response struct for a given transaction hash.
 func (tn *ChainNode) TxHashToResponse(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
-	stdout, stderr, err := tn.ExecQuery(ctx, "tx", txHash)
+	res, err := authTx.QueryTx(tn.CliContext(), txHash)
 	if err != nil {
-		tn.log.Info("TxHashToResponse returned an error",
-			zap.String("tx_hash", txHash),
-			zap.Error(err),
-			zap.String("stderr", string(stderr)),
-		)
+		return nil, fmt.Errorf("failed to query tx %s: %w", txHash, err)
 	}
-
-	i := &sdk.TxResponse{}
-
-	// ignore the error since some types do not unmarshal (ex: height of int64 vs string)
-	_ = json.Unmarshal(stdout, &i)
-	return i, nil
+	return res, nil
 }
 
 // NodeCommand is a helper to retrieve a full command for a chain node binary.
@@ -1065,15 +1087,32 @@ func (tn *ChainNode) UnsafeResetAll(ctx context.Context) error {
 func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 	chainCfg := tn.Chain.Config()
 
+	// Run the chain binary directly, unless it is managed by cosmovisor.
+	startBin := []string{chainCfg.Bin}
+	env := chainCfg.Env
+	if chainCfg.Cosmovisor != nil {
+		if err := tn.setupCosmovisor(ctx, *chainCfg.Cosmovisor); err != nil {
+			return err
+		}
+
+		daemonHome := tn.HomeDir()
+		if chainCfg.NoHostMount {
+			daemonHome += "_nomnt"
+		}
+		startBin = []string{tn.cosmovisorBin(*chainCfg.Cosmovisor), "run"}
+		env = append(append([]string(nil), env...), tn.cosmovisorEnv(*chainCfg.Cosmovisor, daemonHome)...)
+	}
+
 	var cmd []string
 	if chainCfg.NoHostMount {
-		startCmd := fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt", tn.HomeDir(), tn.HomeDir(), chainCfg.Bin, tn.HomeDir())
+		startCmd := fmt.Sprintf("cp -r %s %s_nomnt && %s start --home %s_nomnt", tn.HomeDir(), tn.HomeDir(), strings.Join(startBin, " "), tn.HomeDir())
 		if len(chainCfg.AdditionalStartArgs) > 0 {
 			startCmd = fmt.Sprintf("%s %s", startCmd, chainCfg.AdditionalStartArgs)
 		}
 		cmd = []string{"sh", "-c", startCmd}
 	} else {
-		cmd = []string{chainCfg.Bin, "start", "--home", tn.HomeDir()}
+		cmd = append(cmd, startBin...)
+		cmd = append(cmd, "start", "--home", tn.HomeDir())
 		if len(chainCfg.AdditionalStartArgs) > 0 {
 			cmd = append(cmd, chainCfg.AdditionalStartArgs...)
 		}
@@ -1146,7 +1185,7 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		tn.log.Info("Port overrides", fields...)
 	}
 
-	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, usingPorts, "", tn.Bind(), nil, tn.HostName(), cmd, chainCfg.Env, []string{})
+	return tn.containerLifecycle.CreateContainer(ctx, tn.TestName, tn.NetworkID, tn.Image, usingPorts, "", tn.Bind(), nil, tn.HostName(), cmd, env, []string{})
 }
 
 func (tn *ChainNode) StartContainer(ctx context.Context) error {
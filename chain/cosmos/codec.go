@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
@@ -29,7 +30,13 @@ import (
 )
 
 func DefaultEncoding() testutil.TestEncodingConfig {
-	return testutil.MakeTestEncodingConfig(
+	return testutil.MakeTestEncodingConfig(DefaultModuleBasics()...)
+}
+
+// DefaultModuleBasics returns the modules registered with DefaultEncoding.
+// Their genesis states are the ones validated by ModifyGenesisState by default.
+func DefaultModuleBasics() []module.AppModuleBasic {
+	return []module.AppModuleBasic{
 		auth.AppModuleBasic{},
 		genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
 		bank.AppModuleBasic{},
@@ -49,7 +56,7 @@ func DefaultEncoding() testutil.TestEncodingConfig {
 		ibccore.AppModuleBasic{},
 		ibctm.AppModuleBasic{},
 		ibcwasm.AppModuleBasic{},
-	)
+	}
 }
 
 func decodeTX(interfaceRegistry codectypes.InterfaceRegistry, txbz []byte) (sdk.Tx, error) {
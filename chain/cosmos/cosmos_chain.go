@@ -32,13 +32,14 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
 
 	"github.com/cosmos/interchaintest/v11/blockdb"
 	wasmtypes "github.com/cosmos/interchaintest/v11/chain/cosmos/08-wasm-types"
-	"github.com/cosmos/interchaintest/v11/chain/internal/tendermint"
 	"github.com/cosmos/interchaintest/v11/dockerutil"
 	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
 	"github.com/cosmos/interchaintest/v11/testutil"
 )
 
@@ -63,6 +64,12 @@ type CosmosChain struct {
 	log      *zap.Logger
 	keyring  keyring.Keyring
 	findTxMu sync.Mutex
+	// valSetMu serializes changes to the validator set by AddValidators and RemoveValidator.
+	valSetMu sync.Mutex
+
+	// gasProfilerMu guards gasProfiler, which is set by SetGasProfiler while transactions are committed.
+	gasProfilerMu sync.Mutex
+	gasProfiler   *testreporter.GasProfiler
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -141,58 +148,75 @@ func (c *CosmosChain) Nodes() ChainNodes {
 
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
+	_, err := c.addFullNodes(ctx, configFileOverrides, inc, nil)
+	return err
+}
+
+// addFullNodes adds inc fullnodes to the network and returns them.
+// If set, beforeStart is called for each node once its home is initialized, before it is started.
+func (c *CosmosChain) addFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int, beforeStart func(*ChainNode) error) (ChainNodes, error) {
 	// Get peer string for existing nodes
 	peers := c.Nodes().PeerString(ctx)
 
 	// Get genesis.json
 	genbz, err := c.Validators[0].GenesisFileContent(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	prevCount := c.numFullNodes
 	c.numFullNodes += inc
 	if err := c.initializeChainNodes(ctx, c.testName, c.GetFullNode().DockerClient, c.GetFullNode().NetworkID); err != nil {
-		return err
+		return nil, err
 	}
 
+	added := c.FullNodes[prevCount:c.numFullNodes]
 	var eg errgroup.Group
-	for i := prevCount; i < c.numFullNodes; i++ {
+	for _, fn := range added {
 		eg.Go(func() error {
-			fn := c.FullNodes[i]
-			if err := fn.InitFullNodeFiles(ctx); err != nil {
+			if err := c.provisionNode(ctx, fn, peers, genbz, configFileOverrides); err != nil {
 				return err
 			}
-			if err := fn.SetPeers(ctx, peers); err != nil {
-				return err
-			}
-			if err := fn.OverwriteGenesisFile(ctx, genbz); err != nil {
-				return err
-			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					fn.logger(),
-					fn.DockerClient,
-					fn.TestName,
-					fn.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
+			if beforeStart != nil {
+				if err := beforeStart(fn); err != nil {
 					return err
 				}
 			}
-			if err := fn.CreateNodeContainer(ctx); err != nil {
-				return err
-			}
 			return fn.StartContainer(ctx)
 		})
 	}
-	return eg.Wait()
+	return added, eg.Wait()
+}
+
+// provisionNode initializes the home of n, a node joining the running chain, and creates its container.
+func (c *CosmosChain) provisionNode(ctx context.Context, n *ChainNode, peers string, genbz []byte, configFileOverrides map[string]any) error {
+	if err := n.InitFullNodeFiles(ctx); err != nil {
+		return err
+	}
+	if err := n.SetPeers(ctx, peers); err != nil {
+		return err
+	}
+	if err := n.OverwriteGenesisFile(ctx, genbz); err != nil {
+		return err
+	}
+	for configFile, modifiedConfig := range configFileOverrides {
+		modifiedToml, ok := modifiedConfig.(testutil.Toml)
+		if !ok {
+			return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
+		}
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			n.logger(),
+			n.DockerClient,
+			n.TestName,
+			n.VolumeName,
+			configFile,
+			modifiedToml,
+		); err != nil {
+			return err
+		}
+	}
+	return n.CreateNodeContainer(ctx)
 }
 
 // Implements Chain interface.
@@ -341,12 +365,14 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 
 // Implements Chain interface.
 func (c *CosmosChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return c.GetFullNode().BankSend(ctx, keyName, amount)
+	_, err := c.GetFullNode().BankSend(ctx, keyName, amount)
+	return err
 }
 
 // Implements Chain interface.
 func (c *CosmosChain) SendFundsWithNote(ctx context.Context, keyName string, amount ibc.WalletAmount, note string) (string, error) {
-	return c.GetFullNode().BankSendWithNote(ctx, keyName, amount, note)
+	res, err := c.GetFullNode().BankSendWithNote(ctx, keyName, amount, note)
+	return res.TxHash, err
 }
 
 // Implements Chain interface.
@@ -374,17 +400,17 @@ func (c *CosmosChain) SendIBCTransfer(
 	tx.GasSpent = txResp.GasWanted
 
 	const evType = "send_packet"
-	events := txResp.Events
+	events := Events(txResp.Events)
 
 	var (
-		seq, _           = tendermint.AttributeValue(events, evType, "packet_sequence")
-		srcPort, _       = tendermint.AttributeValue(events, evType, "packet_src_port")
-		srcChan, _       = tendermint.AttributeValue(events, evType, "packet_src_channel")
-		dstPort, _       = tendermint.AttributeValue(events, evType, "packet_dst_port")
-		dstChan, _       = tendermint.AttributeValue(events, evType, "packet_dst_channel")
-		timeoutHeight, _ = tendermint.AttributeValue(events, evType, "packet_timeout_height")
-		timeoutTS, _     = tendermint.AttributeValue(events, evType, "packet_timeout_timestamp")
-		dataHex, _       = tendermint.AttributeValue(events, evType, "packet_data_hex")
+		seq, _           = events.AttributeValue(evType, "packet_sequence")
+		srcPort, _       = events.AttributeValue(evType, "packet_src_port")
+		srcChan, _       = events.AttributeValue(evType, "packet_src_channel")
+		dstPort, _       = events.AttributeValue(evType, "packet_dst_port")
+		dstChan, _       = events.AttributeValue(evType, "packet_dst_channel")
+		timeoutHeight, _ = events.AttributeValue(evType, "packet_timeout_height")
+		timeoutTS, _     = events.AttributeValue(evType, "packet_timeout_timestamp")
+		dataHex, _       = events.AttributeValue(evType, "packet_data_hex")
 	)
 	tx.Packet.SourcePort = srcPort
 	tx.Packet.SourceChannel = srcChan
@@ -454,49 +480,48 @@ func (c *CosmosChain) PushNewWasmClientProposal(ctx context.Context, keyName str
 		return tx, "", err
 	}
 	prop.Messages = append(prop.Messages, msg)
-	txHash, err := c.GetFullNode().SubmitProposal(ctx, keyName, prop)
+	res, err := c.GetFullNode().SubmitProposal(ctx, keyName, prop)
 	if err != nil {
 		return tx, "", fmt.Errorf("failed to submit wasm client proposal: %w", err)
 	}
-	tx, err = c.txProposal(txHash)
-	return tx, codeHash, err
+	return txProposal(res), codeHash, nil
 }
 
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (c *CosmosChain) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (tx TxProposal, _ error) {
-	txHash, err := c.GetFullNode().UpgradeProposal(ctx, keyName, prop)
+	res, err := c.GetFullNode().UpgradeProposal(ctx, keyName, prop)
 	if err != nil {
 		return tx, fmt.Errorf("failed to submit upgrade proposal: %w", err)
 	}
-	return c.txProposal(txHash)
+	return txProposal(res), nil
 }
 
 // SubmitProposal submits a gov v1 proposal to the chain.
 func (c *CosmosChain) SubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (tx TxProposal, _ error) {
-	txHash, err := c.GetFullNode().SubmitProposal(ctx, keyName, prop)
+	res, err := c.GetFullNode().SubmitProposal(ctx, keyName, prop)
 	if err != nil {
 		return tx, fmt.Errorf("failed to submit gov v1 proposal: %w", err)
 	}
-	return c.txProposal(txHash)
+	return txProposal(res), nil
 }
 
 // TextProposal submits a text governance proposal to the chain.
 func (c *CosmosChain) TextProposal(ctx context.Context, keyName string, prop TextProposal) (tx TxProposal, _ error) {
-	txHash, err := c.GetFullNode().TextProposal(ctx, keyName, prop)
+	res, err := c.GetFullNode().TextProposal(ctx, keyName, prop)
 	if err != nil {
 		return tx, fmt.Errorf("failed to submit upgrade proposal: %w", err)
 	}
-	return c.txProposal(txHash)
+	return txProposal(res), nil
 }
 
 // ParamChangeProposal submits a param change proposal to the chain, signed by keyName.
 func (c *CosmosChain) ParamChangeProposal(ctx context.Context, keyName string, prop *paramsutils.ParamChangeProposalJSON) (tx TxProposal, _ error) {
-	txHash, err := c.GetFullNode().ParamChangeProposal(ctx, keyName, prop)
+	res, err := c.GetFullNode().ParamChangeProposal(ctx, keyName, prop)
 	if err != nil {
 		return tx, fmt.Errorf("failed to submit param change proposal: %w", err)
 	}
 
-	return c.txProposal(txHash)
+	return txProposal(res), nil
 }
 
 // QueryParam returns the param state of a given key.
@@ -509,24 +534,20 @@ func (c *CosmosChain) QueryBankMetadata(ctx context.Context, denom string) (*Ban
 	return c.GetFullNode().QueryBankMetadata(ctx, denom)
 }
 
-func (c *CosmosChain) txProposal(txHash string) (tx TxProposal, _ error) {
-	txResp, err := c.GetTransaction(txHash)
-	if err != nil {
-		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
-	}
-	tx.Height = txResp.Height
-	tx.TxHash = txHash
+// txProposal returns the TxProposal of a committed proposal submission.
+func txProposal(res TxResult[*govv1.MsgSubmitProposalResponse]) (tx TxProposal) {
+	tx.Height = res.Height
+	tx.TxHash = res.TxHash
 	// In cosmos, user is charged for entire gas requested, not the actual gas used.
-	tx.GasSpent = txResp.GasWanted
-	events := txResp.Events
+	tx.GasSpent = res.GasWanted
 
-	tx.DepositAmount, _ = tendermint.AttributeValue(events, "proposal_deposit", "amount")
+	tx.DepositAmount, _ = res.Events.AttributeValue("proposal_deposit", "amount")
 
 	evtSubmitProp := "submit_proposal"
-	tx.ProposalID, _ = tendermint.AttributeValue(events, evtSubmitProp, "proposal_id")
-	tx.ProposalType, _ = tendermint.AttributeValue(events, evtSubmitProp, "proposal_type")
+	tx.ProposalID, _ = res.Events.AttributeValue(evtSubmitProp, "proposal_id")
+	tx.ProposalType, _ = res.Events.AttributeValue(evtSubmitProp, "proposal_type")
 
-	return tx, nil
+	return tx
 }
 
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
@@ -748,10 +769,14 @@ func (c *CosmosChain) initializeChainNodes(
 	newFullNodes := make(ChainNodes, c.numFullNodes)
 	copy(newFullNodes, c.FullNodes)
 
+	// Nodes may have been removed, so index new nodes after the last existing one to keep their names unique.
+	valIndex := nextNodeIndex(c.Validators) - len(c.Validators)
+	fnIndex := nextNodeIndex(c.FullNodes) - len(c.FullNodes)
+
 	eg, egCtx := errgroup.WithContext(ctx)
 	for i := len(c.Validators); i < c.NumValidators; i++ {
 		eg.Go(func() error {
-			val, err := c.NewChainNode(egCtx, testName, cli, networkID, image, true, i)
+			val, err := c.NewChainNode(egCtx, testName, cli, networkID, image, true, valIndex+i)
 			if err != nil {
 				return err
 			}
@@ -761,7 +786,7 @@ func (c *CosmosChain) initializeChainNodes(
 	}
 	for i := len(c.FullNodes); i < c.numFullNodes; i++ {
 		eg.Go(func() error {
-			fn, err := c.NewChainNode(egCtx, testName, cli, networkID, image, false, i)
+			fn, err := c.NewChainNode(egCtx, testName, cli, networkID, image, false, fnIndex+i)
 			if err != nil {
 				return err
 			}
@@ -779,6 +804,14 @@ func (c *CosmosChain) initializeChainNodes(
 	return nil
 }
 
+// nextNodeIndex returns the index following the last of nodes, or 0 if there are none.
+func nextNodeIndex(nodes ChainNodes) int {
+	if len(nodes) == 0 {
+		return 0
+	}
+	return nodes[len(nodes)-1].Index + 1
+}
+
 // initializeSidecars creates the sidecar processes that exist at the chain level.
 func (c *CosmosChain) initializeSidecars(
 	ctx context.Context,
@@ -845,33 +878,18 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		}
 	}
 
-	configFileOverrides := chainCfg.ConfigFileOverrides
+	if c.cfg.GenesisImport != nil {
+		return c.startFromGenesisImport(ctx, genesisAmounts, genesisSelfDelegation, additionalGenesisWallets)
+	}
 
 	eg := new(errgroup.Group)
 	// Initialize config and sign gentx for each validator.
 	for i, v := range c.Validators {
 		v.Validator = true
 		eg.Go(func() error {
-			if err := v.InitFullNodeFiles(ctx); err != nil {
+			if err := c.initNodeFiles(ctx, v); err != nil {
 				return err
 			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					v.logger(),
-					v.DockerClient,
-					v.TestName,
-					v.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return fmt.Errorf("failed to modify toml config file: %w", err)
-				}
-			}
 			if !c.cfg.SkipGenTx {
 				return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts[i], genesisSelfDelegation[i])
 			}
@@ -883,27 +901,7 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	for _, n := range c.FullNodes {
 		n.Validator = false
 		eg.Go(func() error {
-			if err := n.InitFullNodeFiles(ctx); err != nil {
-				return err
-			}
-			for configFile, modifiedConfig := range configFileOverrides {
-				modifiedToml, ok := modifiedConfig.(testutil.Toml)
-				if !ok {
-					return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
-				}
-				if err := testutil.ModifyTomlConfigFile(
-					ctx,
-					n.logger(),
-					n.DockerClient,
-					n.TestName,
-					n.VolumeName,
-					configFile,
-					modifiedToml,
-				); err != nil {
-					return err
-				}
-			}
-			return nil
+			return c.initNodeFiles(ctx, n)
 		})
 	}
 
@@ -971,6 +969,37 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		}
 	}
 
+	return c.startNodes(ctx, genbz)
+}
+
+// initNodeFiles initializes the home directory of n and applies the configured config file overrides.
+func (c *CosmosChain) initNodeFiles(ctx context.Context, n *ChainNode) error {
+	if err := n.InitFullNodeFiles(ctx); err != nil {
+		return err
+	}
+	for configFile, modifiedConfig := range c.cfg.ConfigFileOverrides {
+		modifiedToml, ok := modifiedConfig.(testutil.Toml)
+		if !ok {
+			return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
+		}
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			n.logger(),
+			n.DockerClient,
+			n.TestName,
+			n.VolumeName,
+			configFile,
+			modifiedToml,
+		); err != nil {
+			return fmt.Errorf("failed to modify toml config file: %w", err)
+		}
+	}
+	return nil
+}
+
+// startNodes writes the final genesis to every node, then starts sidecars and node containers
+// and waits for the chain to produce blocks.
+func (c *CosmosChain) startNodes(ctx context.Context, genbz []byte) error {
 	// Provide EXPORT_GENESIS_FILE_PATH and EXPORT_GENESIS_CHAIN to help debug genesis file
 	exportGenesis := os.Getenv("EXPORT_GENESIS_FILE_PATH")
 	exportGenesisChain := os.Getenv("EXPORT_GENESIS_CHAIN")
@@ -994,10 +1023,18 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	return c.launchNodes(ctx)
+}
+
+// launchNodes starts sidecars and node containers, whose genesis is already written,
+// and waits for the chain to produce blocks.
+func (c *CosmosChain) launchNodes(ctx context.Context) error {
+	chainNodes := c.Nodes()
+
 	// Start any sidecar processes that should be running before the chain starts
 	eg, egCtx := errgroup.WithContext(ctx)
 	for _, s := range c.Sidecars {
-		err = s.containerLifecycle.Running(ctx)
+		err := s.containerLifecycle.Running(ctx)
 		if s.preStart && err != nil {
 			eg.Go(func() error {
 				if err := s.CreateContainer(egCtx); err != nil {
@@ -1168,6 +1205,60 @@ func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
 	return eg.Wait()
 }
 
+// NodeVolumes returns the names of the Docker volumes backing each node's home directory,
+// in the same order as Nodes.
+func (c *CosmosChain) NodeVolumes() []string {
+	nodes := c.Nodes()
+	volumes := make([]string, len(nodes))
+	for i, n := range nodes {
+		volumes[i] = n.VolumeName
+	}
+	return volumes
+}
+
+// StartWithExistingHome starts a chain whose node home directories are already populated,
+// such as volumes restored from an Interchain snapshot, skipping the genesis ceremony entirely.
+// Peers are re-derived because node host names differ between tests.
+// Should only be used after Initialize.
+func (c *CosmosChain) StartWithExistingHome(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, s := range c.Sidecars {
+		if !s.preStart || s.containerLifecycle.Running(ctx) == nil {
+			continue
+		}
+		eg.Go(func() error {
+			if err := s.CreateContainer(egCtx); err != nil {
+				return err
+			}
+			return s.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	chainNodes := c.Nodes()
+	peers := chainNodes.PeerString(ctx)
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		eg.Go(func() error {
+			if err := n.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			if err := n.CreateNodeContainer(egCtx); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	return testutil.WaitForBlocks(ctx, 2, c.GetFullNode())
+}
+
 // StartAllSidecars creates and starts new containers for each sidecar process.
 // Should only be used if the chain has previously been started with .Start.
 func (c *CosmosChain) StartAllSidecars(ctx context.Context) error {
@@ -1223,7 +1314,8 @@ func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalI
 	for _, n := range c.Nodes() {
 		if n.Validator {
 			eg.Go(func() error {
-				return n.VoteOnProposal(ctx, valKey, proposalID, vote)
+				_, err := n.VoteOnProposal(ctx, valKey, proposalID, vote)
+				return err
 			})
 		}
 	}
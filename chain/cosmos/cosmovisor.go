@@ -0,0 +1,126 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/cosmos/interchaintest/v11/dockerutil"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+const cosmovisorBinName = "cosmovisor"
+
+// CosmovisorDir returns the cosmovisor root directory (DAEMON_HOME/cosmovisor) inside the node container.
+func (tn *ChainNode) CosmovisorDir() string {
+	return path.Join(tn.HomeDir(), "cosmovisor")
+}
+
+// cosmovisorBin returns the path or name used to invoke cosmovisor in the node container.
+func (tn *ChainNode) cosmovisorBin(cfg ibc.CosmovisorConfig) string {
+	if cfg.Image.Repository == "" {
+		return cosmovisorBinName
+	}
+	return path.Join(tn.CosmovisorDir(), "bin", cosmovisorBinName)
+}
+
+// cosmovisorEnv returns the environment variables configuring cosmovisor for a node whose home is daemonHome.
+func (tn *ChainNode) cosmovisorEnv(cfg ibc.CosmovisorConfig, daemonHome string) []string {
+	env := []string{
+		"DAEMON_NAME=" + tn.Chain.Config().Bin,
+		"DAEMON_HOME=" + daemonHome,
+		"DAEMON_ALLOW_DOWNLOAD_BINARIES=" + strconv.FormatBool(cfg.AllowDownloadBinaries),
+		"DAEMON_RESTART_AFTER_UPGRADE=true",
+		// Backups of the data directory only slow down tests.
+		"UNSAFE_SKIP_BACKUP=true",
+	}
+	return append(env, cfg.Env...)
+}
+
+// setupCosmovisor populates the cosmovisor directory layout in the node home:
+// the genesis binary from the node's own image, each configured upgrade binary from its image,
+// and the cosmovisor binary itself if an image is configured.
+// Binaries are only copied once per node.
+func (tn *ChainNode) setupCosmovisor(ctx context.Context, cfg ibc.CosmovisorConfig) error {
+	if tn.cosmovisorReady {
+		return nil
+	}
+
+	bin := tn.Chain.Config().Bin
+	root := tn.CosmovisorDir()
+
+	if err := tn.copyBinaryFromImage(ctx, tn.Image, bin, path.Join(root, "genesis", "bin")); err != nil {
+		return fmt.Errorf("failed to set up cosmovisor genesis binary: %w", err)
+	}
+
+	// Sort for deterministic logs and errors.
+	names := make([]string, 0, len(cfg.Upgrades))
+	for name := range cfg.Upgrades {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := tn.copyBinaryFromImage(ctx, cfg.Upgrades[name], bin, path.Join(root, "upgrades", name, "bin")); err != nil {
+			return fmt.Errorf("failed to set up cosmovisor binary for upgrade %s: %w", name, err)
+		}
+	}
+
+	if cfg.Image.Repository != "" {
+		if err := tn.copyBinaryFromImage(ctx, cfg.Image, cosmovisorBinName, path.Join(root, "bin")); err != nil {
+			return fmt.Errorf("failed to set up cosmovisor binary: %w", err)
+		}
+	}
+
+	tn.cosmovisorReady = true
+	return nil
+}
+
+// copyBinaryScript copies the binary $1 on the PATH into the directory $2.
+// If the binary links shared libraries other than the C library, such as libwasmvm, the binary and those
+// libraries are copied into the directory $3 instead, and $2 holds a wrapper script which runs the binary
+// with $3 on LD_LIBRARY_PATH.
+const copyBinaryScript = `set -e
+src=$(command -v "$1")
+mkdir -p "$2"
+libs=""
+if command -v ldd >/dev/null 2>&1; then
+	libs=$(ldd "$src" 2>/dev/null | awk '$2 == "=>" && $3 ~ /^\// {print $3}' | grep -Ev '/(ld-|libc\.|libm\.|libdl\.|libpthread\.|librt\.)' || true)
+fi
+if [ -z "$libs" ]; then
+	cp "$src" "$2/$1"
+	chmod +x "$2/$1"
+	exit 0
+fi
+mkdir -p "$3"
+for lib in $libs; do cp -L "$lib" "$3/"; done
+cp "$src" "$3/$1"
+chmod +x "$3/$1"
+printf '#!/bin/sh\nexport LD_LIBRARY_PATH="%s${LD_LIBRARY_PATH:+:$LD_LIBRARY_PATH}"\nexec "%s/%s" "$@"\n' "$3" "$3" "$1" > "$2/$1"
+chmod +x "$2/$1"`
+
+// copyBinaryFromImage copies the named binary on the PATH of image into dstDir in the node home,
+// owned by the node's user, along with the shared libraries it needs, such as libwasmvm, into the
+// sibling lib directory of dstDir.
+//
+// The C library is not copied, so binaries must be built against the same C library as the node's image,
+// e.g. a glibc binary cannot run in a musl based image.
+func (tn *ChainNode) copyBinaryFromImage(ctx context.Context, image ibc.DockerImage, bin, dstDir string) error {
+	job := dockerutil.NewImage(tn.logger(), tn.DockerClient, tn.NetworkID, tn.TestName, image.Repository, image.Version)
+	res := job.Run(ctx, []string{
+		"sh", "-c",
+		copyBinaryScript,
+		"_", // Meaningless arg0 for sh -c with positional args.
+		bin,
+		dstDir,
+		path.Join(path.Dir(dstDir), "lib"),
+	}, dockerutil.ContainerOptions{
+		Binds: tn.Bind(),
+		User:  tn.Image.UIDGID,
+	})
+	if res.Err != nil {
+		return fmt.Errorf("copying %s from %s: %w", bin, image.Ref(), res.Err)
+	}
+	return nil
+}
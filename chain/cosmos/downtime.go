@@ -0,0 +1,232 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// SigningInfoSnapshot is a validator's liveness state as of a block.
+type SigningInfoSnapshot struct {
+	Height    int64
+	BlockTime time.Time
+	// Jailed is the jailed flag of the validator in the staking module.
+	Jailed bool
+	Info   slashingtypes.ValidatorSigningInfo
+}
+
+// DowntimeReport records a validator's signing info, block by block, while it was offline.
+type DowntimeReport struct {
+	ConsAddress string
+	// StoppedHeight is the chain height when the validator's node was stopped.
+	StoppedHeight int64
+	// Jailed reports whether the validator was jailed before the deadline,
+	// at JailedHeight.
+	Jailed       bool
+	JailedHeight int64
+	// Snapshots holds a snapshot for every block observed while the validator was offline, in height order.
+	Snapshots []SigningInfoSnapshot
+}
+
+// Last returns the last snapshot taken, or the zero value if there is none.
+func (r DowntimeReport) Last() SigningInfoSnapshot {
+	if len(r.Snapshots) == 0 {
+		return SigningInfoSnapshot{}
+	}
+	return r.Snapshots[len(r.Snapshots)-1]
+}
+
+// MaxMissedBlocks is the highest missed blocks counter observed.
+// The counter is reset when the validator is jailed, so this is the count that triggered jailing.
+func (r DowntimeReport) MaxMissedBlocks() int64 {
+	var maxMissed int64
+	for _, s := range r.Snapshots {
+		maxMissed = max(maxMissed, s.Info.MissedBlocksCounter)
+	}
+	return maxMissed
+}
+
+// ValidatorConsAddress returns the bech32 consensus address of the node's priv_validator_key.json.
+func (tn *ChainNode) ValidatorConsAddress(ctx context.Context) (string, error) {
+	privVal, err := tn.PrivValFileContent(ctx)
+	if err != nil {
+		return "", err
+	}
+	var keyFile PrivValidatorKeyFile
+	if err := json.Unmarshal(privVal, &keyFile); err != nil {
+		return "", fmt.Errorf("unmarshal priv_validator_key.json: %w", err)
+	}
+	addr, err := hex.DecodeString(keyFile.Address)
+	if err != nil {
+		return "", fmt.Errorf("decode consensus address: %w", err)
+	}
+	return tn.Chain.(*CosmosChain).ConsAddressToBech32(sdk.ConsAddress(addr))
+}
+
+// InduceDowntime stops val's node and snapshots its signing info at every block, height by height,
+// until the slashing module jails it for missing too many blocks of the signed_blocks_window, or until timeout passes.
+// Reaching the timeout is not an error: the report's Jailed is false.
+//
+// The node is left stopped; RecoverFromDowntime restarts and unjails it.
+// The remaining validators must hold more than 2/3 of the voting power for the chain to keep producing blocks.
+// The chain's first validator cannot be taken offline, as the chain is queried through it.
+func (c *CosmosChain) InduceDowntime(ctx context.Context, val *ChainNode, timeout time.Duration) (*DowntimeReport, error) {
+	if val == c.GetNode() {
+		return nil, fmt.Errorf("cannot take %s offline, the chain is queried through it", val.Name())
+	}
+
+	consAddr, err := val.ValidatorConsAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valAddr, err := val.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return nil, fmt.Errorf("stop %s: %w", val.Name(), err)
+	}
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &DowntimeReport{ConsAddress: consAddr, StoppedHeight: height}
+	c.log.Info("Stopped validator to induce downtime",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.String("validator", val.Name()),
+		zap.Int64("height", height),
+	)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// timedOut reports whether err was caused by the timeout rather than by ctx.
+	timedOut := func() bool { return waitCtx.Err() != nil && ctx.Err() == nil }
+	for next := height + 1; ; {
+		if err := testutil.WaitForBlocks(waitCtx, 1, c); err != nil {
+			if timedOut() {
+				return report, nil
+			}
+			return report, err
+		}
+		latest, err := c.Height(waitCtx)
+		if err != nil {
+			if timedOut() {
+				return report, nil
+			}
+			return report, err
+		}
+
+		// Every block committed since the last snapshot is snapshotted at its own height.
+		for ; next <= latest; next++ {
+			snapshot, err := c.signingInfoSnapshot(waitCtx, next, consAddr, valAddr)
+			if err != nil {
+				if timedOut() {
+					return report, nil
+				}
+				return report, err
+			}
+			report.Snapshots = append(report.Snapshots, snapshot)
+			if snapshot.Jailed {
+				report.Jailed = true
+				report.JailedHeight = snapshot.Height
+				c.log.Info("Validator jailed for downtime",
+					zap.String("chain_id", c.cfg.ChainID),
+					zap.String("validator", val.Name()),
+					zap.Int64("height", snapshot.Height),
+					zap.Int64("missed_blocks", report.MaxMissedBlocks()),
+				)
+				return report, nil
+			}
+		}
+	}
+}
+
+// RecoverFromDowntime restarts val's node, waits out the downtime jail duration, unjails val,
+// and returns its signing info once it is back in the active set.
+func (c *CosmosChain) RecoverFromDowntime(ctx context.Context, val *ChainNode) (SigningInfoSnapshot, error) {
+	consAddr, err := val.ValidatorConsAddress(ctx)
+	if err != nil {
+		return SigningInfoSnapshot{}, err
+	}
+	valAddr, err := val.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return SigningInfoSnapshot{}, err
+	}
+
+	if err := val.StartContainer(ctx); err != nil {
+		return SigningInfoSnapshot{}, fmt.Errorf("start %s: %w", val.Name(), err)
+	}
+
+	// Unjailing is only allowed once the block time passes the signing info's JailedUntil.
+	for {
+		snapshot, err := c.signingInfoSnapshot(ctx, 0, consAddr, valAddr)
+		if err != nil {
+			return SigningInfoSnapshot{}, err
+		}
+		if snapshot.BlockTime.After(snapshot.Info.JailedUntil) {
+			break
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return SigningInfoSnapshot{}, err
+		}
+	}
+
+	if _, err := val.SlashingUnJail(ctx, valKey); err != nil {
+		return SigningInfoSnapshot{}, fmt.Errorf("unjail %s: %w", valAddr, err)
+	}
+	// The validator rejoins the active set at the end of the block unjailing it.
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return SigningInfoSnapshot{}, err
+	}
+
+	snapshot, err := c.signingInfoSnapshot(ctx, 0, consAddr, valAddr)
+	if err != nil {
+		return SigningInfoSnapshot{}, err
+	}
+	if snapshot.Jailed {
+		return snapshot, fmt.Errorf("validator %s is still jailed after unjailing", valAddr)
+	}
+	return snapshot, nil
+}
+
+// signingInfoSnapshot queries the signing info of consAddr and the jailed flag of valAddr as of the block at height,
+// or of the latest block if height is zero. Both are queried at that block's height, so that the snapshot is consistent
+// even if blocks are committed meanwhile.
+func (c *CosmosChain) signingInfoSnapshot(ctx context.Context, height int64, consAddr, valAddr string) (SigningInfoSnapshot, error) {
+	var heightPtr *int64
+	if height > 0 {
+		heightPtr = &height
+	}
+	block, err := c.GetNode().Client.Block(ctx, heightPtr)
+	if err != nil {
+		return SigningInfoSnapshot{}, fmt.Errorf("query block at height %d: %w", height, err)
+	}
+	heightCtx := metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(block.Block.Height, 10))
+	info, err := c.SlashingQuerySigningInfo(heightCtx, consAddr)
+	if err != nil {
+		return SigningInfoSnapshot{}, fmt.Errorf("query signing info of %s at height %d: %w", consAddr, block.Block.Height, err)
+	}
+	validator, err := c.StakingQueryValidator(heightCtx, valAddr)
+	if err != nil {
+		return SigningInfoSnapshot{}, fmt.Errorf("query validator %s at height %d: %w", valAddr, block.Block.Height, err)
+	}
+	return SigningInfoSnapshot{
+		Height:    block.Block.Height,
+		BlockTime: block.Block.Time,
+		Jailed:    validator.Jailed,
+		Info:      *info,
+	}, nil
+}
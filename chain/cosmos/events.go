@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"encoding/base64"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+)
+
+// Events is a list of ABCI events, such as those emitted by a transaction, with helpers to query them.
+// Attributes are matched as-is and, for nodes running tendermint < v0.37-alpha, base64 decoded.
+type Events []abcitypes.Event
+
+// OfType returns the events of type eventType, in order.
+func (evs Events) OfType(eventType string) Events {
+	var found Events
+	for _, event := range evs {
+		if event.Type == eventType {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+// WithAttribute returns the events with an attribute attrKey set to value, in order.
+func (evs Events) WithAttribute(attrKey, value string) Events {
+	var found Events
+	for _, event := range evs {
+		for _, attr := range event.Attributes {
+			if v, ok := attributeValue(attr, attrKey); ok && v == value {
+				found = append(found, event)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// AttributeValue returns an event attribute value given the eventType and attribute key tuple.
+// In the event of duplicate types and keys, returns the first attribute value found.
+// If not found, returns empty string and false.
+func (evs Events) AttributeValue(eventType, attrKey string) (string, bool) {
+	values := evs.OfType(eventType).attributeValues(attrKey, 1)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// AttributeValues returns the values of every attrKey attribute of events of type eventType, in order.
+func (evs Events) AttributeValues(eventType, attrKey string) []string {
+	return evs.OfType(eventType).attributeValues(attrKey, -1)
+}
+
+// attributeValues returns up to limit values of attrKey attributes, or all of them if limit is negative.
+func (evs Events) attributeValues(attrKey string, limit int) []string {
+	var values []string
+	for _, event := range evs {
+		for _, attr := range event.Attributes {
+			if limit >= 0 && len(values) == limit {
+				return values
+			}
+			if v, ok := attributeValue(attr, attrKey); ok {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func attributeValue(attr abcitypes.EventAttribute, attrKey string) (string, bool) {
+	if attr.Key == attrKey {
+		return attr.Value, true
+	}
+
+	// tendermint < v0.37-alpha returns base64 encoded strings in events.
+	key, err := base64.StdEncoding.DecodeString(attr.Key)
+	if err != nil || string(key) != attrKey {
+		return "", false
+	}
+	value, err := base64.StdEncoding.DecodeString(attr.Value)
+	if err != nil {
+		return "", false
+	}
+	return string(value), true
+}
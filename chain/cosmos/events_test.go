@@ -0,0 +1,144 @@
+package cosmos_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+)
+
+func TestEventsAttributeValue(t *testing.T) {
+	events := cosmos.Events{
+		{Type: "1", Attributes: []abcitypes.EventAttribute{
+			{Key: "ignore", Value: "should not see me"},
+			{Key: "key1", Value: "found1"},
+		}},
+		{Type: "2", Attributes: []abcitypes.EventAttribute{
+			{Key: "key2", Value: "found2"},
+			{Key: "ignore", Value: "should not see me"},
+		}},
+	}
+
+	_, ok := cosmos.Events(nil).AttributeValue("test", "")
+	require.False(t, ok)
+
+	_, ok = events.AttributeValue("key_not_there", "ignored")
+	require.False(t, ok)
+
+	_, ok = events.AttributeValue("1", "attribute not there")
+	require.False(t, ok)
+
+	found, ok := events.AttributeValue("1", "key1")
+	require.True(t, ok)
+	require.Equal(t, "found1", found)
+
+	found, ok = events.AttributeValue("2", "key2")
+	require.True(t, ok)
+	require.Equal(t, "found2", found)
+}
+
+func TestEventsQuery(t *testing.T) {
+	b64 := base64.StdEncoding.EncodeToString
+	events := cosmos.Events{
+		{Type: "transfer", Attributes: []abcitypes.EventAttribute{
+			{Key: "recipient", Value: "alice"},
+			{Key: "amount", Value: "1stake"},
+		}},
+		{Type: "message", Attributes: []abcitypes.EventAttribute{
+			{Key: "sender", Value: "bob"},
+		}},
+		// tendermint < v0.37-alpha base64 encodes attributes.
+		{Type: "transfer", Attributes: []abcitypes.EventAttribute{
+			{Key: b64([]byte("recipient")), Value: b64([]byte("carol"))},
+			{Key: b64([]byte("amount")), Value: b64([]byte("2stake"))},
+		}},
+	}
+
+	require.Len(t, events.OfType("transfer"), 2)
+	require.Empty(t, events.OfType("burn"))
+
+	require.Equal(t, []string{"alice", "carol"}, events.AttributeValues("transfer", "recipient"))
+	require.Empty(t, events.AttributeValues("message", "recipient"))
+
+	withCarol := events.WithAttribute("recipient", "carol")
+	require.Len(t, withCarol, 1)
+	amount, ok := withCarol.AttributeValue("transfer", "amount")
+	require.True(t, ok)
+	require.Equal(t, "2stake", amount)
+}
+
+func TestNewTxResult(t *testing.T) {
+	sendResp, err := codectypes.NewAnyWithValue(&banktypes.MsgSendResponse{})
+	require.NoError(t, err)
+	multiSendResp, err := codectypes.NewAnyWithValue(&banktypes.MsgMultiSendResponse{})
+	require.NoError(t, err)
+
+	msgData := sdk.TxMsgData{MsgResponses: []*codectypes.Any{sendResp, multiSendResp, sendResp}}
+	data, err := msgData.Marshal()
+	require.NoError(t, err)
+
+	res := &sdk.TxResponse{
+		TxHash:    "ABCD",
+		Height:    10,
+		GasWanted: 200_000,
+		GasUsed:   100_000,
+		Data:      hex.EncodeToString(data),
+		Events: []abcitypes.Event{
+			{Type: "message", Attributes: []abcitypes.EventAttribute{{Key: "sender", Value: "bob"}}},
+		},
+	}
+
+	result, err := cosmos.NewTxResult[*banktypes.MsgSendResponse](res)
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", result.TxHash)
+	require.EqualValues(t, 10, result.Height)
+	require.EqualValues(t, 200_000, result.GasWanted)
+	require.EqualValues(t, 100_000, result.GasUsed)
+	require.Same(t, res, result.TxResponse)
+	require.Len(t, result.MsgResponses, 2)
+	_, ok := result.MsgResponse()
+	require.True(t, ok)
+	sender, ok := result.Events.AttributeValue("message", "sender")
+	require.True(t, ok)
+	require.Equal(t, "bob", sender)
+
+	raw, err := cosmos.NewTxResult[*codectypes.Any](res)
+	require.NoError(t, err)
+	require.Len(t, raw.MsgResponses, 3)
+
+	res.Data = "not hex"
+	_, err = cosmos.NewTxResult[*banktypes.MsgSendResponse](res)
+	require.Error(t, err)
+}
+
+func TestNewTxResultTokenFactory(t *testing.T) {
+	denom := "factory/cosmos1abc/ictest"
+	// MsgCreateDenomResponse with new_token_denom (field 1) set.
+	value := append([]byte{0x0a, byte(len(denom))}, denom...)
+	msgData := sdk.TxMsgData{MsgResponses: []*codectypes.Any{
+		{TypeUrl: "/osmosis.tokenfactory.v1beta1.MsgCreateDenomResponse", Value: value},
+		{TypeUrl: "/osmosis.tokenfactory.v1beta1.MsgMintResponse"},
+	}}
+	data, err := msgData.Marshal()
+	require.NoError(t, err)
+	res := &sdk.TxResponse{Data: hex.EncodeToString(data)}
+
+	created, err := cosmos.NewTxResult[*cosmos.TokenFactoryCreateDenomResponse](res)
+	require.NoError(t, err)
+	resp, ok := created.MsgResponse()
+	require.True(t, ok)
+	require.Equal(t, denom, resp.NewTokenDenom)
+
+	minted, err := cosmos.NewTxResult[*cosmos.TokenFactoryMintResponse](res)
+	require.NoError(t, err)
+	require.Len(t, minted.MsgResponses, 1)
+}
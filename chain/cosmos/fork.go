@@ -0,0 +1,174 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// RelinkOptions describes an IBC path linked by RestartFromExport between the restarted chain and a counterparty.
+type RelinkOptions struct {
+	Relayer      ibc.Relayer
+	Reporter     ibc.RelayerExecReporter
+	Counterparty ibc.Chain
+	// Path is the name of the new path. It must not be one of the chain's existing paths,
+	// whose clients track the chain's blocks from before the restart.
+	Path string
+
+	// ClientOpts and ChannelOpts are the options the path is linked with, the defaults if zero.
+	ClientOpts  ibc.CreateClientOptions
+	ChannelOpts ibc.CreateChannelOptions
+}
+
+// RestartFromExport restarts the chain from its state exported at height, as in a hard fork or chain ID migration.
+// It waits for the chain to reach height, stops all nodes, and exports the state at height.
+// If mutateFn is set, it is applied to the exported genesis, with the same signature as ChainConfig.ModifyGenesis,
+// so e.g. ModifyGenesis([]GenesisKV{NewGenesisKV("chain_id", "newchain-2")}) migrates the chain ID.
+// Every node's data is then reset with UnsafeResetAll, the genesis replaced, and the nodes restarted.
+//
+// If the chain ID changes, the chain's config is updated to the new chain ID.
+// The IBC clients of the chain on its counterparties cannot follow the restarted chain: its blocks past height
+// conflict with the ones they were updated with, and a client cannot be updated across a chain ID change.
+// For each of relink, the restarted chain is linked to the counterparty with new clients, connections and channels
+// on a new path. If the chain ID changed, the new chain ID is first added to the relayer, with the relayer's key
+// of the previous chain ID. The previous paths, and the IBC state of the chain, are left as they are.
+func (c *CosmosChain) RestartFromExport(ctx context.Context, height int64, mutateFn func(ibc.ChainConfig, []byte) ([]byte, error), relink ...RelinkOptions) error {
+	current, err := c.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if current < height {
+		if err := testutil.WaitForBlocks(ctx, int(height-current), c); err != nil {
+			return fmt.Errorf("wait for export height %d: %w", height, err)
+		}
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return fmt.Errorf("stop nodes: %w", err)
+	}
+
+	state, err := c.ExportState(ctx, height)
+	if err != nil {
+		return fmt.Errorf("export state at height %d: %w", height, err)
+	}
+	genbz := []byte(state)
+	if mutateFn != nil {
+		if genbz, err = mutateFn(c.Config(), genbz); err != nil {
+			return fmt.Errorf("mutate exported genesis: %w", err)
+		}
+	}
+
+	chainID, err := genesisChainID(genbz)
+	if err != nil {
+		return err
+	}
+	prevChainID := c.cfg.ChainID
+	if chainID != c.cfg.ChainID {
+		c.log.Info("Migrating chain ID",
+			zap.String("from", c.cfg.ChainID),
+			zap.String("to", chainID),
+		)
+		c.cfg.ChainID = chainID
+	}
+
+	var eg errgroup.Group
+	for _, n := range c.Nodes() {
+		eg.Go(func() error {
+			if err := n.UnsafeResetAll(ctx); err != nil {
+				return fmt.Errorf("reset %s: %w", n.Name(), err)
+			}
+			return n.OverwriteGenesisFile(ctx, genbz)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fmt.Errorf("start nodes: %w", err)
+	}
+	if err := testutil.WaitForBlocks(ctx, 2, c); err != nil {
+		return err
+	}
+	return c.relink(ctx, prevChainID, relink)
+}
+
+// relink links the paths of links between the restarted chain and its counterparties.
+// If the chain ID changed from prevChainID, each relayer is first configured for the new chain ID.
+func (c *CosmosChain) relink(ctx context.Context, prevChainID string, links []RelinkOptions) error {
+	configured := make(map[ibc.Relayer]bool)
+	for _, l := range links {
+		r := l.Relayer
+		if c.cfg.ChainID != prevChainID && !configured[r] {
+			if err := c.addRelayerChainID(ctx, r, l.Reporter, prevChainID); err != nil {
+				return err
+			}
+			configured[r] = true
+		}
+
+		clientOpts, channelOpts := l.ClientOpts, l.ChannelOpts
+		if clientOpts == (ibc.CreateClientOptions{}) {
+			clientOpts = ibc.DefaultClientOpts()
+		}
+		if channelOpts == (ibc.CreateChannelOptions{}) {
+			channelOpts = ibc.DefaultChannelOpts()
+		}
+
+		cpChainID := l.Counterparty.Config().ChainID
+		if err := r.GeneratePath(ctx, l.Reporter, c.cfg.ChainID, cpChainID, l.Path); err != nil {
+			return fmt.Errorf("generate path %s between %s and %s: %w", l.Path, c.cfg.ChainID, cpChainID, err)
+		}
+		if err := r.LinkPath(ctx, l.Reporter, l.Path, channelOpts, clientOpts); err != nil {
+			return fmt.Errorf("link path %s between %s and %s: %w", l.Path, c.cfg.ChainID, cpChainID, err)
+		}
+		c.log.Info("Relinked restarted chain",
+			zap.String("chain_id", c.cfg.ChainID),
+			zap.String("counterparty", cpChainID),
+			zap.String("path", l.Path),
+		)
+	}
+	return nil
+}
+
+// addRelayerChainID adds the chain's configuration under its new chain ID to r,
+// and restores r's key of prevChainID for it, so that r signs with the same account as before the restart.
+func (c *CosmosChain) addRelayerChainID(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, prevChainID string) error {
+	wallet, ok := r.GetWallet(prevChainID)
+	if !ok {
+		return fmt.Errorf("relayer has no wallet for chain %s", prevChainID)
+	}
+
+	rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+	if !r.UseDockerNetwork() {
+		rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+	}
+	keyName := c.cfg.ChainID
+	if err := r.AddChainConfiguration(ctx, rep, c.cfg, keyName, rpcAddr, grpcAddr); err != nil {
+		return fmt.Errorf("add chain %s to relayer: %w", c.cfg.ChainID, err)
+	}
+	if err := r.RestoreKey(ctx, rep, c.cfg, keyName, wallet.Mnemonic()); err != nil {
+		return fmt.Errorf("restore relayer key for chain %s: %w", c.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// genesisChainID returns the chain ID of the genesis file genbz.
+func genesisChainID(genbz []byte) (string, error) {
+	var genesis struct {
+		ChainID string `json:"chain_id"`
+	}
+	if err := json.Unmarshal(genbz, &genesis); err != nil {
+		return "", fmt.Errorf("unmarshal genesis: %w", err)
+	}
+	if genesis.ChainID == "" {
+		return "", errors.New("genesis has no chain_id")
+	}
+	return genesis.ChainID, nil
+}
@@ -0,0 +1,57 @@
+package cosmos
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/cosmos/interchaintest/v11/testreporter"
+)
+
+// SetGasProfiler records the gas wanted and used by every transaction committed through the chain's
+// helpers, such as ExecTx, the module helpers, Broadcaster and SendIBCTransfer, in p.
+// Failed transactions are not recorded, as the gas they used does not reflect their messages' cost.
+// A nil p stops profiling.
+func (c *CosmosChain) SetGasProfiler(p *testreporter.GasProfiler) {
+	c.gasProfilerMu.Lock()
+	defer c.gasProfilerMu.Unlock()
+	c.gasProfiler = p
+}
+
+// trackGas records the gas of the committed transaction res in the chain's GasProfiler, if any,
+// unless the transaction failed.
+func (tn *ChainNode) trackGas(res *sdk.TxResponse) {
+	chain, ok := tn.Chain.(*CosmosChain)
+	if !ok || res.Code != 0 {
+		return
+	}
+	chain.gasProfilerMu.Lock()
+	p := chain.gasProfiler
+	chain.gasProfilerMu.Unlock()
+	if p == nil {
+		return
+	}
+	p.Track(testreporter.GasRecord{
+		TestName:    chain.testName,
+		ChainID:     chain.cfg.ChainID,
+		TxHash:      res.TxHash,
+		MsgTypeURLs: msgTypeURLs(res),
+		GasWanted:   res.GasWanted,
+		GasUsed:     res.GasUsed,
+	})
+}
+
+// msgTypeURLs returns the type URLs of the messages of the transaction in res.
+func msgTypeURLs(res *sdk.TxResponse) []string {
+	if res.Tx == nil {
+		return nil
+	}
+	var tx txtypes.Tx
+	if err := tx.Unmarshal(res.Tx.Value); err != nil || tx.Body == nil {
+		return nil
+	}
+	urls := make([]string, len(tx.Body.Messages))
+	for i, msg := range tx.Body.Messages {
+		urls[i] = msg.TypeUrl
+	}
+	return urls
+}
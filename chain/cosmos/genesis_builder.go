@@ -0,0 +1,190 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// GenesisBuilder decodes the app state of a genesis file into the SDK module genesis types,
+// so that it can be edited as Go structs, and validated with each module's ValidateGenesis.
+// Unlike GenesisKV paths, decoding is strict: unknown fields and mistyped values are errors.
+type GenesisBuilder struct {
+	cdc      codec.JSONCodec
+	txConfig client.TxEncodingConfig
+
+	genesis  map[string]json.RawMessage
+	appState map[string]json.RawMessage
+	// edited holds the modules whose state was set, in the order they were first set.
+	edited []string
+}
+
+// NewGenesisBuilder returns a GenesisBuilder for genbz, decoding module states with the codec of enc,
+// which must register every interface type used by the edited module states.
+func NewGenesisBuilder(enc testutil.TestEncodingConfig, genbz []byte) (*GenesisBuilder, error) {
+	b := &GenesisBuilder{cdc: enc.Codec, txConfig: enc.TxConfig}
+	if err := json.Unmarshal(genbz, &b.genesis); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	if err := json.Unmarshal(b.genesis["app_state"], &b.appState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis app_state: %w", err)
+	}
+	return b, nil
+}
+
+// Module decodes the genesis state of module into state, e.g. a *govv1.GenesisState for "gov".
+func (b *GenesisBuilder) Module(module string, state proto.Message) error {
+	bz, ok := b.appState[module]
+	if !ok {
+		return fmt.Errorf("genesis has no %s module state", module)
+	}
+	if err := b.cdc.UnmarshalJSON(bz, state); err != nil {
+		return fmt.Errorf("failed to decode %s genesis: %w", module, err)
+	}
+	return nil
+}
+
+// SetModule encodes state as the genesis state of module.
+func (b *GenesisBuilder) SetModule(module string, state proto.Message) error {
+	bz, err := b.cdc.MarshalJSON(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s genesis: %w", module, err)
+	}
+	b.appState[module] = bz
+	if !slices.Contains(b.edited, module) {
+		b.edited = append(b.edited, module)
+	}
+	return nil
+}
+
+// Edited returns the modules whose state was set with SetModule or EditModuleGenesis.
+func (b *GenesisBuilder) Edited() []string {
+	return slices.Clone(b.edited)
+}
+
+// EditModuleGenesis decodes the genesis state of module, applies edit to it, and encodes it back. For example:
+//
+//	err := cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+//		g.Params.VotingPeriod = &votingPeriod
+//		return nil
+//	})
+func EditModuleGenesis[S any, T interface {
+	*S
+	proto.Message
+}](b *GenesisBuilder, module string, edit func(T) error) error {
+	state := T(new(S))
+	if err := b.Module(module, state); err != nil {
+		return err
+	}
+	if err := edit(state); err != nil {
+		return fmt.Errorf("failed to edit %s genesis: %w", module, err)
+	}
+	return b.SetModule(module, state)
+}
+
+// Validate runs ValidateGenesis of each of modules whose state is present in the genesis.
+func (b *GenesisBuilder) Validate(modules ...module.AppModuleBasic) error {
+	for _, m := range modules {
+		mod, ok := m.(module.HasGenesisBasics)
+		if !ok {
+			continue
+		}
+		bz, ok := b.appState[m.Name()]
+		if !ok {
+			continue
+		}
+		if err := mod.ValidateGenesis(b.cdc, b.txConfig, bz); err != nil {
+			return fmt.Errorf("invalid %s genesis: %w", m.Name(), err)
+		}
+	}
+	return nil
+}
+
+// ValidateEdited runs ValidateGenesis of each edited module, as implemented by the module of the same name
+// in DefaultModuleBasics. Edited modules which are not part of DefaultModuleBasics are not validated;
+// pass their AppModuleBasic to Validate instead.
+func (b *GenesisBuilder) ValidateEdited() error {
+	return b.Validate(ModuleBasics(b.edited...)...)
+}
+
+// ModuleBasics returns the modules of DefaultModuleBasics with the given names.
+func ModuleBasics(names ...string) []module.AppModuleBasic {
+	var modules []module.AppModuleBasic
+	for _, m := range DefaultModuleBasics() {
+		if slices.Contains(names, m.Name()) {
+			modules = append(modules, m)
+		}
+	}
+	return modules
+}
+
+// Bytes encodes the genesis file, with the edited app state.
+func (b *GenesisBuilder) Bytes() ([]byte, error) {
+	appState, err := json.Marshal(b.appState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis app_state: %w", err)
+	}
+	b.genesis["app_state"] = appState
+	out, err := json.Marshal(b.genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// ModifyGenesisState returns a ChainConfig.ModifyGenesis function which applies edit to a GenesisBuilder
+// of the genesis, and then validates the genesis states of the modules edit changed, with ValidateEdited.
+// If modules are given, their genesis states are validated instead, e.g. DefaultModuleBasics() to validate
+// every module, including the ones edit left as they were.
+// Invalid genesis states fail the chain's Start before its nodes are started.
+// The builder decodes with the chain's EncodingConfig, or DefaultEncoding if it is not set.
+func ModifyGenesisState(edit func(*GenesisBuilder) error, modules ...module.AppModuleBasic) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		enc := DefaultEncoding()
+		if chainConfig.EncodingConfig != nil {
+			enc = *chainConfig.EncodingConfig
+		}
+		b, err := NewGenesisBuilder(enc, genbz)
+		if err != nil {
+			return nil, err
+		}
+		if edit != nil {
+			if err := edit(b); err != nil {
+				return nil, err
+			}
+		}
+		if len(modules) == 0 {
+			err = b.ValidateEdited()
+		} else {
+			err = b.Validate(modules...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return b.Bytes()
+	}
+}
+
+// ValidateGenesis validates a genesis file with the ValidateGenesis of each of modules. For example,
+// it checks the result of ModifyGenesis with GenesisKV paths under app_state.gov with ModuleBasics("gov"),
+// or every module with DefaultModuleBasics().
+func ValidateGenesis(enc testutil.TestEncodingConfig, genbz []byte, modules ...module.AppModuleBasic) error {
+	if len(modules) == 0 {
+		return errors.New("no modules to validate the genesis with")
+	}
+	b, err := NewGenesisBuilder(enc, genbz)
+	if err != nil {
+		return err
+	}
+	return b.Validate(modules...)
+}
@@ -0,0 +1,131 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/module"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// defaultGenesis returns a genesis file with the default genesis state of every module of cosmos.DefaultEncoding.
+func defaultGenesis(t *testing.T) []byte {
+	t.Helper()
+	enc := cosmos.DefaultEncoding()
+	appState, err := json.Marshal(module.NewBasicManager(cosmos.DefaultModuleBasics()...).DefaultGenesis(enc.Codec))
+	require.NoError(t, err)
+	genbz, err := json.Marshal(map[string]any{
+		"chain_id":  "test-1",
+		"app_state": json.RawMessage(appState),
+	})
+	require.NoError(t, err)
+	return genbz
+}
+
+func TestGenesisBuilderEditModule(t *testing.T) {
+	enc := cosmos.DefaultEncoding()
+	b, err := cosmos.NewGenesisBuilder(enc, defaultGenesis(t))
+	require.NoError(t, err)
+
+	votingPeriod := 10 * time.Second
+	require.NoError(t, cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+		g.Params.VotingPeriod = &votingPeriod
+		return nil
+	}))
+	require.NoError(t, b.Validate(cosmos.DefaultModuleBasics()...))
+
+	genbz, err := b.Bytes()
+	require.NoError(t, err)
+
+	b, err = cosmos.NewGenesisBuilder(enc, genbz)
+	require.NoError(t, err)
+	var gov govv1.GenesisState
+	require.NoError(t, b.Module("gov", &gov))
+	require.Equal(t, votingPeriod, *gov.Params.VotingPeriod)
+
+	var genesis map[string]any
+	require.NoError(t, json.Unmarshal(genbz, &genesis))
+	require.Equal(t, "test-1", genesis["chain_id"])
+}
+
+func TestModifyGenesisStateValidates(t *testing.T) {
+	modify := cosmos.ModifyGenesisState(func(b *cosmos.GenesisBuilder) error {
+		return cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+			g.Params.Quorum = "2"
+			return nil
+		})
+	})
+	_, err := modify(ibc.ChainConfig{}, defaultGenesis(t))
+	require.ErrorContains(t, err, "invalid gov genesis")
+
+	modify = cosmos.ModifyGenesisState(func(b *cosmos.GenesisBuilder) error {
+		return cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+			g.Params.Quorum = "0.5"
+			return nil
+		})
+	})
+	_, err = modify(ibc.ChainConfig{}, defaultGenesis(t))
+	require.NoError(t, err)
+}
+
+func TestValidateGenesisKV(t *testing.T) {
+	enc := cosmos.DefaultEncoding()
+	for _, tc := range []struct {
+		name    string
+		kv      cosmos.GenesisKV
+		wantErr string
+	}{
+		{
+			name:    "bad value",
+			kv:      cosmos.NewGenesisKV("app_state.gov.params.voting_period", "bad"),
+			wantErr: "bad Duration",
+		},
+		{
+			name:    "typo",
+			kv:      cosmos.NewGenesisKV("app_state.gov.params.votng_period", "10s"),
+			wantErr: "votng_period",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			genbz, err := cosmos.ModifyGenesis([]cosmos.GenesisKV{tc.kv})(ibc.ChainConfig{}, defaultGenesis(t))
+			require.NoError(t, err)
+			require.ErrorContains(t, cosmos.ValidateGenesis(enc, genbz, cosmos.ModuleBasics("gov")...), tc.wantErr)
+			require.ErrorContains(t, cosmos.ValidateGenesis(enc, genbz, cosmos.DefaultModuleBasics()...), tc.wantErr)
+		})
+	}
+
+	require.NoError(t, cosmos.ValidateGenesis(enc, defaultGenesis(t), cosmos.DefaultModuleBasics()...))
+	require.Error(t, cosmos.ValidateGenesis(enc, defaultGenesis(t)))
+}
+
+func TestModifyGenesisStateValidatesEditedModules(t *testing.T) {
+	// A genesis with an invalid staking state, which is not edited below.
+	b, err := cosmos.NewGenesisBuilder(cosmos.DefaultEncoding(), defaultGenesis(t))
+	require.NoError(t, err)
+	require.NoError(t, cosmos.EditModuleGenesis(b, "staking", func(g *stakingtypes.GenesisState) error {
+		g.Params.BondDenom = ""
+		return nil
+	}))
+	require.Equal(t, []string{"staking"}, b.Edited())
+	genbz, err := b.Bytes()
+	require.NoError(t, err)
+
+	editGov := func(b *cosmos.GenesisBuilder) error {
+		return cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+			g.Params.Quorum = "0.5"
+			return nil
+		})
+	}
+	_, err = cosmos.ModifyGenesisState(editGov)(ibc.ChainConfig{}, genbz)
+	require.NoError(t, err)
+
+	_, err = cosmos.ModifyGenesisState(editGov, cosmos.DefaultModuleBasics()...)(ibc.ChainConfig{}, genbz)
+	require.ErrorContains(t, err, "invalid staking genesis")
+}
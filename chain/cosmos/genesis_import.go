@@ -0,0 +1,906 @@
+package cosmos
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// zeroTime is the JSON encoding of the zero timestamp in genesis files.
+const zeroTime = "1970-01-01T00:00:00Z"
+
+// TestnetValidator is a validator replacing the validator set of an exported genesis.
+type TestnetValidator struct {
+	Moniker string
+	// AccountAddress, OperatorAddress and ConsAddress are the bech32 account, operator and consensus addresses of the validator.
+	AccountAddress, OperatorAddress, ConsAddress string
+	// ConsPubKey is the base64 encoded ed25519 consensus public key of the validator.
+	ConsPubKey string
+	// Tokens is the validator's bonded self-delegation, in the staking bond denom.
+	Tokens sdkmath.Int
+}
+
+// TestnetifyOptions configures TestnetifyGenesis.
+type TestnetifyOptions struct {
+	ChainID string
+	// Bech32Prefix is the account address prefix of the chain.
+	Bech32Prefix string
+	// Validators replace the validator set of the genesis. At least one is required.
+	Validators []TestnetValidator
+	// Accounts are funded in the genesis, and created if they do not exist.
+	Accounts []ibc.WalletAmount
+	// PowerReduction converts tokens to consensus power, sdk.DefaultPowerReduction if nil.
+	PowerReduction *sdkmath.Int
+}
+
+// testnetifyModules are the modules of app_state rewritten by TestnetifyGenesis.
+var testnetifyModules = []string{"staking", "slashing", "distribution", "bank", "auth"}
+
+// TestnetifyGenesis reads an exported genesis from r, which may be gzip-compressed, and writes a genesis to w
+// which starts a chain with the given validators from the exported state.
+// The validators, delegations and signing infos of the exported validator set are dropped,
+// and its outstanding rewards are moved to the community pool, so that the staking, bank and distribution
+// module balances remain consistent. The consensus validator set is emptied, to be taken from the staking module.
+//
+// The genesis is streamed from r to w: only the states of the rewritten staking, slashing, distribution, bank
+// and auth modules are held in memory together, and every other module state is copied one at a time.
+// The rewritten module states are written last in app_state.
+func TestnetifyGenesis(r io.Reader, w io.Writer, opts TestnetifyOptions) error {
+	if len(opts.Validators) == 0 {
+		return errors.New("at least one validator is required")
+	}
+
+	r, err := maybeGunzip(r)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(r)
+	bw := bufio.NewWriter(w)
+	genesis := &objectWriter{w: bw}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return fmt.Errorf("decode genesis: %w", err)
+	}
+	if err := bw.WriteByte('{'); err != nil {
+		return err
+	}
+	var hasAppState bool
+	for dec.More() {
+		key, err := objectKey(dec)
+		if err != nil {
+			return fmt.Errorf("decode genesis: %w", err)
+		}
+		if key == "app_state" {
+			hasAppState = true
+			if err := genesis.key(key); err != nil {
+				return err
+			}
+			if err := testnetifyAppState(dec, bw, opts); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("decode %s: %w", key, err)
+		}
+		switch key {
+		case "chain_id":
+			// Written below.
+			continue
+		case "validators":
+			// Legacy genesis files hold the consensus validator set at the top level.
+			value = json.RawMessage("[]")
+		case "consensus":
+			if value, err = clearConsensusValidators(value); err != nil {
+				return err
+			}
+		}
+		if err := genesis.field(key, value); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return fmt.Errorf("decode genesis: %w", err)
+	}
+	if !hasAppState {
+		return errors.New("genesis has no app_state")
+	}
+
+	chainID, err := json.Marshal(opts.ChainID)
+	if err != nil {
+		return err
+	}
+	if err := genesis.field("chain_id", chainID); err != nil {
+		return err
+	}
+	if err := bw.WriteByte('}'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// testnetifyAppState reads the app_state object from dec and writes it to w with the testnetifyModules rewritten.
+// The other modules are copied as they are read, while the testnetifyModules are held until the end of app_state,
+// as the bank state depends on the staking state.
+func testnetifyAppState(dec *json.Decoder, w *bufio.Writer, opts TestnetifyOptions) error {
+	powerReduction := sdk.DefaultPowerReduction
+	if opts.PowerReduction != nil {
+		powerReduction = *opts.PowerReduction
+	}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return fmt.Errorf("decode app_state: %w", err)
+	}
+	if err := w.WriteByte('{'); err != nil {
+		return err
+	}
+	out := &objectWriter{w: w}
+	appState := make(map[string]json.RawMessage, len(testnetifyModules))
+	for dec.More() {
+		module, err := objectKey(dec)
+		if err != nil {
+			return fmt.Errorf("decode app_state: %w", err)
+		}
+		var state json.RawMessage
+		if err := dec.Decode(&state); err != nil {
+			return fmt.Errorf("decode %s state: %w", module, err)
+		}
+		if slices.Contains(testnetifyModules, module) {
+			appState[module] = state
+			continue
+		}
+		if err := out.field(module, state); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return fmt.Errorf("decode app_state: %w", err)
+	}
+
+	bondDenom, err := testnetifyStaking(appState, opts.Validators, powerReduction)
+	if err != nil {
+		return fmt.Errorf("rewrite staking state: %w", err)
+	}
+	if err := testnetifySlashing(appState, opts.Validators); err != nil {
+		return fmt.Errorf("rewrite slashing state: %w", err)
+	}
+	if err := testnetifyDistribution(appState, opts.Validators); err != nil {
+		return fmt.Errorf("rewrite distribution state: %w", err)
+	}
+	if err := testnetifyBank(appState, opts, bondDenom); err != nil {
+		return fmt.Errorf("rewrite bank state: %w", err)
+	}
+	if err := testnetifyAuth(appState, opts.Accounts); err != nil {
+		return fmt.Errorf("rewrite auth state: %w", err)
+	}
+
+	for _, module := range testnetifyModules {
+		if err := out.field(module, appState[module]); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte('}')
+}
+
+// maybeGunzip returns a reader decompressing r if it starts with the gzip magic number, or reading r as is.
+func maybeGunzip(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read genesis: %w", err)
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("decompress genesis: %w", err)
+		}
+		return gz, nil
+	}
+	return br, nil
+}
+
+// expectDelim reads the next token from dec, which must be delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %s, got %v", delim, tok)
+	}
+	return nil
+}
+
+// objectKey reads the next key of an object from dec.
+func objectKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+// objectWriter writes the fields of a JSON object, whose braces are written by the caller.
+type objectWriter struct {
+	w      *bufio.Writer
+	fields int
+}
+
+// key writes the key of the next field, which must be followed by its value.
+func (o *objectWriter) key(key string) error {
+	if o.fields > 0 {
+		if err := o.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	o.fields++
+	bz, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	if _, err := o.w.Write(bz); err != nil {
+		return err
+	}
+	return o.w.WriteByte(':')
+}
+
+// field writes the next field, with its value as is.
+func (o *objectWriter) field(key string, value json.RawMessage) error {
+	if err := o.key(key); err != nil {
+		return err
+	}
+	_, err := o.w.Write(value)
+	return err
+}
+
+// clearConsensusValidators empties the CometBFT validator set of the consensus field of genesis files of SDK v0.50+,
+// so that InitChain takes the validator set from the staking module.
+func clearConsensusValidators(raw json.RawMessage) (json.RawMessage, error) {
+	var consensus map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &consensus); err != nil {
+		return nil, fmt.Errorf("decode consensus: %w", err)
+	}
+	consensus["validators"] = json.RawMessage("[]")
+	return json.Marshal(consensus)
+}
+
+// setModuleState re-encodes the fields of a module's state, after fields have been modified.
+func setModuleState(appState map[string]json.RawMessage, module string, fields map[string]json.RawMessage) error {
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	appState[module] = bz
+	return nil
+}
+
+// moduleState decodes the top-level fields of a module's state.
+func moduleState(appState map[string]json.RawMessage, module string) (map[string]json.RawMessage, error) {
+	raw, ok := appState[module]
+	if !ok {
+		return nil, fmt.Errorf("genesis has no %s state", module)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decode %s state: %w", module, err)
+	}
+	return fields, nil
+}
+
+// setField encodes v as the field key of a module's state.
+func setField(fields map[string]json.RawMessage, key string, v any) error {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	fields[key] = bz
+	return nil
+}
+
+type genesisPubKey struct {
+	Type string `json:"@type"`
+	Key  string `json:"key"`
+}
+
+type genesisDescription struct {
+	Moniker         string `json:"moniker"`
+	Identity        string `json:"identity"`
+	Website         string `json:"website"`
+	SecurityContact string `json:"security_contact"`
+	Details         string `json:"details"`
+}
+
+type genesisCommissionRates struct {
+	Rate          sdkmath.LegacyDec `json:"rate"`
+	MaxRate       sdkmath.LegacyDec `json:"max_rate"`
+	MaxChangeRate sdkmath.LegacyDec `json:"max_change_rate"`
+}
+
+type genesisCommission struct {
+	CommissionRates genesisCommissionRates `json:"commission_rates"`
+	UpdateTime      string                 `json:"update_time"`
+}
+
+type genesisValidator struct {
+	OperatorAddress         string             `json:"operator_address"`
+	ConsensusPubkey         genesisPubKey      `json:"consensus_pubkey"`
+	Jailed                  bool               `json:"jailed"`
+	Status                  string             `json:"status"`
+	Tokens                  sdkmath.Int        `json:"tokens"`
+	DelegatorShares         sdkmath.LegacyDec  `json:"delegator_shares"`
+	Description             genesisDescription `json:"description"`
+	UnbondingHeight         string             `json:"unbonding_height"`
+	UnbondingTime           string             `json:"unbonding_time"`
+	Commission              genesisCommission  `json:"commission"`
+	MinSelfDelegation       sdkmath.Int        `json:"min_self_delegation"`
+	UnbondingOnHoldRefCount string             `json:"unbonding_on_hold_ref_count"`
+	UnbondingIDs            []string           `json:"unbonding_ids"`
+}
+
+type genesisValidatorPower struct {
+	Address string `json:"address"`
+	Power   string `json:"power"`
+}
+
+type genesisDelegation struct {
+	DelegatorAddress string            `json:"delegator_address"`
+	ValidatorAddress string            `json:"validator_address"`
+	Shares           sdkmath.LegacyDec `json:"shares"`
+}
+
+// testnetifyStaking replaces the validators, delegations and last validator powers with vals
+// and their self-delegations, and returns the bond denom.
+func testnetifyStaking(appState map[string]json.RawMessage, vals []TestnetValidator, powerReduction sdkmath.Int) (string, error) {
+	fields, err := moduleState(appState, "staking")
+	if err != nil {
+		return "", err
+	}
+	var params struct {
+		BondDenom string `json:"bond_denom"`
+	}
+	if err := json.Unmarshal(fields["params"], &params); err != nil {
+		return "", fmt.Errorf("decode params: %w", err)
+	}
+
+	validators := make([]genesisValidator, len(vals))
+	powers := make([]genesisValidatorPower, len(vals))
+	delegations := make([]genesisDelegation, len(vals))
+	var totalPower int64
+	for i, v := range vals {
+		shares := sdkmath.LegacyNewDecFromInt(v.Tokens)
+		validators[i] = genesisValidator{
+			OperatorAddress: v.OperatorAddress,
+			ConsensusPubkey: genesisPubKey{Type: "/cosmos.crypto.ed25519.PubKey", Key: v.ConsPubKey},
+			Status:          stakingtypes.Bonded.String(),
+			Tokens:          v.Tokens,
+			DelegatorShares: shares,
+			Description:     genesisDescription{Moniker: v.Moniker},
+			UnbondingHeight: "0",
+			UnbondingTime:   zeroTime,
+			Commission: genesisCommission{
+				CommissionRates: genesisCommissionRates{
+					Rate:          sdkmath.LegacyMustNewDecFromStr("0.1"),
+					MaxRate:       sdkmath.LegacyMustNewDecFromStr("0.2"),
+					MaxChangeRate: sdkmath.LegacyMustNewDecFromStr("0.01"),
+				},
+				UpdateTime: zeroTime,
+			},
+			MinSelfDelegation:       sdkmath.OneInt(),
+			UnbondingOnHoldRefCount: "0",
+			UnbondingIDs:            []string{},
+		}
+		power := sdk.TokensToConsensusPower(v.Tokens, powerReduction)
+		if power <= 0 {
+			return "", fmt.Errorf("validator %s has no consensus power with %s tokens", v.Moniker, v.Tokens)
+		}
+		powers[i] = genesisValidatorPower{Address: v.OperatorAddress, Power: strconv.FormatInt(power, 10)}
+		totalPower += power
+		delegations[i] = genesisDelegation{
+			DelegatorAddress: v.AccountAddress,
+			ValidatorAddress: v.OperatorAddress,
+			Shares:           shares,
+		}
+	}
+
+	for key, v := range map[string]any{
+		"validators":            validators,
+		"last_validator_powers": powers,
+		"last_total_power":      strconv.FormatInt(totalPower, 10),
+		"delegations":           delegations,
+		"unbonding_delegations": []any{},
+		"redelegations":         []any{},
+		"exported":              true,
+	} {
+		if err := setField(fields, key, v); err != nil {
+			return "", err
+		}
+	}
+	return params.BondDenom, setModuleState(appState, "staking", fields)
+}
+
+type genesisSigningInfo struct {
+	Address              string                      `json:"address"`
+	ValidatorSigningInfo genesisValidatorSigningInfo `json:"validator_signing_info"`
+}
+
+type genesisValidatorSigningInfo struct {
+	Address             string `json:"address"`
+	StartHeight         string `json:"start_height"`
+	IndexOffset         string `json:"index_offset"`
+	JailedUntil         string `json:"jailed_until"`
+	Tombstoned          bool   `json:"tombstoned"`
+	MissedBlocksCounter string `json:"missed_blocks_counter"`
+}
+
+// testnetifySlashing replaces the signing infos and missed blocks with fresh signing infos for vals.
+func testnetifySlashing(appState map[string]json.RawMessage, vals []TestnetValidator) error {
+	fields, err := moduleState(appState, "slashing")
+	if err != nil {
+		return err
+	}
+	infos := make([]genesisSigningInfo, len(vals))
+	for i, v := range vals {
+		infos[i] = genesisSigningInfo{
+			Address: v.ConsAddress,
+			ValidatorSigningInfo: genesisValidatorSigningInfo{
+				Address:             v.ConsAddress,
+				StartHeight:         "0",
+				IndexOffset:         "0",
+				JailedUntil:         zeroTime,
+				MissedBlocksCounter: "0",
+			},
+		}
+	}
+	if err := setField(fields, "signing_infos", infos); err != nil {
+		return err
+	}
+	if err := setField(fields, "missed_blocks", []any{}); err != nil {
+		return err
+	}
+	return setModuleState(appState, "slashing", fields)
+}
+
+type genesisOutstandingRewards struct {
+	ValidatorAddress   string       `json:"validator_address"`
+	OutstandingRewards sdk.DecCoins `json:"outstanding_rewards"`
+}
+
+type genesisAccumulatedCommission struct {
+	ValidatorAddress string `json:"validator_address"`
+	Accumulated      struct {
+		Commission sdk.DecCoins `json:"commission"`
+	} `json:"accumulated"`
+}
+
+type genesisHistoricalRewards struct {
+	ValidatorAddress string `json:"validator_address"`
+	Period           string `json:"period"`
+	Rewards          struct {
+		CumulativeRewardRatio sdk.DecCoins `json:"cumulative_reward_ratio"`
+		ReferenceCount        int          `json:"reference_count"`
+	} `json:"rewards"`
+}
+
+type genesisCurrentRewards struct {
+	ValidatorAddress string `json:"validator_address"`
+	Rewards          struct {
+		Rewards sdk.DecCoins `json:"rewards"`
+		Period  string       `json:"period"`
+	} `json:"rewards"`
+}
+
+type genesisStartingInfo struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	StartingInfo     struct {
+		PreviousPeriod string            `json:"previous_period"`
+		Stake          sdkmath.LegacyDec `json:"stake"`
+		Height         string            `json:"height"`
+	} `json:"starting_info"`
+}
+
+// testnetifyDistribution moves the outstanding rewards of the exported validators to the community pool,
+// so that the distribution module balance still matches, and replaces the per-validator and per-delegation
+// state with the state of vals right after their self-delegation.
+func testnetifyDistribution(appState map[string]json.RawMessage, vals []TestnetValidator) error {
+	fields, err := moduleState(appState, "distribution")
+	if err != nil {
+		return err
+	}
+
+	var outstanding []genesisOutstandingRewards
+	if err := json.Unmarshal(fields["outstanding_rewards"], &outstanding); err != nil {
+		return fmt.Errorf("decode outstanding_rewards: %w", err)
+	}
+	var feePool map[string]json.RawMessage
+	if err := json.Unmarshal(fields["fee_pool"], &feePool); err != nil {
+		return fmt.Errorf("decode fee_pool: %w", err)
+	}
+	var communityPool sdk.DecCoins
+	if raw, ok := feePool["community_pool"]; ok {
+		if err := json.Unmarshal(raw, &communityPool); err != nil {
+			return fmt.Errorf("decode community_pool: %w", err)
+		}
+	}
+	for _, o := range outstanding {
+		communityPool = communityPool.Add(o.OutstandingRewards...)
+	}
+	if err := setField(feePool, "community_pool", communityPool); err != nil {
+		return err
+	}
+	if err := setField(fields, "fee_pool", feePool); err != nil {
+		return err
+	}
+
+	// Creating a validator initializes historical rewards at period 0, and self-delegating
+	// increments the period, leaving period 1 referenced by the validator and its delegation.
+	outstanding = make([]genesisOutstandingRewards, len(vals))
+	commissions := make([]genesisAccumulatedCommission, len(vals))
+	historical := make([]genesisHistoricalRewards, len(vals))
+	current := make([]genesisCurrentRewards, len(vals))
+	startingInfos := make([]genesisStartingInfo, len(vals))
+	for i, v := range vals {
+		outstanding[i] = genesisOutstandingRewards{ValidatorAddress: v.OperatorAddress, OutstandingRewards: sdk.DecCoins{}}
+
+		commissions[i].ValidatorAddress = v.OperatorAddress
+		commissions[i].Accumulated.Commission = sdk.DecCoins{}
+
+		historical[i].ValidatorAddress = v.OperatorAddress
+		historical[i].Period = "1"
+		historical[i].Rewards.CumulativeRewardRatio = sdk.DecCoins{}
+		historical[i].Rewards.ReferenceCount = 2
+
+		current[i].ValidatorAddress = v.OperatorAddress
+		current[i].Rewards.Rewards = sdk.DecCoins{}
+		current[i].Rewards.Period = "2"
+
+		startingInfos[i].DelegatorAddress = v.AccountAddress
+		startingInfos[i].ValidatorAddress = v.OperatorAddress
+		startingInfos[i].StartingInfo.PreviousPeriod = "1"
+		startingInfos[i].StartingInfo.Stake = sdkmath.LegacyNewDecFromInt(v.Tokens)
+		startingInfos[i].StartingInfo.Height = "0"
+	}
+
+	for key, v := range map[string]any{
+		"outstanding_rewards":               outstanding,
+		"validator_accumulated_commissions": commissions,
+		"validator_historical_rewards":      historical,
+		"validator_current_rewards":         current,
+		"delegator_starting_infos":          startingInfos,
+		"validator_slash_events":            []any{},
+		"previous_proposer":                 "",
+	} {
+		if err := setField(fields, key, v); err != nil {
+			return err
+		}
+	}
+	return setModuleState(appState, "distribution", fields)
+}
+
+type genesisBalance struct {
+	Address string    `json:"address"`
+	Coins   sdk.Coins `json:"coins"`
+}
+
+// testnetifyBank sets the bonded pool balance to the tokens of vals, empties the bond denom balance
+// of the not bonded pool, as every unbonding delegation is dropped, and funds opts.Accounts.
+// The supply is adjusted accordingly.
+func testnetifyBank(appState map[string]json.RawMessage, opts TestnetifyOptions, bondDenom string) error {
+	fields, err := moduleState(appState, "bank")
+	if err != nil {
+		return err
+	}
+	var balances []genesisBalance
+	if err := json.Unmarshal(fields["balances"], &balances); err != nil {
+		return fmt.Errorf("decode balances: %w", err)
+	}
+	var supply sdk.Coins
+	if err := json.Unmarshal(fields["supply"], &supply); err != nil {
+		return fmt.Errorf("decode supply: %w", err)
+	}
+
+	index := make(map[string]int, len(balances))
+	for i, b := range balances {
+		index[b.Address] = i
+	}
+	// balance returns the balance of addr, adding an empty one if it has none.
+	balance := func(addr string) *genesisBalance {
+		i, ok := index[addr]
+		if !ok {
+			i = len(balances)
+			index[addr] = i
+			balances = append(balances, genesisBalance{Address: addr, Coins: sdk.Coins{}})
+		}
+		return &balances[i]
+	}
+
+	bonded := sdkmath.ZeroInt()
+	for _, v := range opts.Validators {
+		bonded = bonded.Add(v.Tokens)
+	}
+	for pool, amount := range map[string]sdkmath.Int{
+		stakingtypes.BondedPoolName:    bonded,
+		stakingtypes.NotBondedPoolName: sdkmath.ZeroInt(),
+	} {
+		addr, err := bech32.ConvertAndEncode(opts.Bech32Prefix, authtypes.NewModuleAddress(pool))
+		if err != nil {
+			return err
+		}
+		b := balance(addr)
+		prev := sdk.NewCoin(bondDenom, b.Coins.AmountOf(bondDenom))
+		next := sdk.NewCoin(bondDenom, amount)
+		b.Coins = b.Coins.Sub(prev).Add(next)
+		supply = supply.Sub(prev).Add(next)
+	}
+
+	for _, acc := range opts.Accounts {
+		coin := sdk.NewCoin(acc.Denom, acc.Amount)
+		b := balance(acc.Address)
+		b.Coins = b.Coins.Add(coin)
+		supply = supply.Add(coin)
+	}
+
+	if err := setField(fields, "balances", balances); err != nil {
+		return err
+	}
+	if err := setField(fields, "supply", supply); err != nil {
+		return err
+	}
+	return setModuleState(appState, "bank", fields)
+}
+
+// genesisAccount holds the fields identifying an account, at the top level of a base account,
+// or nested in module and vesting accounts.
+type genesisAccount struct {
+	Address            string          `json:"address"`
+	AccountNumber      string          `json:"account_number"`
+	BaseAccount        *genesisAccount `json:"base_account"`
+	BaseVestingAccount *genesisAccount `json:"base_vesting_account"`
+}
+
+// base returns the base account of a, which may be a itself.
+func (a *genesisAccount) base() *genesisAccount {
+	switch {
+	case a.BaseVestingAccount != nil:
+		return a.BaseVestingAccount.base()
+	case a.BaseAccount != nil:
+		return a.BaseAccount.base()
+	default:
+		return a
+	}
+}
+
+type genesisBaseAccount struct {
+	Type          string `json:"@type"`
+	Address       string `json:"address"`
+	PubKey        any    `json:"pub_key"`
+	AccountNumber string `json:"account_number"`
+	Sequence      string `json:"sequence"`
+}
+
+// testnetifyAuth creates a base account for each of accounts which does not exist yet,
+// numbered after the highest existing account number.
+func testnetifyAuth(appState map[string]json.RawMessage, accounts []ibc.WalletAmount) error {
+	fields, err := moduleState(appState, "auth")
+	if err != nil {
+		return err
+	}
+	var existing []json.RawMessage
+	if err := json.Unmarshal(fields["accounts"], &existing); err != nil {
+		return fmt.Errorf("decode accounts: %w", err)
+	}
+
+	exists := make(map[string]bool, len(existing))
+	var nextNumber uint64
+	for _, raw := range existing {
+		var acc genesisAccount
+		if err := json.Unmarshal(raw, &acc); err != nil {
+			return fmt.Errorf("decode account: %w", err)
+		}
+		base := acc.base()
+		exists[base.Address] = true
+		if base.AccountNumber == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(base.AccountNumber, 10, 64)
+		if err != nil {
+			return fmt.Errorf("account %s: invalid account number: %w", base.Address, err)
+		}
+		nextNumber = max(nextNumber, n+1)
+	}
+
+	for _, acc := range accounts {
+		if exists[acc.Address] {
+			continue
+		}
+		exists[acc.Address] = true
+		bz, err := json.Marshal(genesisBaseAccount{
+			Type:          "/cosmos.auth.v1beta1.BaseAccount",
+			Address:       acc.Address,
+			AccountNumber: strconv.FormatUint(nextNumber, 10),
+			Sequence:      "0",
+		})
+		if err != nil {
+			return err
+		}
+		existing = append(existing, bz)
+		nextNumber++
+	}
+
+	if err := setField(fields, "accounts", existing); err != nil {
+		return err
+	}
+	return setModuleState(appState, "auth", fields)
+}
+
+// startFromGenesisImport starts the chain from the exported genesis of ChainConfig.GenesisImport,
+// with the chain's validators, bonded with their genesis self-delegation, replacing the exported validator set.
+func (c *CosmosChain) startFromGenesisImport(ctx context.Context, genesisAmounts [][]sdk.Coin, genesisSelfDelegation []sdk.Coin, additionalGenesisWallets []ibc.WalletAmount) error {
+	var eg errgroup.Group
+	for _, v := range c.Validators {
+		v.Validator = true
+		eg.Go(func() error {
+			if err := c.initNodeFiles(ctx, v); err != nil {
+				return err
+			}
+			return v.CreateKey(ctx, valKey)
+		})
+	}
+	for _, n := range c.FullNodes {
+		n.Validator = false
+		eg.Go(func() error {
+			return c.initNodeFiles(ctx, n)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if c.preStartNodes != nil {
+		c.preStartNodes(c)
+	}
+
+	if c.cfg.PreGenesis != nil {
+		if err := c.cfg.PreGenesis(c); err != nil {
+			return err
+		}
+	}
+
+	opts := TestnetifyOptions{
+		ChainID:      c.cfg.ChainID,
+		Bech32Prefix: c.cfg.Bech32Prefix,
+		Accounts:     slices.Clone(additionalGenesisWallets),
+	}
+	for i, v := range c.Validators {
+		val, err := v.testnetValidator(ctx, genesisSelfDelegation[i].Amount)
+		if err != nil {
+			return err
+		}
+		opts.Validators = append(opts.Validators, val)
+		for _, coin := range genesisAmounts[i] {
+			opts.Accounts = append(opts.Accounts, ibc.WalletAmount{
+				Address: val.AccountAddress,
+				Denom:   coin.Denom,
+				Amount:  coin.Amount,
+			})
+		}
+	}
+
+	genesisPath := c.cfg.GenesisImport.Path
+	src, err := os.Open(genesisPath)
+	if err != nil {
+		return fmt.Errorf("open genesis to import: %w", err)
+	}
+	defer src.Close()
+
+	genesisFile, err := os.CreateTemp("", "genesis-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(genesisFile.Name())
+	defer genesisFile.Close()
+
+	c.log.Info("Importing genesis", zap.String("chain_id", c.cfg.ChainID), zap.String("path", genesisPath))
+	if err := TestnetifyGenesis(src, genesisFile, opts); err != nil {
+		return fmt.Errorf("testnetify genesis %s: %w", genesisPath, err)
+	}
+
+	if c.cfg.ModifyGenesis != nil {
+		if err := c.modifyGenesisFile(genesisFile); err != nil {
+			return err
+		}
+	}
+
+	size, err := genesisFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	for _, n := range c.Nodes() {
+		eg.Go(func() error {
+			return n.OverwriteGenesisFileFrom(ctx, io.NewSectionReader(genesisFile, 0, size), size)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	return c.launchNodes(ctx)
+}
+
+// modifyGenesisFile applies ChainConfig.ModifyGenesis to the genesis in f.
+// ModifyGenesis takes and returns the genesis as bytes, so the whole genesis is read into memory,
+// unlike TestnetifyGenesis which streams the module states it does not rewrite.
+func (c *CosmosChain) modifyGenesisFile(f *os.File) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	genbz, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if genbz, err = c.cfg.ModifyGenesis(c.Config(), genbz); err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(genbz, 0)
+	return err
+}
+
+// testnetValidator returns tn as a validator bonded with tokens, from its validator key and consensus key.
+func (tn *ChainNode) testnetValidator(ctx context.Context, tokens sdkmath.Int) (TestnetValidator, error) {
+	accAddr, err := tn.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return TestnetValidator{}, err
+	}
+	valAddr, err := tn.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return TestnetValidator{}, err
+	}
+	consAddr, err := tn.ValidatorConsAddress(ctx)
+	if err != nil {
+		return TestnetValidator{}, err
+	}
+	privVal, err := tn.PrivValFileContent(ctx)
+	if err != nil {
+		return TestnetValidator{}, err
+	}
+	var keyFile PrivValidatorKeyFile
+	if err := json.Unmarshal(privVal, &keyFile); err != nil {
+		return TestnetValidator{}, fmt.Errorf("unmarshal priv_validator_key.json: %w", err)
+	}
+	return TestnetValidator{
+		Moniker:         tn.Name(),
+		AccountAddress:  accAddr,
+		OperatorAddress: valAddr,
+		ConsAddress:     consAddr,
+		ConsPubKey:      keyFile.PubKey.Value,
+		Tokens:          tokens,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package cosmos_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+func moduleAddress(t *testing.T, name string) string {
+	t.Helper()
+	addr, err := bech32.ConvertAndEncode("cosmos", authtypes.NewModuleAddress(name))
+	require.NoError(t, err)
+	return addr
+}
+
+func exportedGenesis(t *testing.T) []byte {
+	t.Helper()
+	bonded := moduleAddress(t, stakingtypes.BondedPoolName)
+	notBonded := moduleAddress(t, stakingtypes.NotBondedPoolName)
+	return []byte(`{
+  "app_name": "simd",
+  "chain_id": "mainnet-1",
+  "initial_height": "100",
+  "consensus": {
+    "validators": [{"address": "AA", "name": "old", "power": "1000"}],
+    "params": {"block": {"max_gas": "-1"}}
+  },
+  "app_state": {
+    "auth": {
+      "params": {},
+      "accounts": [
+        {"@type": "/cosmos.auth.v1beta1.BaseAccount", "address": "cosmos1old", "pub_key": null, "account_number": "7", "sequence": "3"},
+        {"@type": "/cosmos.auth.v1beta1.ModuleAccount", "base_account": {"address": "` + bonded + `", "pub_key": null, "account_number": "12", "sequence": "0"}, "name": "bonded_tokens_pool", "permissions": ["burner", "staking"]}
+      ]
+    },
+    "bank": {
+      "params": {},
+      "balances": [
+        {"address": "` + bonded + `", "coins": [{"denom": "uatom", "amount": "1000"}]},
+        {"address": "` + notBonded + `", "coins": [{"denom": "uatom", "amount": "50"}]},
+        {"address": "cosmos1old", "coins": [{"denom": "uatom", "amount": "500"}]}
+      ],
+      "supply": [{"denom": "uatom", "amount": "1550"}]
+    },
+    "staking": {
+      "params": {"bond_denom": "uatom"},
+      "validators": [{"operator_address": "cosmosvaloper1old", "tokens": "1000"}],
+      "delegations": [{"delegator_address": "cosmos1old", "validator_address": "cosmosvaloper1old", "shares": "1000.000000000000000000"}],
+      "unbonding_delegations": [{"delegator_address": "cosmos1old", "validator_address": "cosmosvaloper1old", "entries": []}],
+      "last_total_power": "1000",
+      "exported": true
+    },
+    "slashing": {
+      "params": {},
+      "signing_infos": [{"address": "cosmosvalcons1old"}],
+      "missed_blocks": [{"address": "cosmosvalcons1old"}]
+    },
+    "distribution": {
+      "params": {},
+      "fee_pool": {"community_pool": [{"denom": "uatom", "amount": "10.500000000000000000"}]},
+      "outstanding_rewards": [{"validator_address": "cosmosvaloper1old", "outstanding_rewards": [{"denom": "uatom", "amount": "2.250000000000000000"}]}],
+      "previous_proposer": "cosmosvalcons1old"
+    },
+    "gov": {"params": {"quorum": "0.334000000000000000"}}
+  }
+}`)
+}
+
+func testnetifyOptions() cosmos.TestnetifyOptions {
+	return cosmos.TestnetifyOptions{
+		ChainID:      "testnet-1",
+		Bech32Prefix: "cosmos",
+		Validators: []cosmos.TestnetValidator{{
+			Moniker:         "val-0",
+			AccountAddress:  "cosmos1val",
+			OperatorAddress: "cosmosvaloper1val",
+			ConsAddress:     "cosmosvalcons1val",
+			ConsPubKey:      "pubkey",
+			Tokens:          sdkmath.NewInt(5_000_000),
+		}},
+		Accounts: []ibc.WalletAmount{
+			{Address: "cosmos1val", Denom: "uatom", Amount: sdkmath.NewInt(100)},
+			{Address: "cosmos1old", Denom: "uatom", Amount: sdkmath.NewInt(1)},
+		},
+	}
+}
+
+// genesisValue decodes the JSON value at path in genesis.
+func genesisValue(t *testing.T, genesis []byte, path ...string) any {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal(genesis, &v))
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "%s is not an object", key)
+		v = m[key]
+	}
+	return v
+}
+
+func TestTestnetifyGenesis(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, cosmos.TestnetifyGenesis(bytes.NewReader(exportedGenesis(t)), &out, testnetifyOptions()))
+	genesis := out.Bytes()
+
+	require.Equal(t, "testnet-1", genesisValue(t, genesis, "chain_id"))
+	require.Equal(t, "100", genesisValue(t, genesis, "initial_height"))
+	require.Empty(t, genesisValue(t, genesis, "consensus", "validators"))
+	require.NotNil(t, genesisValue(t, genesis, "consensus", "params"))
+	require.Equal(t, "0.334000000000000000", genesisValue(t, genesis, "app_state", "gov", "params", "quorum"))
+
+	validators := genesisValue(t, genesis, "app_state", "staking", "validators").([]any)
+	require.Len(t, validators, 1)
+	validator := validators[0].(map[string]any)
+	require.Equal(t, "cosmosvaloper1val", validator["operator_address"])
+	require.Equal(t, "BOND_STATUS_BONDED", validator["status"])
+	require.Equal(t, "5000000", validator["tokens"])
+	require.Equal(t, "pubkey", validator["consensus_pubkey"].(map[string]any)["key"])
+	require.Equal(t, "5", genesisValue(t, genesis, "app_state", "staking", "last_total_power"))
+	require.Len(t, genesisValue(t, genesis, "app_state", "staking", "delegations"), 1)
+	require.Empty(t, genesisValue(t, genesis, "app_state", "staking", "unbonding_delegations"))
+
+	signingInfos := genesisValue(t, genesis, "app_state", "slashing", "signing_infos").([]any)
+	require.Len(t, signingInfos, 1)
+	require.Equal(t, "cosmosvalcons1val", signingInfos[0].(map[string]any)["address"])
+	require.Empty(t, genesisValue(t, genesis, "app_state", "slashing", "missed_blocks"))
+
+	communityPool := genesisValue(t, genesis, "app_state", "distribution", "fee_pool", "community_pool").([]any)
+	require.Equal(t, "12.750000000000000000", communityPool[0].(map[string]any)["amount"])
+	require.Equal(t, "", genesisValue(t, genesis, "app_state", "distribution", "previous_proposer"))
+	outstanding := genesisValue(t, genesis, "app_state", "distribution", "outstanding_rewards").([]any)
+	require.Len(t, outstanding, 1)
+	require.Equal(t, "cosmosvaloper1val", outstanding[0].(map[string]any)["validator_address"])
+
+	balances := make(map[string]string)
+	for _, b := range genesisValue(t, genesis, "app_state", "bank", "balances").([]any) {
+		b := b.(map[string]any)
+		amount := ""
+		if coins := b["coins"].([]any); len(coins) > 0 {
+			amount = coins[0].(map[string]any)["amount"].(string)
+		}
+		balances[b["address"].(string)] = amount
+	}
+	require.Equal(t, "5000000", balances[moduleAddress(t, stakingtypes.BondedPoolName)])
+	require.Equal(t, "", balances[moduleAddress(t, stakingtypes.NotBondedPoolName)])
+	require.Equal(t, "100", balances["cosmos1val"])
+	require.Equal(t, "501", balances["cosmos1old"])
+	// 1550 - 1000 bonded - 50 not bonded + 5000000 bonded + 101 funded.
+	supply := genesisValue(t, genesis, "app_state", "bank", "supply").([]any)
+	require.Equal(t, "5000601", supply[0].(map[string]any)["amount"])
+
+	accounts := genesisValue(t, genesis, "app_state", "auth", "accounts").([]any)
+	require.Len(t, accounts, 3)
+	require.Equal(t, "cosmos1val", accounts[2].(map[string]any)["address"])
+	require.Equal(t, "13", accounts[2].(map[string]any)["account_number"])
+}
+
+func TestTestnetifyGenesisGzip(t *testing.T) {
+	var plain bytes.Buffer
+	require.NoError(t, cosmos.TestnetifyGenesis(bytes.NewReader(exportedGenesis(t)), &plain, testnetifyOptions()))
+
+	compressed, err := testutil.GzipIt(exportedGenesis(t))
+	require.NoError(t, err)
+	var fromGzip bytes.Buffer
+	require.NoError(t, cosmos.TestnetifyGenesis(bytes.NewReader(compressed), &fromGzip, testnetifyOptions()))
+
+	require.Equal(t, plain.String(), fromGzip.String())
+}
+
+func TestTestnetifyGenesisRequiresValidators(t *testing.T) {
+	opts := testnetifyOptions()
+	opts.Validators = nil
+	require.Error(t, cosmos.TestnetifyGenesis(bytes.NewReader(exportedGenesis(t)), &bytes.Buffer{}, opts))
+}
+
+func TestTestnetifyGenesisInvalid(t *testing.T) {
+	for name, genesis := range map[string]string{
+		"no app_state":     `{"chain_id": "mainnet-1"}`,
+		"no staking state": `{"chain_id": "mainnet-1", "app_state": {"gov": {}}}`,
+		"not an object":    `[]`,
+		"truncated":        string(exportedGenesis(t)[:200]),
+	} {
+		err := cosmos.TestnetifyGenesis(bytes.NewReader([]byte(genesis)), &bytes.Buffer{}, testnetifyOptions())
+		require.Error(t, err, name)
+	}
+}
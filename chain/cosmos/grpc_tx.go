@@ -0,0 +1,210 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// msgsFunc builds the messages of a transaction signed by signer, the bech32 account address of the signing key.
+type msgsFunc func(signer string) ([]sdk.Msg, error)
+
+// execTx runs a module helper's transaction. If the chain opts into ibc.ChainConfig.GRPCTx, the messages
+// returned by msgs are signed in-process and broadcast over gRPC. Otherwise, or if msgs is nil,
+// the equivalent CLI command is run like ExecTx. Either way the response is returned once the tx is committed.
+func (tn *ChainNode) execTx(ctx context.Context, keyName string, msgs msgsFunc, command ...string) (*sdk.TxResponse, error) {
+	if msgs == nil {
+		return tn.execTxCLIOnly(ctx, keyName, command...)
+	}
+	if !tn.Chain.Config().GRPCTx {
+		return tn.execTxCLI(ctx, keyName, command...)
+	}
+	return tn.broadcastMsgsFunc(ctx, keyName, "", msgs)
+}
+
+// execTxCLIOnly runs the transaction of a module helper without a gRPC transaction path with the CLI, like ExecTx.
+func (tn *ChainNode) execTxCLIOnly(ctx context.Context, keyName string, command ...string) (*sdk.TxResponse, error) {
+	tn.warnCLIFallback(command)
+	return tn.execTxCLI(ctx, keyName, command...)
+}
+
+// warnCLIFallback logs a warning if the chain opts into ibc.ChainConfig.GRPCTx,
+// as command is run with the CLI regardless.
+func (tn *ChainNode) warnCLIFallback(command []string) {
+	if !tn.Chain.Config().GRPCTx {
+		return
+	}
+	tn.logger().Warn("Transaction has no gRPC path, running it with the CLI despite GRPCTx",
+		zap.Strings("command", command),
+	)
+}
+
+// BroadcastMsgs signs msgs with keyName from the node's test keyring and broadcasts them through the node's
+// gRPC tx service, without invoking the chain binary's tx commands. It waits for the transaction to be
+// committed and returns an error if it failed during CheckTx or DeliverTx.
+// The chain's EncodingConfig must register every message type in msgs.
+func (tn *ChainNode) BroadcastMsgs(ctx context.Context, keyName string, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	return tn.broadcastMsgs(ctx, keyName, "", msgs...)
+}
+
+func (tn *ChainNode) broadcastMsgsFunc(ctx context.Context, keyName, memo string, msgs msgsFunc) (*sdk.TxResponse, error) {
+	record, err := tn.txKey(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := tn.Chain.(*CosmosChain).AccAddressToBech32(addr)
+	if err != nil {
+		return nil, err
+	}
+	m, err := msgs(signer)
+	if err != nil {
+		return nil, err
+	}
+	return tn.broadcastMsgs(ctx, keyName, memo, m...)
+}
+
+func (tn *ChainNode) broadcastMsgs(ctx context.Context, keyName, memo string, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	record, err := tn.txKey(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// Serialize with ExecTx so concurrent transactions from the same key get consecutive sequences.
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	chain := tn.Chain.(*CosmosChain)
+	cfg := chain.Config()
+	clientCtx := tn.CliContext().
+		WithFromName(keyName).
+		WithFromAddress(addr).
+		WithKeyring(tn.txKeyring).
+		WithAccountRetriever(AccountRetriever{chain: chain}).
+		WithCodec(cfg.EncodingConfig.Codec)
+
+	account, err := clientCtx.AccountRetriever.GetAccount(clientCtx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("query account %s: %w", keyName, err)
+	}
+
+	f := tx.Factory{}.
+		WithAccountNumber(account.GetAccountNumber()).
+		WithSequence(account.GetSequence()).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
+		WithGasAdjustment(cfg.GasAdjustment).
+		WithGasPrices(cfg.GasPrices).
+		WithMemo(memo).
+		WithTxConfig(clientCtx.TxConfig).
+		WithAccountRetriever(clientCtx.AccountRetriever).
+		WithKeybase(clientCtx.Keyring).
+		WithFromName(keyName).
+		WithChainID(cfg.ChainID)
+
+	if cfg.Gas == "" || cfg.Gas == "auto" {
+		_, gas, err := tx.CalculateGas(clientCtx, f.WithSimulateAndExecute(true), msgs...)
+		if err != nil {
+			return nil, fmt.Errorf("simulate tx: %w", err)
+		}
+		f = f.WithGas(gas)
+	} else {
+		gas, err := strconv.ParseUint(cfg.Gas, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas %q: %w", cfg.Gas, err)
+		}
+		f = f.WithGas(gas)
+	}
+
+	builder, err := f.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("build tx: %w", err)
+	}
+	if err := tx.Sign(ctx, f, keyName, builder, true); err != nil {
+		return nil, fmt.Errorf("sign tx: %w", err)
+	}
+	txBytes, err := clientCtx.TxConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("encode tx: %w", err)
+	}
+
+	res, err := txtypes.NewServiceClient(tn.GrpcConn).BroadcastTx(ctx, &txtypes.BroadcastTxRequest{
+		TxBytes: txBytes,
+		Mode:    txtypes.BroadcastMode_BROADCAST_MODE_SYNC,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("broadcast tx: %w", err)
+	}
+	if res.TxResponse.Code != 0 {
+		return res.TxResponse, fmt.Errorf("transaction failed with code %d: %s", res.TxResponse.Code, res.TxResponse.RawLog)
+	}
+
+	txResp, err := tn.WaitForTx(ctx, res.TxResponse.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	tn.trackGas(txResp)
+	if txResp.Code != 0 {
+		return txResp, fmt.Errorf("transaction failed with code %d: %s", txResp.Code, txResp.RawLog)
+	}
+	return txResp, nil
+}
+
+// txKey returns keyName from the in-memory keyring used to sign gRPC transactions,
+// importing it from the node's test keyring the first time it is used.
+func (tn *ChainNode) txKey(ctx context.Context, keyName string) (*keyring.Record, error) {
+	tn.txKeyringMu.Lock()
+	defer tn.txKeyringMu.Unlock()
+
+	if tn.txKeyring == nil {
+		tn.txKeyring = keyring.NewInMemory(tn.Chain.Config().EncodingConfig.Codec)
+	}
+
+	record, err := tn.txKeyring.Key(keyName)
+	if err == nil {
+		return record, nil
+	}
+	if !errors.Is(err, keyring.ErrKeyNotFound) {
+		return nil, err
+	}
+
+	stdout, stderr, err := tn.ExecBin(ctx,
+		"keys", "export", keyName,
+		"--unarmored-hex", "--unsafe", "-y",
+		"--keyring-backend", keyring.BackendTest,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export key %q (stderr=%q): %w", keyName, stderr, err)
+	}
+	// Depending on the SDK version the hex key is printed to stdout or stderr.
+	privKeyHex := string(bytes.TrimSpace(stdout))
+	if privKeyHex == "" {
+		privKeyHex = string(bytes.TrimSpace(stderr))
+	}
+
+	algo := tn.Chain.Config().SigningAlgorithm
+	if algo == "" {
+		algo = string(hd.Secp256k1Type)
+	}
+	if err := tn.txKeyring.ImportPrivKeyHex(keyName, privKeyHex, algo); err != nil {
+		return nil, fmt.Errorf("import key %q: %w", keyName, err)
+	}
+	return tn.txKeyring.Key(keyName)
+}
@@ -0,0 +1,371 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/icza/dyno"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types" // nolint:staticcheck
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+const consumerPhaseLaunched = "CONSUMER_PHASE_LAUNCHED"
+
+// ConsumerLaunchOptions configures how a provider chain launches an ICS consumer chain.
+// Zero values fall back to the defaults from DefaultConsumerLaunchOptions.
+type ConsumerLaunchOptions struct {
+	// SpawnTimeDelay is how far in the future the spawn time is set when the consumer is created,
+	// leaving provider validators time to opt in before launch.
+	SpawnTimeDelay time.Duration
+
+	// If set, every provider validator assigns a freshly generated consensus key to the consumer.
+	// Otherwise the consumer validators sign with the provider validators' keys.
+	AssignConsumerKeys bool
+
+	UnbondingPeriod                   time.Duration
+	CCVTimeoutPeriod                  time.Duration
+	TransferTimeoutPeriod             time.Duration
+	ConsumerRedistributionFraction    string
+	BlocksPerDistributionTransmission int64
+	HistoricalEntries                 int64
+}
+
+// DefaultConsumerLaunchOptions returns the launch options used for any unset ConsumerLaunchOptions field.
+func DefaultConsumerLaunchOptions() ConsumerLaunchOptions {
+	return ConsumerLaunchOptions{
+		SpawnTimeDelay:                    30 * time.Second,
+		UnbondingPeriod:                   20 * 24 * time.Hour,
+		CCVTimeoutPeriod:                  28 * 24 * time.Hour,
+		TransferTimeoutPeriod:             time.Hour,
+		ConsumerRedistributionFraction:    "0.75",
+		BlocksPerDistributionTransmission: 1000,
+		HistoricalEntries:                 10000,
+	}
+}
+
+func (o ConsumerLaunchOptions) withDefaults() ConsumerLaunchOptions {
+	d := DefaultConsumerLaunchOptions()
+	if o.SpawnTimeDelay == 0 {
+		o.SpawnTimeDelay = d.SpawnTimeDelay
+	}
+	if o.UnbondingPeriod == 0 {
+		o.UnbondingPeriod = d.UnbondingPeriod
+	}
+	if o.CCVTimeoutPeriod == 0 {
+		o.CCVTimeoutPeriod = d.CCVTimeoutPeriod
+	}
+	if o.TransferTimeoutPeriod == 0 {
+		o.TransferTimeoutPeriod = d.TransferTimeoutPeriod
+	}
+	if o.ConsumerRedistributionFraction == "" {
+		o.ConsumerRedistributionFraction = d.ConsumerRedistributionFraction
+	}
+	if o.BlocksPerDistributionTransmission == 0 {
+		o.BlocksPerDistributionTransmission = d.BlocksPerDistributionTransmission
+	}
+	if o.HistoricalEntries == 0 {
+		o.HistoricalEntries = d.HistoricalEntries
+	}
+	return o
+}
+
+// LaunchConsumer creates consumer on the provider c, opts every provider validator in, waits for the spawn time
+// to pass and then starts the consumer from the genesis the provider hands out. It returns the consumer ID.
+//
+// The consumer must have as many validators as the provider: validator i of the consumer runs on behalf of
+// validator i of the provider. Requires a provider with permissionless consumer creation (ICS v6 or later).
+func (c *CosmosChain) LaunchConsumer(
+	ctx context.Context,
+	testName string,
+	consumer *CosmosChain,
+	opts ConsumerLaunchOptions,
+	additionalGenesisWallets ...ibc.WalletAmount,
+) (string, error) {
+	opts = opts.withDefaults()
+
+	if len(consumer.Validators) != len(c.Validators) {
+		return "", fmt.Errorf("consumer %s has %d validators but provider %s has %d",
+			consumer.Config().ChainID, len(consumer.Validators), c.Config().ChainID, len(c.Validators))
+	}
+
+	privValKeys := make([][]byte, len(c.Validators))
+	for i, v := range c.Validators {
+		var err error
+		if opts.AssignConsumerKeys {
+			privValKeys[i], err = newPrivValKeyFile()
+		} else {
+			privValKeys[i], err = v.PrivValFileContent(ctx)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	spawnTime := time.Now().UTC().Add(opts.SpawnTimeDelay)
+	consumerID, err := c.GetNode().CreateConsumer(ctx, valKey, consumer.Config().ChainID, spawnTime, opts)
+	if err != nil {
+		return "", fmt.Errorf("create consumer %s: %w", consumer.Config().ChainID, err)
+	}
+
+	var eg errgroup.Group
+	for i, v := range c.Validators {
+		eg.Go(func() error {
+			var consumerKey string
+			if opts.AssignConsumerKeys {
+				var err error
+				consumerKey, err = privValPubKeyJSON(privValKeys[i])
+				if err != nil {
+					return err
+				}
+			}
+			return v.OptIn(ctx, valKey, consumerID, consumerKey)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", fmt.Errorf("opt in to consumer %s: %w", consumerID, err)
+	}
+
+	c.log.Info("Waiting for consumer spawn time",
+		zap.String("consumer_id", consumerID),
+		zap.Time("spawn_time", spawnTime),
+	)
+	if err := c.GetNode().WaitForConsumerLaunch(ctx, consumerID, opts.SpawnTimeDelay+time.Minute); err != nil {
+		return "", err
+	}
+
+	if err := consumer.StartConsumer(testName, ctx, c, consumerID, privValKeys, additionalGenesisWallets...); err != nil {
+		return "", fmt.Errorf("start consumer %s: %w", consumer.Config().ChainID, err)
+	}
+	return consumerID, nil
+}
+
+// CreateConsumer submits a create-consumer transaction for an opt-in consumer chain that spawns at spawnTime,
+// and returns the consumer ID assigned by the provider.
+func (tn *ChainNode) CreateConsumer(ctx context.Context, keyName, chainID string, spawnTime time.Time, opts ConsumerLaunchOptions) (string, error) {
+	if !tn.HasCommand(ctx, "tx", "provider", "create-consumer") {
+		return "", fmt.Errorf("%s does not support permissionless consumer creation", tn.Chain.Config().Bin)
+	}
+
+	msg := map[string]any{
+		"chain_id": chainID,
+		"metadata": map[string]any{
+			"name":        chainID,
+			"description": "interchaintest consumer chain",
+			"metadata":    "{}",
+		},
+		"initialization_parameters": map[string]any{
+			"initial_height": map[string]any{
+				"revision_number": clienttypes.ParseChainID(chainID),
+				"revision_height": 1,
+			},
+			"genesis_hash":                         "",
+			"binary_hash":                          "",
+			"spawn_time":                           spawnTime.Format(time.RFC3339Nano),
+			"unbonding_period":                     opts.UnbondingPeriod.Nanoseconds(),
+			"ccv_timeout_period":                   opts.CCVTimeoutPeriod.Nanoseconds(),
+			"transfer_timeout_period":              opts.TransferTimeoutPeriod.Nanoseconds(),
+			"consumer_redistribution_fraction":     opts.ConsumerRedistributionFraction,
+			"blocks_per_distribution_transmission": opts.BlocksPerDistributionTransmission,
+			"historical_entries":                   opts.HistoricalEntries,
+			"distribution_transmission_channel":    "",
+		},
+		"power_shaping_parameters": map[string]any{
+			"top_N":                0,
+			"validators_power_cap": 0,
+			"validator_set_cap":    0,
+			"allowlist":            []string{},
+			"denylist":             []string{},
+			"min_stake":            0,
+			"allow_inactive_vals":  false,
+		},
+	}
+	msgJSON, err := json.MarshalIndent(msg, "", " ")
+	if err != nil {
+		return "", err
+	}
+
+	file := fmt.Sprintf("create-consumer-%s.json", chainID)
+	if err := tn.WriteFile(ctx, msgJSON, file); err != nil {
+		return "", fmt.Errorf("writing create-consumer file to docker volume: %w", err)
+	}
+
+	if _, err := tn.ExecTx(ctx, keyName, "provider", "create-consumer", path.Join(tn.HomeDir(), file), "--gas", "auto"); err != nil {
+		return "", err
+	}
+	return tn.GetConsumerChainByChainID(ctx, chainID)
+}
+
+// OptIn opts the validator owning keyName in to validate consumerID.
+// If consumerKey is set, it is assigned as the validator's consensus key on the consumer; it is the
+// JSON-encoded public key, e.g. {"@type":"/cosmos.crypto.ed25519.PubKey","key":"..."}.
+func (tn *ChainNode) OptIn(ctx context.Context, keyName, consumerID, consumerKey string) error {
+	command := []string{"provider", "opt-in", consumerID}
+	if consumerKey != "" {
+		command = append(command, consumerKey)
+	}
+	_, err := tn.ExecTx(ctx, keyName, command...)
+	return err
+}
+
+// WaitForConsumerLaunch waits until the provider reports consumerID as launched.
+func (tn *ChainNode) WaitForConsumerLaunch(ctx context.Context, consumerID string, timeout time.Duration) error {
+	var phase string
+	err := testutil.WaitForCondition(timeout, time.Second, func() (bool, error) {
+		stdout, _, err := tn.ExecQuery(ctx, "provider", "consumer-chain", consumerID)
+		if err != nil {
+			return false, err
+		}
+		phase = gjson.GetBytes(stdout, "phase").String()
+		return phase == consumerPhaseLaunched, nil
+	})
+	if err != nil {
+		return fmt.Errorf("consumer %s not launched (phase %q): %w", consumerID, phase, err)
+	}
+	return nil
+}
+
+// ConsumerGenesis returns the CCV consumer genesis state the provider generated for consumerID at launch.
+func (tn *ChainNode) ConsumerGenesis(ctx context.Context, consumerID string) ([]byte, error) {
+	stdout, _, err := tn.ExecQuery(ctx, "provider", "consumer-genesis", consumerID)
+	if err != nil {
+		return nil, fmt.Errorf("query consumer genesis: %w", err)
+	}
+	return stdout, nil
+}
+
+// StartConsumer bootstraps the chain as an ICS consumer of provider, which must already have launched consumerID.
+// Instead of collecting gentxs, the validator set comes from the provider's consumer genesis, and validator i
+// signs with privValKeys[i], the content of a priv_validator_key.json file.
+func (c *CosmosChain) StartConsumer(
+	testName string,
+	ctx context.Context,
+	provider *CosmosChain,
+	consumerID string,
+	privValKeys [][]byte,
+	additionalGenesisWallets ...ibc.WalletAmount,
+) error {
+	if len(privValKeys) != len(c.Validators) {
+		return fmt.Errorf("got %d validator keys for %d validators", len(privValKeys), len(c.Validators))
+	}
+
+	chainCfg := c.Config()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, v := range c.Validators {
+		v.Validator = true
+		eg.Go(func() error {
+			if err := c.initNodeFiles(egCtx, v); err != nil {
+				return err
+			}
+			return v.OverwritePrivValFile(egCtx, privValKeys[i])
+		})
+	}
+	for _, n := range c.FullNodes {
+		n.Validator = false
+		eg.Go(func() error {
+			return c.initNodeFiles(egCtx, n)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if c.preStartNodes != nil {
+		c.preStartNodes(c)
+	}
+
+	if c.cfg.PreGenesis != nil {
+		if err := c.cfg.PreGenesis(c); err != nil {
+			return err
+		}
+	}
+
+	validator0 := c.Validators[0]
+	for _, wallet := range additionalGenesisWallets {
+		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []sdk.Coin{{Denom: wallet.Denom, Amount: wallet.Amount}}); err != nil {
+			return err
+		}
+	}
+
+	genbz, err := validator0.GenesisFileContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	ccvGenesis, err := provider.GetNode().ConsumerGenesis(ctx, consumerID)
+	if err != nil {
+		return err
+	}
+	genbz, err = setConsumerGenesis(genbz, ccvGenesis)
+	if err != nil {
+		return err
+	}
+
+	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
+
+	if c.cfg.ModifyGenesis != nil {
+		genbz, err = c.cfg.ModifyGenesis(chainCfg, genbz)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.startNodes(ctx, genbz)
+}
+
+// setConsumerGenesis places the provider-generated CCV state into the ccvconsumer module of genbz.
+func setConsumerGenesis(genbz, ccvGenesis []byte) ([]byte, error) {
+	g := make(map[string]any)
+	if err := json.Unmarshal(genbz, &g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+	ccv := make(map[string]any)
+	if err := json.Unmarshal(ccvGenesis, &ccv); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal consumer genesis: %w", err)
+	}
+	if err := dyno.Set(g, ccv, "app_state", "ccvconsumer"); err != nil {
+		return nil, fmt.Errorf("failed to set ccvconsumer in genesis json: %w", err)
+	}
+	return json.Marshal(g)
+}
+
+// newPrivValKeyFile returns the content of a priv_validator_key.json file holding a new ed25519 key.
+func newPrivValKeyFile() ([]byte, error) {
+	privKey := ed25519.GenPrivKey()
+	pubKey := privKey.PubKey()
+	return json.Marshal(PrivValidatorKeyFile{
+		Address: pubKey.Address().String(),
+		PubKey: PrivValidatorKey{
+			Type:  "tendermint/PubKeyEd25519",
+			Value: base64.StdEncoding.EncodeToString(pubKey.Bytes()),
+		},
+		PrivKey: PrivValidatorKey{
+			Type:  "tendermint/PrivKeyEd25519",
+			Value: base64.StdEncoding.EncodeToString(privKey.Bytes()),
+		},
+	})
+}
+
+// privValPubKeyJSON returns the public key of a priv_validator_key.json file in the JSON form
+// expected by create-validator and consumer key assignment.
+func privValPubKeyJSON(privValKey []byte) (string, error) {
+	var keyFile PrivValidatorKeyFile
+	if err := json.Unmarshal(privValKey, &keyFile); err != nil {
+		return "", fmt.Errorf("unmarshal priv_validator_key.json: %w", err)
+	}
+	return fmt.Sprintf(`{"@type":"/cosmos.crypto.ed25519.PubKey","key":%q}`, keyFile.PubKey.Value), nil
+}
@@ -13,10 +13,10 @@ import (
 )
 
 // AuthzGrant grants a message as a permission to an account.
-func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee, authType string, extraFlags ...string) (*sdk.TxResponse, error) {
+func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee, authType string, extraFlags ...string) (TxResult[*authz.MsgGrantResponse], error) {
 	allowed := "send|generic|delegate|unbond|redelegate"
 	if !strings.Contains(allowed, authType) {
-		return nil, fmt.Errorf("invalid auth type: %s allowed: %s", authType, allowed)
+		return TxResult[*authz.MsgGrantResponse]{}, fmt.Errorf("invalid auth type: %s allowed: %s", authType, allowed)
 	}
 
 	cmd := []string{"authz", "grant", grantee, authType}
@@ -32,7 +32,7 @@ func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee
 		}
 
 		if msgTypeIndex == -1 {
-			return nil, fmt.Errorf("missing --msg-type flag when granting generic authz")
+			return TxResult[*authz.MsgGrantResponse]{}, fmt.Errorf("missing --msg-type flag when granting generic authz")
 		}
 
 		extraFlags[msgTypeIndex+1] = PrefixMsgTypeIfRequired(extraFlags[msgTypeIndex+1])
@@ -40,45 +40,30 @@ func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee
 
 	cmd = append(cmd, extraFlags...)
 
-	txHash, err := tn.ExecTx(ctx, granter.KeyName(),
+	return txResult[*authz.MsgGrantResponse](tn.execTxCLI(ctx, granter.KeyName(),
 		append(cmd, "--output", "json")...,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return tn.TxHashToResponse(ctx, txHash)
+	))
 }
 
 // AuthzExec executes an authz MsgExec transaction with a single nested message.
-func (tn *ChainNode) AuthzExec(ctx context.Context, grantee ibc.Wallet, nestedMsgCmd []string) (*sdk.TxResponse, error) {
+func (tn *ChainNode) AuthzExec(ctx context.Context, grantee ibc.Wallet, nestedMsgCmd []string) (TxResult[*authz.MsgExecResponse], error) {
 	fileName := "authz.json"
 	if err := createAuthzJSON(ctx, tn, fileName, nestedMsgCmd); err != nil {
-		return nil, err
+		return TxResult[*authz.MsgExecResponse]{}, err
 	}
 
-	txHash, err := tn.ExecTx(ctx, grantee.KeyName(),
+	return txResult[*authz.MsgExecResponse](tn.execTxCLI(ctx, grantee.KeyName(),
 		"authz", "exec", path.Join(tn.HomeDir(), fileName),
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return tn.TxHashToResponse(ctx, txHash)
+	))
 }
 
 // AuthzRevoke revokes a message as a permission to an account.
-func (tn *ChainNode) AuthzRevoke(ctx context.Context, granter ibc.Wallet, grantee string, msgType string) (*sdk.TxResponse, error) {
+func (tn *ChainNode) AuthzRevoke(ctx context.Context, granter ibc.Wallet, grantee string, msgType string) (TxResult[*authz.MsgRevokeResponse], error) {
 	msgType = PrefixMsgTypeIfRequired(msgType)
 
-	txHash, err := tn.ExecTx(ctx, granter.KeyName(),
-		"authz", "revoke", grantee, msgType,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return tn.TxHashToResponse(ctx, txHash)
+	return execTxResult[*authz.MsgRevokeResponse](ctx, tn, granter.KeyName(), func(signer string) ([]sdk.Msg, error) {
+		return []sdk.Msg{&authz.MsgRevoke{Granter: signer, Grantee: grantee, MsgTypeUrl: msgType}}, nil
+	}, "authz", "revoke", grantee, msgType)
 }
 
 // AuthzQueryGrants queries all grants for a given granter and grantee.
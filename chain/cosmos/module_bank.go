@@ -13,32 +13,55 @@ import (
 )
 
 // BankSend sends tokens from one account to another.
-func (tn *ChainNode) BankSend(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "bank", "send", keyName,
+func (tn *ChainNode) BankSend(ctx context.Context, keyName string, amount ibc.WalletAmount) (TxResult[*banktypes.MsgSendResponse], error) {
+	return execTxResult[*banktypes.MsgSendResponse](ctx, tn, keyName, bankSendMsgs(amount),
+		"bank", "send", keyName,
 		amount.Address, fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
 	)
-	return err
 }
 
 // BankSend sends tokens from one account to another.
-func (tn *ChainNode) BankSendWithNote(ctx context.Context, keyName string, amount ibc.WalletAmount, note string) (string, error) {
-	return tn.ExecTx(ctx, keyName, "bank", "send", keyName, amount.Address,
-		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom), "--note", note)
+func (tn *ChainNode) BankSendWithNote(ctx context.Context, keyName string, amount ibc.WalletAmount, note string) (TxResult[*banktypes.MsgSendResponse], error) {
+	if tn.Chain.Config().GRPCTx {
+		return txResult[*banktypes.MsgSendResponse](tn.broadcastMsgsFunc(ctx, keyName, note, bankSendMsgs(amount)))
+	}
+	return txResult[*banktypes.MsgSendResponse](tn.execTxCLI(ctx, keyName, "bank", "send", keyName, amount.Address,
+		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom), "--note", note))
+}
+
+func bankSendMsgs(amount ibc.WalletAmount) msgsFunc {
+	return func(signer string) ([]types.Msg, error) {
+		return []types.Msg{&banktypes.MsgSend{
+			FromAddress: signer,
+			ToAddress:   amount.Address,
+			Amount:      types.NewCoins(types.NewCoin(amount.Denom, amount.Amount)),
+		}}, nil
+	}
 }
 
 // Deprecated: use BankSend instead.
 func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return tn.BankSend(ctx, keyName, amount)
+	_, err := tn.BankSend(ctx, keyName, amount)
+	return err
 }
 
 // BankMultiSend sends an amount of token from one account to multiple accounts.
-func (tn *ChainNode) BankMultiSend(ctx context.Context, keyName string, addresses []string, amount sdkmath.Int, denom string) error {
+func (tn *ChainNode) BankMultiSend(ctx context.Context, keyName string, addresses []string, amount sdkmath.Int, denom string) (TxResult[*banktypes.MsgMultiSendResponse], error) {
 	cmd := append([]string{"bank", "multi-send", keyName}, addresses...)
 	cmd = append(cmd, fmt.Sprintf("%s%s", amount, denom))
 
-	_, err := tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return execTxResult[*banktypes.MsgMultiSendResponse](ctx, tn, keyName, func(signer string) ([]types.Msg, error) {
+		coins := types.NewCoins(types.NewCoin(denom, amount))
+		outputs := make([]banktypes.Output, len(addresses))
+		for i, addr := range addresses {
+			outputs[i] = banktypes.Output{Address: addr, Coins: coins}
+		}
+		total := types.NewCoins(types.NewCoin(denom, amount.MulRaw(int64(len(addresses)))))
+		return []types.Msg{&banktypes.MsgMultiSend{
+			Inputs:  []banktypes.Input{{Address: signer, Coins: total}},
+			Outputs: outputs,
+		}}, nil
+	}, cmd...)
 }
 
 // GetBalance fetches the current balance for a specific account address and denom.
@@ -53,6 +53,7 @@ func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName
 	cmd := []string{"wasm", "store", path.Join(tn.HomeDir(), file), "--gas", "auto"}
 	cmd = append(cmd, extraExecTxArgs...)
 
+	tn.warnCLIFallback(cmd)
 	if _, err := tn.ExecTx(ctx, keyName, cmd...); err != nil {
 		return "", err
 	}
@@ -82,6 +83,7 @@ func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, co
 	if needsNoAdminFlag {
 		command = append(command, "--no-admin")
 	}
+	tn.warnCLIFallback(command)
 	txHash, err := tn.ExecTx(ctx, keyName, command...)
 	if err != nil {
 		return "", err
@@ -114,6 +116,7 @@ func (tn *ChainNode) ExecuteContract(ctx context.Context, keyName string, contra
 	cmd := []string{"wasm", "execute", contractAddress, message}
 	cmd = append(cmd, extraExecTxArgs...)
 
+	tn.warnCLIFallback(cmd)
 	txHash, err := tn.ExecTx(ctx, keyName, cmd...)
 	if err != nil {
 		return &types.TxResponse{}, err
@@ -166,6 +169,7 @@ func (tn *ChainNode) MigrateContract(ctx context.Context, keyName string, contra
 	cmd := []string{"wasm", "migrate", contractAddress, codeID, message}
 	cmd = append(cmd, extraExecTxArgs...)
 
+	tn.warnCLIFallback(cmd)
 	txHash, err := tn.ExecTx(ctx, keyName, cmd...)
 	if err != nil {
 		return &types.TxResponse{}, err
@@ -198,6 +202,7 @@ func (tn *ChainNode) StoreClientContract(ctx context.Context, keyName string, fi
 	cmd := []string{"ibc-wasm", "store-code", path.Join(tn.HomeDir(), file), "--gas", "auto"}
 	cmd = append(cmd, extraExecTxArgs...)
 
+	tn.warnCLIFallback(cmd)
 	_, err = tn.ExecTx(ctx, keyName, cmd...)
 	if err != nil {
 		return "", err
@@ -2,12 +2,12 @@ package cosmos
 
 import (
 	"context"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 )
 
 // CrisisInvariantBroken executes the crisis invariant broken command.
-func (tn *ChainNode) CrisisInvariantBroken(ctx context.Context, keyName, moduleName, route string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "crisis", "invariant-broken", moduleName, route,
-	)
-	return err
+// Message responses are left undecoded as x/crisis is not part of the default codec.
+func (tn *ChainNode) CrisisInvariantBroken(ctx context.Context, keyName, moduleName, route string) (TxResult[*codectypes.Any], error) {
+	return execTxResult[*codectypes.Any](ctx, tn, keyName, nil, "crisis", "invariant-broken", moduleName, route)
 }
@@ -8,49 +8,71 @@ import (
 )
 
 // DistributionFundCommunityPool funds the community pool with the specified amount of coins.
-func (tn *ChainNode) DistributionFundCommunityPool(ctx context.Context, keyName, amount string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "distribution", "fund-community-pool", amount,
-	)
-	return err
+func (tn *ChainNode) DistributionFundCommunityPool(ctx context.Context, keyName, amount string) (TxResult[*distrtypes.MsgFundCommunityPoolResponse], error) {
+	return execTxResult[*distrtypes.MsgFundCommunityPoolResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coins, err := sdk.ParseCoinsNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{&distrtypes.MsgFundCommunityPool{Amount: coins, Depositor: signer}}, nil
+	}, "distribution", "fund-community-pool", amount)
 }
 
-func (tn *ChainNode) DistributionFundValidatorRewardsPool(ctx context.Context, keyName, valAddr, amount string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "distribution", "fund-validator-rewards-pool", valAddr, amount,
-	)
-	return err
+func (tn *ChainNode) DistributionFundValidatorRewardsPool(ctx context.Context, keyName, valAddr, amount string) (TxResult[*distrtypes.MsgDepositValidatorRewardsPoolResponse], error) {
+	return execTxResult[*distrtypes.MsgDepositValidatorRewardsPoolResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coins, err := sdk.ParseCoinsNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{&distrtypes.MsgDepositValidatorRewardsPool{
+			Depositor:        signer,
+			ValidatorAddress: valAddr,
+			Amount:           coins,
+		}}, nil
+	}, "distribution", "fund-validator-rewards-pool", valAddr, amount)
 }
 
 // DistributionSetWithdrawAddr change the default withdraw address for rewards associated with an address.
-func (tn *ChainNode) DistributionSetWithdrawAddr(ctx context.Context, keyName, withdrawAddr string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "distribution", "set-withdraw-addr", withdrawAddr,
-	)
-	return err
+func (tn *ChainNode) DistributionSetWithdrawAddr(ctx context.Context, keyName, withdrawAddr string) (TxResult[*distrtypes.MsgSetWithdrawAddressResponse], error) {
+	return execTxResult[*distrtypes.MsgSetWithdrawAddressResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		return []sdk.Msg{&distrtypes.MsgSetWithdrawAddress{DelegatorAddress: signer, WithdrawAddress: withdrawAddr}}, nil
+	}, "distribution", "set-withdraw-addr", withdrawAddr)
 }
 
 // DistributionWithdrawAllRewards withdraws all delegations rewards for a delegator.
-func (tn *ChainNode) DistributionWithdrawAllRewards(ctx context.Context, keyName string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "distribution", "withdraw-all-rewards",
-	)
-	return err
+func (tn *ChainNode) DistributionWithdrawAllRewards(ctx context.Context, keyName string) (TxResult[*distrtypes.MsgWithdrawDelegatorRewardResponse], error) {
+	return execTxResult[*distrtypes.MsgWithdrawDelegatorRewardResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		res, err := distrtypes.NewQueryClient(tn.GrpcConn).DelegatorValidators(ctx, &distrtypes.QueryDelegatorValidatorsRequest{
+			DelegatorAddress: signer,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msgs := make([]sdk.Msg, len(res.Validators))
+		for i, valAddr := range res.Validators {
+			msgs[i] = &distrtypes.MsgWithdrawDelegatorReward{DelegatorAddress: signer, ValidatorAddress: valAddr}
+		}
+		return msgs, nil
+	}, "distribution", "withdraw-all-rewards")
 }
 
 // DistributionWithdrawValidatorRewards withdraws all delegations rewards for a delegator.
-// If includeCommission is true, it also withdraws the validator's commission.
-func (tn *ChainNode) DistributionWithdrawValidatorRewards(ctx context.Context, keyName, valAddr string, includeCommission bool) error {
+// If includeCommission is true, it also withdraws the validator's commission,
+// whose response is available in the result's TxResponse.
+func (tn *ChainNode) DistributionWithdrawValidatorRewards(ctx context.Context, keyName, valAddr string, includeCommission bool) (TxResult[*distrtypes.MsgWithdrawDelegatorRewardResponse], error) {
 	cmd := []string{"distribution", "withdraw-rewards", valAddr}
 
 	if includeCommission {
 		cmd = append(cmd, "--commission")
 	}
 
-	_, err := tn.ExecTx(ctx,
-		keyName, cmd...,
-	)
-	return err
+	return execTxResult[*distrtypes.MsgWithdrawDelegatorRewardResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		msgs := []sdk.Msg{&distrtypes.MsgWithdrawDelegatorReward{DelegatorAddress: signer, ValidatorAddress: valAddr}}
+		if includeCommission {
+			msgs = append(msgs, &distrtypes.MsgWithdrawValidatorCommission{ValidatorAddress: valAddr})
+		}
+		return msgs, nil
+	}, cmd...)
 }
 
 // DistributionCommission returns the validator's commission.
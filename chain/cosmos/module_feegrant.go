@@ -5,11 +5,13 @@ import (
 	"strings"
 	"time"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
 )
 
 // FeeGrant grants a fee grant.
-func (tn *ChainNode) FeeGrant(ctx context.Context, granterKey, grantee, spendLimit string, allowedMsgs []string, expiration time.Time, extraFlags ...string) error {
+func (tn *ChainNode) FeeGrant(ctx context.Context, granterKey, grantee, spendLimit string, allowedMsgs []string, expiration time.Time, extraFlags ...string) (TxResult[*feegrant.MsgGrantAllowanceResponse], error) {
 	cmd := []string{"feegrant", "grant", granterKey, grantee, "--spend-limit", spendLimit}
 
 	if len(allowedMsgs) > 0 {
@@ -28,14 +30,43 @@ func (tn *ChainNode) FeeGrant(ctx context.Context, granterKey, grantee, spendLim
 
 	cmd = append(cmd, extraFlags...)
 
-	_, err := tn.ExecTx(ctx, granterKey, cmd...)
-	return err
+	var msgs msgsFunc
+	if len(extraFlags) == 0 {
+		msgs = func(signer string) ([]sdk.Msg, error) {
+			spend, err := sdk.ParseCoinsNormalized(spendLimit)
+			if err != nil {
+				return nil, err
+			}
+			basic := &feegrant.BasicAllowance{SpendLimit: spend}
+			if expiration.After(time.Now()) {
+				basic.Expiration = &expiration
+			}
+			var allowance feegrant.FeeAllowanceI = basic
+			if len(allowedMsgs) > 0 {
+				msgTypes := make([]string, len(allowedMsgs))
+				for i, msg := range allowedMsgs {
+					msgTypes[i] = PrefixMsgTypeIfRequired(msg)
+				}
+				if allowance, err = feegrant.NewAllowedMsgAllowance(basic, msgTypes); err != nil {
+					return nil, err
+				}
+			}
+			anyAllowance, err := codectypes.NewAnyWithValue(allowance)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{&feegrant.MsgGrantAllowance{Granter: signer, Grantee: grantee, Allowance: anyAllowance}}, nil
+		}
+	}
+
+	return execTxResult[*feegrant.MsgGrantAllowanceResponse](ctx, tn, granterKey, msgs, cmd...)
 }
 
 // FeeGrantRevoke revokes a fee grant.
-func (tn *ChainNode) FeeGrantRevoke(ctx context.Context, keyName, granterAddr, granteeAddr string) error {
-	_, err := tn.ExecTx(ctx, keyName, "feegrant", "revoke", granterAddr, granteeAddr)
-	return err
+func (tn *ChainNode) FeeGrantRevoke(ctx context.Context, keyName, granterAddr, granteeAddr string) (TxResult[*feegrant.MsgRevokeAllowanceResponse], error) {
+	return execTxResult[*feegrant.MsgRevokeAllowanceResponse](ctx, tn, keyName, func(string) ([]sdk.Msg, error) {
+		return []sdk.Msg{&feegrant.MsgRevokeAllowance{Granter: granterAddr, Grantee: granteeAddr}}, nil
+	}, "feegrant", "revoke", granterAddr, granteeAddr)
 }
 
 // FeeGrantGetAllowance returns the allowance of a granter and grantee pair.
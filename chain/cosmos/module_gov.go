@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strconv"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
 	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
@@ -18,25 +20,48 @@ import (
 )
 
 // VoteOnProposal submits a vote for the specified proposal.
-func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID uint64, vote string) error {
-	_, err := tn.ExecTx(ctx, keyName,
-		"gov", "vote",
-		fmt.Sprintf("%d", proposalID), vote, "--gas", "auto",
-	)
-	return err
+func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID uint64, vote string) (TxResult[*govv1.MsgVoteResponse], error) {
+	return execTxResult[*govv1.MsgVoteResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		option, err := govv1.VoteOptionFromString(govutils.NormalizeVoteOption(vote))
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{&govv1.MsgVote{ProposalId: proposalID, Voter: signer, Option: option}}, nil
+	}, "gov", "vote", fmt.Sprintf("%d", proposalID), vote, "--gas", "auto")
 }
 
 // SubmitProposal submits a gov v1 proposal to the chain.
-func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (string, error) {
+func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (res TxResult[*govv1.MsgSubmitProposalResponse], _ error) {
+	if tn.Chain.Config().GRPCTx {
+		return txResult[*govv1.MsgSubmitProposalResponse](tn.broadcastMsgsFunc(ctx, keyName, "", func(signer string) ([]sdk.Msg, error) {
+			cdc := tn.Chain.Config().EncodingConfig.Codec
+			msgs := make([]sdk.Msg, len(prop.Messages))
+			for i, raw := range prop.Messages {
+				if err := cdc.UnmarshalInterfaceJSON(raw, &msgs[i]); err != nil {
+					return nil, fmt.Errorf("decode proposal message %d: %w", i, err)
+				}
+			}
+			deposit, err := sdk.ParseCoinsNormalized(prop.Deposit)
+			if err != nil {
+				return nil, err
+			}
+			msg, err := govv1.NewMsgSubmitProposal(msgs, deposit, signer, prop.Metadata, prop.Title, prop.Summary, prop.Expedited)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{msg}, nil
+		}))
+	}
+
 	file := "proposal.json"
 	propJSON, err := json.MarshalIndent(prop, "", " ")
 	if err != nil {
-		return "", err
+		return res, err
 	}
 
 	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, file, propJSON); err != nil {
-		return "", fmt.Errorf("writing contract file to docker volume: %w", err)
+		return res, fmt.Errorf("writing contract file to docker volume: %w", err)
 	}
 
 	command := []string{
@@ -44,23 +69,23 @@ func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop Tx
 		path.Join(tn.HomeDir(), file), "--gas", "auto",
 	}
 
-	return tn.ExecTx(ctx, keyName, command...)
+	return execTxResult[*govv1.MsgSubmitProposalResponse](ctx, tn, keyName, nil, command...)
 }
 
 // GovSubmitProposal is an alias for SubmitProposal.
-func (tn *ChainNode) GovSubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (string, error) {
+func (tn *ChainNode) GovSubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (TxResult[*govv1.MsgSubmitProposalResponse], error) {
 	return tn.SubmitProposal(ctx, keyName, prop)
 }
 
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
-func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
+func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (res TxResult[*govv1.MsgSubmitProposalResponse], _ error) {
 	if tn.IsAboveSDK47(ctx) {
 		cosmosChain := tn.Chain.(*CosmosChain)
 
 		if prop.Authority == "" {
 			authority, err := cosmosChain.GetGovernanceAddress(ctx)
 			if err != nil {
-				return "", err
+				return res, err
 			}
 			prop.Authority = authority
 		}
@@ -76,7 +101,7 @@ func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop S
 
 		proposal, err := cosmosChain.BuildProposal([]ProtoMessage{&msg}, prop.Title, prop.Description, "", prop.Deposit, prop.Proposer, prop.Expedited)
 		if err != nil {
-			return "", err
+			return res, err
 		}
 		return tn.SubmitProposal(ctx, keyName, proposal)
 	}
@@ -93,11 +118,11 @@ func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop S
 		command = append(command, "--upgrade-info", prop.Info)
 	}
 
-	return tn.ExecTx(ctx, keyName, command...)
+	return execTxResult[*govv1.MsgSubmitProposalResponse](ctx, tn, keyName, nil, command...)
 }
 
 // TextProposal submits a text governance proposal to the chain.
-func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
+func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (TxResult[*govv1.MsgSubmitProposalResponse], error) {
 	command := []string{
 		"gov", "submit-proposal",
 		"--type", "text",
@@ -108,21 +133,32 @@ func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop Text
 	if prop.Expedited {
 		command = append(command, "--is-expedited=true")
 	}
-	return tn.ExecTx(ctx, keyName, command...)
+	return execTxResult[*govv1.MsgSubmitProposalResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		deposit, err := sdk.ParseCoinsNormalized(prop.Deposit)
+		if err != nil {
+			return nil, err
+		}
+		// A gov v1 proposal without messages is a text proposal.
+		msg, err := govv1.NewMsgSubmitProposal(nil, deposit, signer, "", prop.Title, prop.Description, prop.Expedited)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{msg}, nil
+	}, command...)
 }
 
 // ParamChangeProposal submits a param change proposal to the chain, signed by keyName.
-func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop *paramsutils.ParamChangeProposalJSON) (string, error) {
+func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop *paramsutils.ParamChangeProposalJSON) (res TxResult[*govv1.MsgSubmitProposalResponse], _ error) {
 	content, err := json.Marshal(prop)
 	if err != nil {
-		return "", err
+		return res, err
 	}
 
 	hash := sha256.Sum256(content)
 	proposalFilename := fmt.Sprintf("%x.json", hash)
 	err = tn.WriteFile(ctx, content, proposalFilename)
 	if err != nil {
-		return "", fmt.Errorf("writing param change proposal: %w", err)
+		return res, fmt.Errorf("writing param change proposal: %w", err)
 	}
 
 	proposalPath := filepath.Join(tn.HomeDir(), proposalFilename)
@@ -133,7 +169,7 @@ func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, pr
 		proposalPath,
 	}
 
-	return tn.ExecTx(ctx, keyName, command...)
+	return execTxResult[*govv1.MsgSubmitProposalResponse](ctx, tn, keyName, nil, command...)
 }
 
 // Build a gov v1 proposal type.
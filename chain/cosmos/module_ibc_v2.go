@@ -0,0 +1,245 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	transfertypes "github.com/cosmos/ibc-go/v11/modules/apps/transfer/types"
+	channeltypesv2 "github.com/cosmos/ibc-go/v11/modules/core/04-channel/v2/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// SendIBCV2Transfer sends an ICS-20 transfer over IBC v2, routed by the source clientID instead of a channel.
+// Timeout heights are not supported by IBC v2, so only options.Timeout.NanoSeconds is honored.
+func (tn *ChainNode) SendIBCV2Transfer(
+	ctx context.Context,
+	clientID string,
+	keyName string,
+	amount ibc.WalletAmount,
+	options ibc.TransferOptions,
+) (string, error) {
+	port := "transfer"
+	if options.Port != "" {
+		port = options.Port
+	}
+	command := []string{
+		"ibc-transfer", "transfer", port, clientID,
+		amount.Address, fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
+		"--packet-timeout-height", "0-0",
+		"--gas", "auto",
+	}
+	if options.Timeout != nil {
+		if options.Timeout.Height > 0 {
+			return "", fmt.Errorf("ibc v2 packets do not support timeout heights")
+		}
+		if options.Timeout.NanoSeconds > 0 {
+			command = append(command, "--packet-timeout-timestamp", fmt.Sprint(options.Timeout.NanoSeconds))
+		}
+		if options.AbsoluteTimeouts {
+			command = append(command, "--absolute-timeouts")
+		}
+	}
+	if options.Memo != "" {
+		command = append(command, "--memo", options.Memo)
+	}
+	return tn.ExecTx(ctx, keyName, command...)
+}
+
+// SendIBCV2Transfer sends an ICS-20 transfer over IBC v2 from the source clientID
+// and returns the resulting packet.
+func (c *CosmosChain) SendIBCV2Transfer(
+	ctx context.Context,
+	clientID string,
+	keyName string,
+	amount ibc.WalletAmount,
+	options ibc.TransferOptions,
+) (ibc.TxV2, error) {
+	txHash, err := c.GetFullNode().SendIBCV2Transfer(ctx, clientID, keyName, amount, options)
+	if err != nil {
+		return ibc.TxV2{}, fmt.Errorf("send ibc v2 transfer: %w", err)
+	}
+	txResp, err := c.GetTransaction(txHash)
+	if err != nil {
+		return ibc.TxV2{}, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	return txV2FromResponse(txResp)
+}
+
+// SendIBCV2Packet broadcasts a MsgSendPacket from user over the source clientID carrying the given payloads.
+// timeoutTimestamp is an absolute unix timestamp in seconds. Each payload is delivered to the application
+// bound to its source port, which must accept user as the packet signer.
+func (c *CosmosChain) SendIBCV2Packet(
+	ctx context.Context,
+	broadcaster *Broadcaster,
+	user User,
+	clientID string,
+	timeoutTimestamp uint64,
+	payloads ...ibc.PayloadV2,
+) (ibc.TxV2, error) {
+	msg := channeltypesv2.NewMsgSendPacket(clientID, timeoutTimestamp, user.FormattedAddress(), toProtoPayloads(payloads)...)
+	txResp, err := BroadcastTx(ctx, broadcaster, user, msg)
+	if err != nil {
+		return ibc.TxV2{}, fmt.Errorf("send ibc v2 packet: %w", err)
+	}
+	return txV2FromResponse(&txResp)
+}
+
+// ICS20PayloadV2 returns a payload transferring amount from sender to receiver through the transfer application.
+func ICS20PayloadV2(sender string, amount ibc.WalletAmount, memo string) ibc.PayloadV2 {
+	data := transfertypes.NewFungibleTokenPacketData(amount.Denom, amount.Amount.String(), sender, amount.Address, memo)
+	return ibc.PayloadV2{
+		SourcePort: transfertypes.PortID,
+		DestPort:   transfertypes.PortID,
+		Version:    transfertypes.V1,
+		Encoding:   transfertypes.EncodingJSON,
+		Value:      data.GetBytes(),
+	}
+}
+
+func txV2FromResponse(txResp *sdk.TxResponse) (ibc.TxV2, error) {
+	var tx ibc.TxV2
+	if txResp.Code != 0 {
+		return tx, fmt.Errorf("error in transaction (code: %d): %s", txResp.Code, txResp.RawLog)
+	}
+	tx.Height = txResp.Height
+	tx.TxHash = txResp.TxHash
+	// In cosmos, user is charged for entire gas requested, not the actual gas used.
+	tx.GasSpent = txResp.GasWanted
+
+	packetHex, ok := Events(txResp.Events).AttributeValue(channeltypesv2.EventTypeSendPacket, channeltypesv2.AttributeKeyEncodedPacketHex)
+	if !ok {
+		return tx, fmt.Errorf("no ibc v2 %s event in transaction %s", channeltypesv2.EventTypeSendPacket, txResp.TxHash)
+	}
+	bz, err := hex.DecodeString(packetHex)
+	if err != nil {
+		return tx, fmt.Errorf("malformed packet hex %s: %w", packetHex, err)
+	}
+	var packet channeltypesv2.Packet
+	if err := packet.Unmarshal(bz); err != nil {
+		return tx, fmt.Errorf("unmarshal ibc v2 packet: %w", err)
+	}
+	tx.Packet = fromProtoPacket(packet)
+	return tx, nil
+}
+
+// AcknowledgementsV2 returns all IBC v2 acknowledgements in a block at height.
+func (c *CosmosChain) AcknowledgementsV2(ctx context.Context, height int64) ([]ibc.PacketAcknowledgementV2, error) {
+	var acks []*channeltypesv2.MsgAcknowledgement
+	err := RangeBlockMessages(ctx, c.cfg.EncodingConfig.InterfaceRegistry, c.GetFullNode().Client, height, func(msg sdk.Msg) bool {
+		found, ok := msg.(*channeltypesv2.MsgAcknowledgement)
+		if ok {
+			acks = append(acks, found)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find ibc v2 acknowledgements at height %d: %w", height, err)
+	}
+	ibcAcks := make([]ibc.PacketAcknowledgementV2, len(acks))
+	for i, ack := range acks {
+		ibcAcks[i] = ibc.PacketAcknowledgementV2{
+			Packet:              fromProtoPacket(ack.Packet),
+			AppAcknowledgements: ack.Acknowledgement.AppAcknowledgements,
+		}
+	}
+	return ibcAcks, nil
+}
+
+// TimeoutsV2 returns all IBC v2 timeouts in a block at height.
+func (c *CosmosChain) TimeoutsV2(ctx context.Context, height int64) ([]ibc.PacketTimeoutV2, error) {
+	var timeouts []*channeltypesv2.MsgTimeout
+	err := RangeBlockMessages(ctx, c.cfg.EncodingConfig.InterfaceRegistry, c.GetFullNode().Client, height, func(msg sdk.Msg) bool {
+		found, ok := msg.(*channeltypesv2.MsgTimeout)
+		if ok {
+			timeouts = append(timeouts, found)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find ibc v2 timeouts at height %d: %w", height, err)
+	}
+	ibcTimeouts := make([]ibc.PacketTimeoutV2, len(timeouts))
+	for i, timeout := range timeouts {
+		ibcTimeouts[i] = ibc.PacketTimeoutV2{Packet: fromProtoPacket(timeout.Packet)}
+	}
+	return ibcTimeouts, nil
+}
+
+// IBCV2QueryNextSequenceSend returns the sequence the next packet sent over clientID will use.
+func (c *CosmosChain) IBCV2QueryNextSequenceSend(ctx context.Context, clientID string) (uint64, error) {
+	res, err := channeltypesv2.NewQueryClient(c.GetNode().GrpcConn).NextSequenceSend(ctx, &channeltypesv2.QueryNextSequenceSendRequest{
+		ClientId: clientID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.NextSequenceSend, nil
+}
+
+// IBCV2QueryPacketCommitment returns the commitment of a sent packet, which is empty once the packet is acknowledged or timed out.
+func (c *CosmosChain) IBCV2QueryPacketCommitment(ctx context.Context, clientID string, sequence uint64) ([]byte, error) {
+	res, err := channeltypesv2.NewQueryClient(c.GetNode().GrpcConn).PacketCommitment(ctx, &channeltypesv2.QueryPacketCommitmentRequest{
+		ClientId: clientID,
+		Sequence: sequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Commitment, nil
+}
+
+// IBCV2QueryPacketAcknowledgement returns the acknowledgement commitment written for a received packet.
+func (c *CosmosChain) IBCV2QueryPacketAcknowledgement(ctx context.Context, clientID string, sequence uint64) ([]byte, error) {
+	res, err := channeltypesv2.NewQueryClient(c.GetNode().GrpcConn).PacketAcknowledgement(ctx, &channeltypesv2.QueryPacketAcknowledgementRequest{
+		ClientId: clientID,
+		Sequence: sequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Acknowledgement, nil
+}
+
+// IBCV2QueryPacketReceipt reports whether a packet sent to clientID has been received.
+func (c *CosmosChain) IBCV2QueryPacketReceipt(ctx context.Context, clientID string, sequence uint64) (bool, error) {
+	res, err := channeltypesv2.NewQueryClient(c.GetNode().GrpcConn).PacketReceipt(ctx, &channeltypesv2.QueryPacketReceiptRequest{
+		ClientId: clientID,
+		Sequence: sequence,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.Received, nil
+}
+
+func fromProtoPacket(packet channeltypesv2.Packet) ibc.PacketV2 {
+	payloads := make([]ibc.PayloadV2, len(packet.Payloads))
+	for i, p := range packet.Payloads {
+		payloads[i] = ibc.PayloadV2{
+			SourcePort: p.SourcePort,
+			DestPort:   p.DestinationPort,
+			Version:    p.Version,
+			Encoding:   p.Encoding,
+			Value:      p.Value,
+		}
+	}
+	return ibc.PacketV2{
+		Sequence:         packet.Sequence,
+		SourceClient:     packet.SourceClient,
+		DestClient:       packet.DestinationClient,
+		TimeoutTimestamp: packet.TimeoutTimestamp,
+		Payloads:         payloads,
+	}
+}
+
+func toProtoPayloads(payloads []ibc.PayloadV2) []channeltypesv2.Payload {
+	out := make([]channeltypesv2.Payload, len(payloads))
+	for i, p := range payloads {
+		out[i] = channeltypesv2.NewPayload(p.SourcePort, p.DestPort, p.Version, p.Encoding, p.Value)
+	}
+	return out
+}
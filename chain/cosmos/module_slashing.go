@@ -3,15 +3,24 @@ package cosmos
 import (
 	"context"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 )
 
 // SlashingUnJail unjails a validator.
-func (tn *ChainNode) SlashingUnJail(ctx context.Context, keyName string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "slashing", "unjail",
-	)
-	return err
+func (tn *ChainNode) SlashingUnJail(ctx context.Context, keyName string) (TxResult[*slashingtypes.MsgUnjailResponse], error) {
+	return execTxResult[*slashingtypes.MsgUnjailResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		chain := tn.Chain.(*CosmosChain)
+		addr, err := chain.AccAddressFromBech32(signer)
+		if err != nil {
+			return nil, err
+		}
+		valAddr, err := chain.ValAddressToBech32(sdk.ValAddress(addr))
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{&slashingtypes.MsgUnjail{ValidatorAddr: valAddr}}, nil
+	}, "slashing", "unjail")
 }
 
 // SlashingGetParams returns slashing params.
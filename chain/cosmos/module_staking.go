@@ -4,58 +4,70 @@ import (
 	"context"
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // StakingCancelUnbond cancels an unbonding delegation.
-func (tn *ChainNode) StakingCancelUnbond(ctx context.Context, keyName, validatorAddr, coinAmt string, creationHeight int64) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "staking", "cancel-unbond", validatorAddr, coinAmt, fmt.Sprintf("%d", creationHeight),
-	)
-	return err
+func (tn *ChainNode) StakingCancelUnbond(ctx context.Context, keyName, validatorAddr, coinAmt string, creationHeight int64) (TxResult[*stakingtypes.MsgCancelUnbondingDelegationResponse], error) {
+	return execTxResult[*stakingtypes.MsgCancelUnbondingDelegationResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coin, err := sdk.ParseCoinNormalized(coinAmt)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{&stakingtypes.MsgCancelUnbondingDelegation{
+			DelegatorAddress: signer,
+			ValidatorAddress: validatorAddr,
+			Amount:           coin,
+			CreationHeight:   creationHeight,
+		}}, nil
+	}, "staking", "cancel-unbond", validatorAddr, coinAmt, fmt.Sprintf("%d", creationHeight))
 }
 
 // StakingCreateValidator creates a new validator.
-func (tn *ChainNode) StakingCreateValidator(ctx context.Context, keyName, valFilePath string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "staking", "create-validator", valFilePath,
-	)
-	return err
+func (tn *ChainNode) StakingCreateValidator(ctx context.Context, keyName, valFilePath string) (TxResult[*stakingtypes.MsgCreateValidatorResponse], error) {
+	return execTxResult[*stakingtypes.MsgCreateValidatorResponse](ctx, tn, keyName, nil, "staking", "create-validator", valFilePath)
 }
 
 // StakingDelegate delegates tokens to a validator.
-func (tn *ChainNode) StakingDelegate(ctx context.Context, keyName, validatorAddr, amount string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "staking", "delegate", validatorAddr, amount,
-	)
-	return err
+func (tn *ChainNode) StakingDelegate(ctx context.Context, keyName, validatorAddr, amount string) (TxResult[*stakingtypes.MsgDelegateResponse], error) {
+	return execTxResult[*stakingtypes.MsgDelegateResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coin, err := sdk.ParseCoinNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{stakingtypes.NewMsgDelegate(signer, validatorAddr, coin)}, nil
+	}, "staking", "delegate", validatorAddr, amount)
 }
 
 // StakingUnbond unstakes tokens from a validator.
-func (tn *ChainNode) StakingUnbond(ctx context.Context, keyName, validatorAddr, amount string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "staking", "unbond", validatorAddr, amount,
-	)
-	return err
+func (tn *ChainNode) StakingUnbond(ctx context.Context, keyName, validatorAddr, amount string) (TxResult[*stakingtypes.MsgUndelegateResponse], error) {
+	return execTxResult[*stakingtypes.MsgUndelegateResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coin, err := sdk.ParseCoinNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{stakingtypes.NewMsgUndelegate(signer, validatorAddr, coin)}, nil
+	}, "staking", "unbond", validatorAddr, amount)
 }
 
 // StakingEditValidator edits an existing validator.
-func (tn *ChainNode) StakingEditValidator(ctx context.Context, keyName string, flags ...string) error {
+func (tn *ChainNode) StakingEditValidator(ctx context.Context, keyName string, flags ...string) (TxResult[*stakingtypes.MsgEditValidatorResponse], error) {
 	cmd := []string{"staking", "edit-validator"}
 	cmd = append(cmd, flags...)
 
-	_, err := tn.ExecTx(ctx,
-		keyName, cmd...,
-	)
-	return err
+	return execTxResult[*stakingtypes.MsgEditValidatorResponse](ctx, tn, keyName, nil, cmd...)
 }
 
 // StakingRedelegate redelegates tokens from one validator to another.
-func (tn *ChainNode) StakingRedelegate(ctx context.Context, keyName, srcValAddr, dstValAddr, amount string) error {
-	_, err := tn.ExecTx(ctx,
-		keyName, "staking", "redelegate", srcValAddr, dstValAddr, amount,
-	)
-	return err
+func (tn *ChainNode) StakingRedelegate(ctx context.Context, keyName, srcValAddr, dstValAddr, amount string) (TxResult[*stakingtypes.MsgBeginRedelegateResponse], error) {
+	return execTxResult[*stakingtypes.MsgBeginRedelegateResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		coin, err := sdk.ParseCoinNormalized(amount)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{stakingtypes.NewMsgBeginRedelegate(signer, srcValAddr, dstValAddr, coin)}, nil
+	}, "staking", "redelegate", srcValAddr, dstValAddr, amount)
 }
 
 // StakingCreateValidatorFile creates a new validator file for use in `StakingCreateValidator`.
@@ -6,82 +6,142 @@ import (
 	"fmt"
 	"strconv"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
 	"github.com/cosmos/interchaintest/v11/ibc"
 )
 
+// tokenFactoryMsgTypePrefix is the type URL prefix of the tokenfactory messages.
+const tokenFactoryMsgTypePrefix = "/osmosis.tokenfactory.v1beta1."
+
 // TokenFactoryCreateDenom creates a new tokenfactory token in the format 'factory/accountaddress/name'.
 // This token will be viewable by standard bank balance queries and send functionality.
 // Depending on the chain parameters, this may require a lot of gas (Juno, Osmosis) if the DenomCreationGasConsume param is enabled.
 // If not, the default implementation cost 10,000,000 micro tokens (utoken) of the chain's native token.
-func (tn *ChainNode) TokenFactoryCreateDenom(ctx context.Context, user ibc.Wallet, denomName string, gas uint64) (string, string, error) {
+// A non-zero gas is only used by the CLI; gRPC transactions always simulate their gas.
+func (tn *ChainNode) TokenFactoryCreateDenom(ctx context.Context, user ibc.Wallet, denomName string, gas uint64) (string, TxResult[*TokenFactoryCreateDenomResponse], error) {
 	cmd := []string{"tokenfactory", "create-denom", denomName}
 
 	if gas != 0 {
 		cmd = append(cmd, "--gas", strconv.FormatUint(gas, 10))
 	}
 
-	txHash, err := tn.ExecTx(ctx, user.KeyName(), cmd...)
+	res, err := execTxResult[*TokenFactoryCreateDenomResponse](ctx, tn, user.KeyName(), tn.tokenFactoryMsgs("MsgCreateDenom", map[string]any{
+		"subdenom": denomName,
+	}), cmd...)
 	if err != nil {
-		return "", "", err
+		return "", res, err
 	}
 
-	return "factory/" + user.FormattedAddress() + "/" + denomName, txHash, nil
+	return "factory/" + user.FormattedAddress() + "/" + denomName, res, nil
 }
 
 // TokenFactoryBurnDenom burns a tokenfactory denomination from the holders account.
-func (tn *ChainNode) TokenFactoryBurnDenom(ctx context.Context, keyName, fullDenom string, amount uint64) (string, error) {
+func (tn *ChainNode) TokenFactoryBurnDenom(ctx context.Context, keyName, fullDenom string, amount uint64) (TxResult[*TokenFactoryBurnResponse], error) {
 	coin := strconv.FormatUint(amount, 10) + fullDenom
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "burn", coin,
-	)
+	return execTxResult[*TokenFactoryBurnResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgBurn", map[string]any{
+		"amount": tokenFactoryCoin(amount, fullDenom),
+	}), "tokenfactory", "burn", coin)
 }
 
 // TokenFactoryBurnDenomFrom burns a tokenfactory denomination from any other users account.
 // Only the admin of the token can perform this action.
-func (tn *ChainNode) TokenFactoryBurnDenomFrom(ctx context.Context, keyName, fullDenom string, amount uint64, fromAddr string) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "burn-from", fromAddr, convertToCoin(amount, fullDenom),
-	)
+func (tn *ChainNode) TokenFactoryBurnDenomFrom(ctx context.Context, keyName, fullDenom string, amount uint64, fromAddr string) (TxResult[*TokenFactoryBurnResponse], error) {
+	return execTxResult[*TokenFactoryBurnResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgBurn", map[string]any{
+		"amount":          tokenFactoryCoin(amount, fullDenom),
+		"burnFromAddress": fromAddr,
+	}), "tokenfactory", "burn-from", fromAddr, convertToCoin(amount, fullDenom))
 }
 
 // TokenFactoryChangeAdmin moves the admin of a tokenfactory token to a new address.
-func (tn *ChainNode) TokenFactoryChangeAdmin(ctx context.Context, keyName, fullDenom, newAdmin string) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "change-admin", fullDenom, newAdmin,
-	)
+func (tn *ChainNode) TokenFactoryChangeAdmin(ctx context.Context, keyName, fullDenom, newAdmin string) (TxResult[*TokenFactoryChangeAdminResponse], error) {
+	return execTxResult[*TokenFactoryChangeAdminResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgChangeAdmin", map[string]any{
+		"denom":     fullDenom,
+		"new_admin": newAdmin,
+	}), "tokenfactory", "change-admin", fullDenom, newAdmin)
 }
 
 // TokenFactoryForceTransferDenom force moves a token from 1 account to another.
 // Only the admin of the token can perform this action.
-func (tn *ChainNode) TokenFactoryForceTransferDenom(ctx context.Context, keyName, fullDenom string, amount uint64, fromAddr, toAddr string) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "force-transfer", convertToCoin(amount, fullDenom), fromAddr, toAddr,
-	)
+func (tn *ChainNode) TokenFactoryForceTransferDenom(ctx context.Context, keyName, fullDenom string, amount uint64, fromAddr, toAddr string) (TxResult[*TokenFactoryForceTransferResponse], error) {
+	return execTxResult[*TokenFactoryForceTransferResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgForceTransfer", map[string]any{
+		"amount":              tokenFactoryCoin(amount, fullDenom),
+		"transferFromAddress": fromAddr,
+		"transferToAddress":   toAddr,
+	}), "tokenfactory", "force-transfer", convertToCoin(amount, fullDenom), fromAddr, toAddr)
 }
 
 // TokenFactoryMintDenom mints a tokenfactory denomination to the admins account.
 // Only the admin of the token can perform this action.
-func (tn *ChainNode) TokenFactoryMintDenom(ctx context.Context, keyName, fullDenom string, amount uint64) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "mint", convertToCoin(amount, fullDenom),
-	)
+func (tn *ChainNode) TokenFactoryMintDenom(ctx context.Context, keyName, fullDenom string, amount uint64) (TxResult[*TokenFactoryMintResponse], error) {
+	return execTxResult[*TokenFactoryMintResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgMint", map[string]any{
+		"amount": tokenFactoryCoin(amount, fullDenom),
+	}), "tokenfactory", "mint", convertToCoin(amount, fullDenom))
 }
 
 // TokenFactoryMintDenomTo mints a token to any external account.
 // Only the admin of the token can perform this action.
-func (tn *ChainNode) TokenFactoryMintDenomTo(ctx context.Context, keyName, fullDenom string, amount uint64, toAddr string) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "mint-to", toAddr, convertToCoin(amount, fullDenom),
-	)
+func (tn *ChainNode) TokenFactoryMintDenomTo(ctx context.Context, keyName, fullDenom string, amount uint64, toAddr string) (TxResult[*TokenFactoryMintResponse], error) {
+	return execTxResult[*TokenFactoryMintResponse](ctx, tn, keyName, tn.tokenFactoryMsgs("MsgMint", map[string]any{
+		"amount":        tokenFactoryCoin(amount, fullDenom),
+		"mintToAddress": toAddr,
+	}), "tokenfactory", "mint-to", toAddr, convertToCoin(amount, fullDenom))
 }
 
 // TokenFactoryMetadata sets the x/bank metadata for a tokenfactory token. This gives the token more detailed information to be queried
 // by frontend UIs and other applications.
 // Only the admin of the token can perform this action.
-func (tn *ChainNode) TokenFactoryMetadata(ctx context.Context, keyName, fullDenom, ticker, description string, exponent uint64) (string, error) {
-	return tn.ExecTx(ctx, keyName,
-		"tokenfactory", "modify-metadata", fullDenom, ticker, description, strconv.FormatUint(exponent, 10),
-	)
+func (tn *ChainNode) TokenFactoryMetadata(ctx context.Context, keyName, fullDenom, ticker, description string, exponent uint64) (TxResult[*TokenFactorySetDenomMetadataResponse], error) {
+	metadata := banktypes.Metadata{
+		Description: description,
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: fullDenom, Exponent: 0, Aliases: []string{ticker}},
+			{Denom: ticker, Exponent: uint32(exponent), Aliases: []string{fullDenom}},
+		},
+		Base:    fullDenom,
+		Display: fullDenom,
+		Name:    fullDenom,
+		Symbol:  ticker,
+	}
+	return execTxResult[*TokenFactorySetDenomMetadataResponse](ctx, tn, keyName, func(signer string) ([]sdk.Msg, error) {
+		metadataJSON, err := tn.Chain.Config().EncodingConfig.Codec.MarshalJSON(&metadata)
+		if err != nil {
+			return nil, err
+		}
+		return tn.tokenFactoryMsgs("MsgSetDenomMetadata", map[string]any{
+			"metadata": json.RawMessage(metadataJSON),
+		})(signer)
+	}, "tokenfactory", "modify-metadata", fullDenom, ticker, description, strconv.FormatUint(exponent, 10))
+}
+
+// tokenFactoryMsgs returns the tokenfactory message msgType, e.g. MsgMint, with the given JSON fields and signer as sender.
+// Tokenfactory is not part of the SDK, so the message is decoded with the chain's codec,
+// whose EncodingConfig must register the tokenfactory messages to use ibc.ChainConfig.GRPCTx.
+func (tn *ChainNode) tokenFactoryMsgs(msgType string, fields map[string]any) msgsFunc {
+	return func(signer string) ([]sdk.Msg, error) {
+		msgJSON := map[string]any{
+			"@type":  tokenFactoryMsgTypePrefix + msgType,
+			"sender": signer,
+		}
+		for k, v := range fields {
+			msgJSON[k] = v
+		}
+		bz, err := json.Marshal(msgJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var msg sdk.Msg
+		if err := tn.Chain.Config().EncodingConfig.Codec.UnmarshalInterfaceJSON(bz, &msg); err != nil {
+			return nil, fmt.Errorf("decode tokenfactory %s, which the chain's EncodingConfig must register for gRPC transactions: %w", msgType, err)
+		}
+		return []sdk.Msg{msg}, nil
+	}
+}
+
+func tokenFactoryCoin(amount uint64, denom string) map[string]string {
+	return map[string]string{"denom": denom, "amount": strconv.FormatUint(amount, 10)}
 }
 
 // TokenFactoryQueryAdmin returns the admin of a tokenfactory token.
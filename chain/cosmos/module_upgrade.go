@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 )
 
-// UpgradeSoftware executes the upgrade software command.
-func (tn *ChainNode) UpgradeSoftware(ctx context.Context, keyName, name, info string, height int, extraFlags ...string) error {
+// UpgradeSoftware executes the upgrade software command, which submits a software upgrade governance proposal.
+func (tn *ChainNode) UpgradeSoftware(ctx context.Context, keyName, name, info string, height int, extraFlags ...string) (TxResult[*govv1.MsgSubmitProposalResponse], error) {
 	cmd := []string{"upgrade", "software-upgrade", name}
 	if height > 0 {
 		cmd = append(cmd, "--upgrade-height", fmt.Sprintf("%d", height))
@@ -21,20 +22,18 @@ func (tn *ChainNode) UpgradeSoftware(ctx context.Context, keyName, name, info st
 		cmd = append(cmd, extraFlags...)
 	}
 
-	_, err := tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return txResult[*govv1.MsgSubmitProposalResponse](tn.execTxCLIOnly(ctx, keyName, cmd...))
 }
 
-// UpgradeCancel executes the upgrade cancel command.
-func (tn *ChainNode) UpgradeCancel(ctx context.Context, keyName string, extraFlags ...string) error {
+// UpgradeCancel executes the upgrade cancel command, which submits a cancel upgrade governance proposal.
+func (tn *ChainNode) UpgradeCancel(ctx context.Context, keyName string, extraFlags ...string) (TxResult[*govv1.MsgSubmitProposalResponse], error) {
 	cmd := []string{"upgrade", "cancel-software-upgrade"}
 
 	if len(extraFlags) > 0 {
 		cmd = append(cmd, extraFlags...)
 	}
 
-	_, err := tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return txResult[*govv1.MsgSubmitProposalResponse](tn.execTxCLIOnly(ctx, keyName, cmd...))
 }
 
 // UpgradeQueryPlan queries the current upgrade plan.
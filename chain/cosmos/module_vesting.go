@@ -6,53 +6,99 @@ import (
 	"fmt"
 	"path"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	vestingcli "github.com/cosmos/cosmos-sdk/x/auth/vesting/client/cli"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 
 	"github.com/cosmos/interchaintest/v11/dockerutil"
 )
 
 // VestingCreateAccount creates a new vesting account funded with an allocation of tokens. The account can either be a delayed or continuous vesting account, which is determined by the '--delayed' flag.
 // All vesting accounts created will have their start time set by the committed block's time. The end_time must be provided as a UNIX epoch timestamp.
-func (tn *ChainNode) VestingCreateAccount(ctx context.Context, keyName string, toAddr string, coin string, endTime int64, flags ...string) error {
+func (tn *ChainNode) VestingCreateAccount(ctx context.Context, keyName string, toAddr string, coin string, endTime int64, flags ...string) (TxResult[*vestingtypes.MsgCreateVestingAccountResponse], error) {
 	cmd := []string{
 		"vesting", "create-vesting-account", toAddr, coin, fmt.Sprintf("%d", endTime),
 	}
 
+	var msgs msgsFunc
 	if len(flags) > 0 {
 		cmd = append(cmd, flags...)
+	} else {
+		msgs = func(signer string) ([]sdk.Msg, error) {
+			coins, err := sdk.ParseCoinsNormalized(coin)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{&vestingtypes.MsgCreateVestingAccount{
+				FromAddress: signer,
+				ToAddress:   toAddr,
+				Amount:      coins,
+				EndTime:     endTime,
+			}}, nil
+		}
 	}
 
-	_, err := tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return execTxResult[*vestingtypes.MsgCreateVestingAccountResponse](ctx, tn, keyName, msgs, cmd...)
 }
 
 // VestingCreatePermanentLockedAccount creates a new vesting account funded with an allocation of tokens that are locked indefinitely.
-func (tn *ChainNode) VestingCreatePermanentLockedAccount(ctx context.Context, keyName string, toAddr string, coin string, flags ...string) error {
+func (tn *ChainNode) VestingCreatePermanentLockedAccount(ctx context.Context, keyName string, toAddr string, coin string, flags ...string) (TxResult[*vestingtypes.MsgCreatePermanentLockedAccountResponse], error) {
 	cmd := []string{
 		"vesting", "create-permanent-locked-account", toAddr, coin,
 	}
 
+	var msgs msgsFunc
 	if len(flags) > 0 {
 		cmd = append(cmd, flags...)
+	} else {
+		msgs = func(signer string) ([]sdk.Msg, error) {
+			coins, err := sdk.ParseCoinsNormalized(coin)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{&vestingtypes.MsgCreatePermanentLockedAccount{
+				FromAddress: signer,
+				ToAddress:   toAddr,
+				Amount:      coins,
+			}}, nil
+		}
 	}
 
-	_, err := tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return execTxResult[*vestingtypes.MsgCreatePermanentLockedAccountResponse](ctx, tn, keyName, msgs, cmd...)
 }
 
 // VestingCreatePeriodicAccount is a sequence of coins and period length in seconds.
 // Periods are sequential, in that the duration of a period only starts at the end of the previous period.
 // The duration of the first period starts upon account creation.
-func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName string, toAddr string, periods vestingcli.VestingData, flags ...string) error {
+func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName string, toAddr string, periods vestingcli.VestingData, flags ...string) (TxResult[*vestingtypes.MsgCreatePeriodicVestingAccountResponse], error) {
+	if tn.Chain.Config().GRPCTx && len(flags) == 0 {
+		return txResult[*vestingtypes.MsgCreatePeriodicVestingAccountResponse](tn.broadcastMsgsFunc(ctx, keyName, "", func(signer string) ([]sdk.Msg, error) {
+			vestingPeriods := make([]vestingtypes.Period, len(periods.Periods))
+			for i, p := range periods.Periods {
+				coins, err := sdk.ParseCoinsNormalized(p.Coins)
+				if err != nil {
+					return nil, err
+				}
+				vestingPeriods[i] = vestingtypes.Period{Length: p.Length, Amount: coins}
+			}
+			return []sdk.Msg{&vestingtypes.MsgCreatePeriodicVestingAccount{
+				FromAddress:    signer,
+				ToAddress:      toAddr,
+				StartTime:      periods.StartTime,
+				VestingPeriods: vestingPeriods,
+			}}, nil
+		}))
+	}
+
 	file := "periods.json"
 	periodsJSON, err := json.MarshalIndent(periods, "", " ")
 	if err != nil {
-		return err
+		return TxResult[*vestingtypes.MsgCreatePeriodicVestingAccountResponse]{}, err
 	}
 
 	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
 	if err := fw.WriteFile(ctx, tn.VolumeName, file, periodsJSON); err != nil {
-		return fmt.Errorf("writing periods JSON file to docker volume: %w", err)
+		return TxResult[*vestingtypes.MsgCreatePeriodicVestingAccountResponse]{}, fmt.Errorf("writing periods JSON file to docker volume: %w", err)
 	}
 
 	cmd := []string{
@@ -63,6 +109,5 @@ func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName s
 		cmd = append(cmd, flags...)
 	}
 
-	_, err = tn.ExecTx(ctx, keyName, cmd...)
-	return err
+	return execTxResult[*vestingtypes.MsgCreatePeriodicVestingAccountResponse](ctx, tn, keyName, nil, cmd...)
 }
@@ -130,6 +130,10 @@ func (s *SidecarProcess) RemoveContainer(ctx context.Context) error {
 	return s.containerLifecycle.RemoveContainer(ctx)
 }
 
+func (s *SidecarProcess) ContainerID() string {
+	return s.containerLifecycle.ContainerID()
+}
+
 // Bind returns the home folder bind point for running the process.
 func (s *SidecarProcess) Bind() []string {
 	return []string{fmt.Sprintf("%s:%s", s.VolumeName, s.HomeDir())}
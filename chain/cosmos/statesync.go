@@ -0,0 +1,106 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+const defaultStateSyncTrustPeriod = "168h"
+
+// AddStateSyncFullNodes adds inc fullnodes to the network which join with state sync, restoring the application state
+// from a snapshot served by the existing nodes instead of replaying every block from genesis.
+// The light client is trusted at a recent snapshot height, with the block hash queried from a live node.
+//
+// Snapshots must be enabled with ChainConfig.Snapshots, so that the existing nodes take them.
+// The new nodes are verified to have restored from a snapshot and to be in sync with the chain.
+func (c *CosmosChain) AddStateSyncFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) (ChainNodes, error) {
+	snapshots := c.cfg.Snapshots
+	if snapshots == nil || snapshots.Interval == 0 {
+		return nil, errors.New("state sync requires snapshots to be enabled with ChainConfig.Snapshots")
+	}
+	if c.cfg.UsesCometMock() {
+		return nil, errors.New("state sync is not supported with CometMock")
+	}
+
+	// Wait for a snapshot to be taken, with at least one block on top of it for the light client to verify its app hash.
+	interval := int64(snapshots.Interval)
+	height, err := c.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height < interval+2 {
+		if err := testutil.WaitForBlocks(ctx, int(interval+2-height), c); err != nil {
+			return nil, err
+		}
+		if height, err = c.Height(ctx); err != nil {
+			return nil, err
+		}
+	}
+	trustHeight := (height - 2) / interval * interval
+	block, err := c.GetNode().Client.Block(ctx, &trustHeight)
+	if err != nil {
+		return nil, fmt.Errorf("query block %d: %w", trustHeight, err)
+	}
+
+	// CometBFT requires two RPC servers to cross-check the light client against, which may be the same node.
+	nodes := c.Nodes()
+	rpcServers := []string{rpcServer(nodes[0]), rpcServer(nodes[min(1, len(nodes)-1)])}
+
+	trustPeriod := c.cfg.TrustingPeriod
+	if trustPeriod == "" {
+		trustPeriod = defaultStateSyncTrustPeriod
+	}
+	stateSync := testutil.Toml{
+		"enable":       true,
+		"rpc_servers":  strings.Join(rpcServers, ","),
+		"trust_height": trustHeight,
+		"trust_hash":   block.BlockID.Hash.String(),
+		"trust_period": trustPeriod,
+	}
+	c.log.Info("Adding state sync full nodes",
+		zap.String("chain_id", c.cfg.ChainID),
+		zap.Int("count", inc),
+		zap.Int64("trust_height", trustHeight),
+	)
+
+	added, err := c.addFullNodes(ctx, configFileOverrides, inc, func(n *ChainNode) error {
+		return testutil.ModifyTomlConfigFile(
+			ctx,
+			n.logger(),
+			n.DockerClient,
+			n.TestName,
+			n.VolumeName,
+			"config/config.toml",
+			testutil.Toml{"statesync": stateSync},
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range added {
+		stat, err := n.Client.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query status of %s: %w", n.Name(), err)
+		}
+		// A node synced from genesis has every block, while a state synced node has no blocks before the snapshot.
+		if stat.SyncInfo.EarliestBlockHeight <= 1 {
+			return nil, fmt.Errorf("%s synced from genesis instead of restoring a snapshot", n.Name())
+		}
+		if err := testutil.WaitForInSync(ctx, c, n); err != nil {
+			return nil, fmt.Errorf("%s did not catch up after state sync: %w", n.Name(), err)
+		}
+	}
+	return added, nil
+}
+
+// rpcServer returns the address of n's RPC server within the docker network.
+func rpcServer(n *ChainNode) string {
+	return fmt.Sprintf("http://%s:26657", n.HostName())
+}
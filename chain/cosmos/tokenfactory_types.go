@@ -0,0 +1,80 @@
+package cosmos
+
+import (
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// Tokenfactory is not part of the SDK, so the responses of its messages are mirrored here for TxResult.
+// They are decoded from their protobuf struct tags, and named after the osmosis tokenfactory messages,
+// which other tokenfactory implementations share.
+
+var (
+	_ proto.Message = (*TokenFactoryCreateDenomResponse)(nil)
+	_ proto.Message = (*TokenFactoryMintResponse)(nil)
+	_ proto.Message = (*TokenFactoryBurnResponse)(nil)
+	_ proto.Message = (*TokenFactoryChangeAdminResponse)(nil)
+	_ proto.Message = (*TokenFactoryForceTransferResponse)(nil)
+	_ proto.Message = (*TokenFactorySetDenomMetadataResponse)(nil)
+)
+
+// TokenFactoryCreateDenomResponse is the response of MsgCreateDenom.
+type TokenFactoryCreateDenomResponse struct {
+	NewTokenDenom string `protobuf:"bytes,1,opt,name=new_token_denom,json=newTokenDenom,proto3" json:"new_token_denom,omitempty"`
+}
+
+func (m *TokenFactoryCreateDenomResponse) Reset()         { *m = TokenFactoryCreateDenomResponse{} }
+func (m *TokenFactoryCreateDenomResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactoryCreateDenomResponse) ProtoMessage()    {}
+func (*TokenFactoryCreateDenomResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgCreateDenomResponse"
+}
+
+// TokenFactoryMintResponse is the response of MsgMint.
+type TokenFactoryMintResponse struct{}
+
+func (m *TokenFactoryMintResponse) Reset()         { *m = TokenFactoryMintResponse{} }
+func (m *TokenFactoryMintResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactoryMintResponse) ProtoMessage()    {}
+func (*TokenFactoryMintResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgMintResponse"
+}
+
+// TokenFactoryBurnResponse is the response of MsgBurn.
+type TokenFactoryBurnResponse struct{}
+
+func (m *TokenFactoryBurnResponse) Reset()         { *m = TokenFactoryBurnResponse{} }
+func (m *TokenFactoryBurnResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactoryBurnResponse) ProtoMessage()    {}
+func (*TokenFactoryBurnResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgBurnResponse"
+}
+
+// TokenFactoryChangeAdminResponse is the response of MsgChangeAdmin.
+type TokenFactoryChangeAdminResponse struct{}
+
+func (m *TokenFactoryChangeAdminResponse) Reset()         { *m = TokenFactoryChangeAdminResponse{} }
+func (m *TokenFactoryChangeAdminResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactoryChangeAdminResponse) ProtoMessage()    {}
+func (*TokenFactoryChangeAdminResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgChangeAdminResponse"
+}
+
+// TokenFactoryForceTransferResponse is the response of MsgForceTransfer.
+type TokenFactoryForceTransferResponse struct{}
+
+func (m *TokenFactoryForceTransferResponse) Reset()         { *m = TokenFactoryForceTransferResponse{} }
+func (m *TokenFactoryForceTransferResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactoryForceTransferResponse) ProtoMessage()    {}
+func (*TokenFactoryForceTransferResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgForceTransferResponse"
+}
+
+// TokenFactorySetDenomMetadataResponse is the response of MsgSetDenomMetadata.
+type TokenFactorySetDenomMetadataResponse struct{}
+
+func (m *TokenFactorySetDenomMetadataResponse) Reset()         { *m = TokenFactorySetDenomMetadataResponse{} }
+func (m *TokenFactorySetDenomMetadataResponse) String() string { return proto.CompactTextString(m) }
+func (*TokenFactorySetDenomMetadataResponse) ProtoMessage()    {}
+func (*TokenFactorySetDenomMetadataResponse) XXX_MessageName() string {
+	return "osmosis.tokenfactory.v1beta1.MsgSetDenomMetadataResponse"
+}
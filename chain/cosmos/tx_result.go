@@ -0,0 +1,104 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TxResult is the committed result of a transaction, with its events and message responses decoded.
+type TxResult[R proto.Message] struct {
+	TxHash    string
+	Height    int64
+	GasWanted int64
+	GasUsed   int64
+	Events    Events
+
+	// MsgResponses holds the responses of the transaction's messages of type R, in message order.
+	MsgResponses []R
+
+	// TxResponse is the full response returned by the node.
+	TxResponse *sdk.TxResponse
+}
+
+// MsgResponse returns the first message response of type R.
+// If the transaction has none, returns the zero value and false.
+func (r TxResult[R]) MsgResponse() (R, bool) {
+	if len(r.MsgResponses) == 0 {
+		var zero R
+		return zero, false
+	}
+	return r.MsgResponses[0], true
+}
+
+// NewTxResult decodes res into a TxResult. Message responses which are not of type R are skipped;
+// use *types.Any from the SDK's codec/types package as R to keep every message response undecoded.
+// R must be a pointer to a message type, e.g. *banktypes.MsgSendResponse, so that responses can be allocated.
+func NewTxResult[R interface {
+	*T
+	proto.Message
+}, T any](res *sdk.TxResponse) (TxResult[R], error) {
+	result := TxResult[R]{
+		TxHash:     res.TxHash,
+		Height:     res.Height,
+		GasWanted:  res.GasWanted,
+		GasUsed:    res.GasUsed,
+		Events:     res.Events,
+		TxResponse: res,
+	}
+
+	data, err := hex.DecodeString(res.Data)
+	if err != nil {
+		return result, fmt.Errorf("malformed tx data %s: %w", res.Data, err)
+	}
+	var msgData sdk.TxMsgData
+	if err := msgData.Unmarshal(data); err != nil {
+		return result, fmt.Errorf("unmarshal tx msg data: %w", err)
+	}
+
+	var zero R
+	typeURL := "/" + proto.MessageName(zero)
+	for _, msgResp := range msgData.MsgResponses {
+		if anyResp, ok := any(msgResp).(R); ok {
+			result.MsgResponses = append(result.MsgResponses, anyResp)
+			continue
+		}
+		if msgResp.TypeUrl != typeURL {
+			continue
+		}
+		resp := R(new(T))
+		if err := proto.Unmarshal(msgResp.Value, resp); err != nil {
+			return result, fmt.Errorf("unmarshal %s: %w", msgResp.TypeUrl, err)
+		}
+		result.MsgResponses = append(result.MsgResponses, resp)
+	}
+	return result, nil
+}
+
+// execTxResult runs a module helper's transaction with ChainNode.execTx and decodes its result.
+func execTxResult[R interface {
+	*T
+	proto.Message
+}, T any](ctx context.Context, tn *ChainNode, keyName string, msgs msgsFunc, command ...string) (TxResult[R], error) {
+	return txResult[R](tn.execTx(ctx, keyName, msgs, command...))
+}
+
+// txResult decodes the response of a transaction which returned err.
+// If the transaction was committed but failed, the partially decoded result is returned with err.
+func txResult[R interface {
+	*T
+	proto.Message
+}, T any](res *sdk.TxResponse, err error) (TxResult[R], error) {
+	if err != nil {
+		if res == nil {
+			return TxResult[R]{}, err
+		}
+		result, _ := NewTxResult[R](res)
+		return result, err
+	}
+	return NewTxResult[R](res)
+}
@@ -0,0 +1,100 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	// txInclusionTimeout bounds how long WaitForTx waits for a transaction to be committed.
+	txInclusionTimeout = time.Minute
+	// txPollInterval is how often WaitForTx queries a transaction without a Tx event subscription.
+	txPollInterval = 200 * time.Millisecond
+	// txSubscribedPollInterval is how often WaitForTx queries a transaction while subscribed to its Tx event,
+	// as a safety net in case the event is missed.
+	txSubscribedPollInterval = 2 * time.Second
+)
+
+// WaitForTx blocks until the transaction with txHash is committed, and returns its full response
+// including the decoded tx and events. It subscribes to the transaction's Tx event over the node's
+// websocket RPC, falling back to polling the transaction by hash if the subscription cannot be established.
+// The transaction's code is not checked; callers decide how to treat failed transactions.
+func (tn *ChainNode) WaitForTx(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, txInclusionTimeout)
+	defer cancel()
+
+	events, unsubscribe := tn.subscribeTx(ctx, txHash)
+	defer unsubscribe()
+
+	interval := txPollInterval
+	if events != nil {
+		interval = txSubscribedPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	clientCtx := tn.CliContext()
+	for {
+		// Query before waiting as the transaction may have been committed before the subscription started.
+		if res, err := authtx.QueryTx(clientCtx, txHash); err == nil {
+			return res, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for tx %s: %w", txHash, ctx.Err())
+		case <-events:
+			// The transaction is committed, but the tx indexer may not have caught up yet.
+			events = nil
+			ticker.Reset(txPollInterval)
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribeTx subscribes to the Tx event of txHash over a dedicated websocket connection to the node.
+// If the subscription fails, the returned channel is nil and WaitForTx falls back to polling.
+func (tn *ChainNode) subscribeTx(ctx context.Context, txHash string) (<-chan coretypes.ResultEvent, func()) {
+	noop := func() {}
+	addr := "tcp://" + tn.hostRPCPort
+
+	httpClient, err := libclient.DefaultHTTPClient(addr)
+	if err != nil {
+		tn.log.Debug("Failed to create websocket client, polling for tx", zap.String("tx_hash", txHash), zap.Error(err))
+		return nil, noop
+	}
+	client, err := rpchttp.NewWithClient(addr, "/websocket", httpClient)
+	if err != nil {
+		tn.log.Debug("Failed to create websocket client, polling for tx", zap.String("tx_hash", txHash), zap.Error(err))
+		return nil, noop
+	}
+	if err := client.Start(); err != nil {
+		tn.log.Debug("Failed to start websocket client, polling for tx", zap.String("tx_hash", txHash), zap.Error(err))
+		return nil, noop
+	}
+
+	subscriber := "interchaintest-" + txHash
+	query := fmt.Sprintf("%s='%s' AND %s='%s'", cmttypes.EventTypeKey, cmttypes.EventTx, cmttypes.TxHashKey, strings.ToUpper(txHash))
+	events, err := client.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		tn.log.Debug("Failed to subscribe to tx event, polling for tx", zap.String("tx_hash", txHash), zap.Error(err))
+		_ = client.Stop()
+		return nil, noop
+	}
+
+	return events, func() {
+		_ = client.UnsubscribeAll(context.Background(), subscriber)
+		_ = client.Stop()
+	}
+}
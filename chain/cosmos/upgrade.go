@@ -0,0 +1,253 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	dockerimagetypes "github.com/docker/docker/api/types/image"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+const defaultUpgradeSyncTimeout = 2 * time.Minute
+
+// RollingUpgradeOptions configures CosmosChain.RollingUpgrade.
+type RollingUpgradeOptions struct {
+	// Image to run on the upgraded nodes.
+	Image ibc.DockerImage
+
+	// Groups of nodes to upgrade. Each group is restarted together,
+	// and must be back in sync with the chain before the next group is upgraded.
+	// If empty, every validator is upgraded individually, followed by every full node.
+	Groups []ChainNodes
+
+	// Maximum time for each group to catch up after restarting. Defaults to 2 minutes.
+	SyncTimeout time.Duration
+}
+
+// UpgradeNodes restarts the given nodes with image, leaving every other node on its current image.
+// If image has no UIDGID, each node keeps its existing one.
+// This allows running a chain with a mix of old and new binaries.
+// UpgradeNodes blocks until the upgraded nodes are in sync with the chain, or until syncTimeout elapses.
+func (c *CosmosChain) UpgradeNodes(ctx context.Context, cli *client.Client, image ibc.DockerImage, syncTimeout time.Duration, nodes ...*ChainNode) error {
+	if len(nodes) == 0 {
+		return errors.New("no nodes to upgrade")
+	}
+	if syncTimeout <= 0 {
+		syncTimeout = defaultUpgradeSyncTimeout
+	}
+
+	if err := pullImage(ctx, cli, image); err != nil {
+		return err
+	}
+
+	// prevent client calls during this time
+	c.findTxMu.Lock()
+	var eg errgroup.Group
+	for _, n := range nodes {
+		eg.Go(func() error {
+			if err := n.StopContainer(ctx); err != nil {
+				return fmt.Errorf("failed to stop node %s: %w", n.Name(), err)
+			}
+			if err := n.RemoveContainer(ctx); err != nil {
+				return fmt.Errorf("failed to remove node %s: %w", n.Name(), err)
+			}
+
+			uidGID := n.Image.UIDGID
+			n.Image = image
+			if n.Image.UIDGID == "" {
+				n.Image.UIDGID = uidGID
+			}
+
+			if err := n.CreateNodeContainer(ctx); err != nil {
+				return fmt.Errorf("failed to create upgraded node %s: %w", n.Name(), err)
+			}
+			if err := n.StartContainer(ctx); err != nil {
+				return fmt.Errorf("failed to start upgraded node %s: %w", n.Name(), err)
+			}
+			return nil
+		})
+	}
+	err := eg.Wait()
+	c.findTxMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	heighters := make([]testutil.ChainHeighter, len(nodes))
+	for i, n := range nodes {
+		heighters[i] = n
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	if err := testutil.WaitForInSync(syncCtx, c, heighters...); err != nil {
+		return fmt.Errorf("upgraded nodes did not catch up: %w", err)
+	}
+
+	return nil
+}
+
+// RollingUpgrade upgrades the chain to a new image one group of nodes at a time, without halting the chain.
+// This is suitable for state-compatible releases such as emergency patches;
+// use UpgradeViaGovernance for upgrades that require a coordinated halt.
+func (c *CosmosChain) RollingUpgrade(ctx context.Context, cli *client.Client, opts RollingUpgradeOptions) error {
+	groups := opts.Groups
+	if len(groups) == 0 {
+		for _, n := range c.Nodes() {
+			groups = append(groups, ChainNodes{n})
+		}
+	}
+
+	for i, g := range groups {
+		c.log.Info("Upgrading node group",
+			zap.Int("group", i),
+			zap.Int("nodes", len(g)),
+			zap.String("image", opts.Image.Ref()),
+		)
+		if err := c.UpgradeNodes(ctx, cli, opts.Image, opts.SyncTimeout, g...); err != nil {
+			return fmt.Errorf("failed to upgrade group %d: %w", i, err)
+		}
+	}
+
+	// Only record the new image as the chain's image once every node runs it,
+	// so that nodes added later match the rest of the chain.
+	for _, n := range c.Nodes() {
+		if n.Image.Repository != opts.Image.Repository || n.Image.Version != opts.Image.Version {
+			return nil
+		}
+	}
+	c.cfg.Images[0].Repository = opts.Image.Repository
+	c.cfg.Images[0].Version = opts.Image.Version
+	return nil
+}
+
+// GovUpgradeOptions configures CosmosChain.UpgradeViaGovernance.
+type GovUpgradeOptions struct {
+	// Name of the upgrade plan, matching the upgrade handler in the new binary.
+	Name string
+
+	// Image to run after the chain halts.
+	Image ibc.DockerImage
+
+	// Deposit for the proposal, e.g. "500000000uatom".
+	Deposit string
+
+	// Number of blocks from submission until the upgrade height.
+	// Must be long enough for the voting period to end.
+	HaltHeightDelta int64
+
+	// Optional proposal fields.
+	Title, Description string
+	Expedited          bool
+
+	// Maximum time to wait for the chain to halt at, and resume after, the upgrade height.
+	// Defaults to 2 minutes each.
+	Timeout time.Duration
+}
+
+// UpgradeViaGovernance drives a full software upgrade:
+// it submits a software upgrade proposal from keyName, votes yes with every validator,
+// waits for the chain to halt at the upgrade height, restarts every node on opts.Image,
+// and waits for block production to resume.
+// It returns the upgrade height.
+func (c *CosmosChain) UpgradeViaGovernance(ctx context.Context, cli *client.Client, keyName string, opts GovUpgradeOptions) (int64, error) {
+	if opts.HaltHeightDelta <= 0 {
+		return 0, errors.New("halt height delta must be positive")
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultUpgradeSyncTimeout
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Upgrade to " + opts.Name
+	}
+	description := opts.Description
+	if description == "" {
+		description = title
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get height before upgrade proposal: %w", err)
+	}
+	haltHeight := height + opts.HaltHeightDelta
+
+	tx, err := c.UpgradeProposal(ctx, keyName, SoftwareUpgradeProposal{
+		Deposit:     opts.Deposit,
+		Title:       title,
+		Name:        opts.Name,
+		Description: description,
+		Height:      haltHeight,
+		Expedited:   opts.Expedited,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	proposalID, err := strconv.ParseUint(tx.ProposalID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse proposal ID %q: %w", tx.ProposalID, err)
+	}
+
+	if err := c.VoteOnProposalAllValidators(ctx, proposalID, ProposalVoteYes); err != nil {
+		return 0, fmt.Errorf("failed to vote on upgrade proposal: %w", err)
+	}
+
+	if _, err := PollForProposalStatusV1(ctx, c, height, haltHeight, proposalID, govv1.StatusPassed); err != nil {
+		return 0, fmt.Errorf("upgrade proposal did not pass before halt height %d: %w", haltHeight, err)
+	}
+
+	if err := testutil.WaitForCondition(timeout, time.Second, func() (bool, error) {
+		h, err := c.Height(ctx)
+		if err != nil {
+			// The node may stop responding as it halts.
+			return false, nil
+		}
+		return h >= haltHeight, nil
+	}); err != nil {
+		return 0, fmt.Errorf("chain did not reach halt height %d: %w", haltHeight, err)
+	}
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return 0, fmt.Errorf("failed to stop nodes for upgrade: %w", err)
+	}
+
+	c.UpgradeVersion(ctx, cli, opts.Image.Repository, opts.Image.Version)
+	c.cfg.Images[0].Repository = opts.Image.Repository
+
+	if err := c.StartAllNodes(ctx); err != nil {
+		return 0, fmt.Errorf("failed to start upgraded nodes: %w", err)
+	}
+
+	resumeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := testutil.WaitForBlocks(resumeCtx, 2, c); err != nil {
+		return 0, fmt.Errorf("chain did not produce blocks after upgrade: %w", err)
+	}
+
+	return haltHeight, nil
+}
+
+func pullImage(ctx context.Context, cli *client.Client, image ibc.DockerImage) error {
+	if image.Version == "local" {
+		return nil
+	}
+	rc, err := cli.ImagePull(ctx, image.Ref(), dockerimagetypes.PullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", image.Ref(), err)
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	return rc.Close()
+}
@@ -0,0 +1,196 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// AddValidators adds n validators to the running chain. Each new node is synced with the chain,
+// funded with stake from the first validator's account, and bonds stake with create-validator.
+// The new validators are appended to c.Validators and returned.
+func (c *CosmosChain) AddValidators(ctx context.Context, n int, stake sdk.Coin) (ChainNodes, error) {
+	c.valSetMu.Lock()
+	defer c.valSetMu.Unlock()
+
+	peers := c.Nodes().PeerString(ctx)
+	genbz, err := c.Validators[0].GenesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prevCount := len(c.Validators)
+	c.NumValidators += n
+	if err := c.initializeChainNodes(ctx, c.testName, c.GetNode().DockerClient, c.GetNode().NetworkID); err != nil {
+		return nil, err
+	}
+	added := c.Validators[prevCount:]
+
+	// StartContainer returns once the node has caught up with the chain.
+	var eg errgroup.Group
+	for _, v := range added {
+		eg.Go(func() error {
+			if err := c.provisionNode(ctx, v, peers, genbz, c.cfg.ConfigFileOverrides); err != nil {
+				return err
+			}
+			if err := v.StartContainer(ctx); err != nil {
+				return err
+			}
+			return v.CreateKey(ctx, valKey)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, fmt.Errorf("start validator nodes: %w", err)
+	}
+
+	// Fund the new validators one at a time, as they share the funding account.
+	for _, v := range added {
+		if err := c.fundValidator(ctx, v, stake); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, v := range added {
+		eg.Go(func() error {
+			return v.createValidator(ctx, stake)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	if err := testutil.WaitForBlocks(ctx, 2, c.GetNode()); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// fundValidator sends stake, and one whole token of the chain's denom to pay fees,
+// from the first validator's account to v's validator key.
+func (c *CosmosChain) fundValidator(ctx context.Context, v *ChainNode, stake sdk.Coin) error {
+	addr, err := v.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+
+	fees := sdk.NewCoin(c.cfg.Denom, sdkmath.NewInt(int64(math.Pow10(int(*c.cfg.CoinDecimals)))))
+	amounts := sdk.NewCoins(stake, fees)
+	for _, amount := range amounts {
+		if _, err := c.Validators[0].BankSend(ctx, valKey, ibc.WalletAmount{
+			Address: addr,
+			Denom:   amount.Denom,
+			Amount:  amount.Amount,
+		}); err != nil {
+			return fmt.Errorf("fund validator %s: %w", v.Name(), err)
+		}
+	}
+	return nil
+}
+
+// createValidator bonds stake from tn's validator key with tn's consensus key.
+func (tn *ChainNode) createValidator(ctx context.Context, stake sdk.Coin) error {
+	privVal, err := tn.PrivValFileContent(ctx)
+	if err != nil {
+		return err
+	}
+	pubKey, err := privValPubKeyJSON(privVal)
+	if err != nil {
+		return err
+	}
+
+	valFile := path.Join(tn.HomeDir(), "create-validator.json")
+	if err := tn.StakingCreateValidatorFile(ctx, valFile,
+		pubKey, stake.String(), tn.Name(), "", "", "", "",
+		"0.1", "0.2", "0.01", "1",
+	); err != nil {
+		return fmt.Errorf("write create-validator file: %w", err)
+	}
+	if _, err := tn.StakingCreateValidator(ctx, valKey, valFile); err != nil {
+		return fmt.Errorf("create validator %s: %w", tn.Name(), err)
+	}
+	return nil
+}
+
+// RemoveValidator removes val from the validator set by unbonding its entire self-delegation,
+// then stops and removes its node and drops it from c.Validators.
+// The chain's first validator cannot be removed, as it holds the keys of the chain's users.
+func (c *CosmosChain) RemoveValidator(ctx context.Context, val *ChainNode) error {
+	c.valSetMu.Lock()
+	defer c.valSetMu.Unlock()
+
+	i := slices.Index(c.Validators, val)
+	switch {
+	case i < 0:
+		return fmt.Errorf("%s is not a validator of chain %s", val.Name(), c.cfg.ChainID)
+	case i == 0:
+		return fmt.Errorf("cannot remove %s, the first validator of chain %s", val.Name(), c.cfg.ChainID)
+	}
+
+	valAddr, err := val.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return err
+	}
+	delegator, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+	delegation, err := c.StakingQueryDelegation(ctx, valAddr, delegator)
+	if err != nil {
+		return fmt.Errorf("query self-delegation of %s: %w", valAddr, err)
+	}
+	if _, err := val.StakingUnbond(ctx, valKey, valAddr, delegation.Balance.String()); err != nil {
+		return fmt.Errorf("unbond self-delegation of %s: %w", valAddr, err)
+	}
+
+	// The validator leaves the active set at the end of the block unbonding it.
+	if err := testutil.WaitForBlocks(ctx, 2, c.GetNode()); err != nil {
+		return err
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop %s: %w", val.Name(), err)
+	}
+	if err := val.RemoveContainer(ctx); err != nil {
+		return fmt.Errorf("remove %s: %w", val.Name(), err)
+	}
+
+	c.Validators = slices.Delete(slices.Clone(c.Validators), i, i+1)
+	c.NumValidators--
+	return nil
+}
+
+// InduceDoubleSign starts a full node signing with val's consensus key, alongside val.
+// When val proposes, both nodes propose and vote for different blocks, and the chain
+// picks up the equivocation as evidence, slashing and tombstoning val.
+// The double signing node is appended to c.FullNodes and returned; stop it to end the double signing.
+func (c *CosmosChain) InduceDoubleSign(ctx context.Context, val *ChainNode) (*ChainNode, error) {
+	privVal, err := val.PrivValFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := c.addFullNodes(ctx, c.cfg.ConfigFileOverrides, 1, func(n *ChainNode) error {
+		return n.OverwritePrivValFile(ctx, privVal)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start double signing node: %w", err)
+	}
+
+	c.log.Info("Started double signing node",
+		zap.String("validator", val.Name()),
+		zap.String("double_signer", added[0].Name()),
+	)
+	return added[0], nil
+}
@@ -0,0 +1,94 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Artifact is a compiled contract, as written by forge to out/<Source>.sol/<Contract>.json.
+type Artifact struct {
+	ABI abi.ABI
+
+	// Bytecode is the creation bytecode, without constructor arguments.
+	Bytecode []byte
+
+	// DeployedBytecode is the runtime bytecode of the deployed contract.
+	DeployedBytecode []byte
+}
+
+type forgeArtifact struct {
+	ABI              json.RawMessage `json:"abi"`
+	Bytecode         forgeBytecode   `json:"bytecode"`
+	DeployedBytecode forgeBytecode   `json:"deployedBytecode"`
+}
+
+type forgeBytecode struct {
+	Object string `json:"object"`
+}
+
+// ParseArtifact parses the JSON of a forge artifact.
+func ParseArtifact(bz []byte) (*Artifact, error) {
+	var fa forgeArtifact
+	if err := json.Unmarshal(bz, &fa); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal artifact: %w", err)
+	}
+
+	contractABI, err := abi.JSON(bytes.NewReader(fa.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse artifact abi: %w", err)
+	}
+
+	bytecode, err := decodeBytecode(fa.Bytecode.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode artifact bytecode: %w", err)
+	}
+	deployedBytecode, err := decodeBytecode(fa.DeployedBytecode.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode artifact deployed bytecode: %w", err)
+	}
+
+	return &Artifact{
+		ABI:              contractABI,
+		Bytecode:         bytecode,
+		DeployedBytecode: deployedBytecode,
+	}, nil
+}
+
+func decodeBytecode(object string) ([]byte, error) {
+	if strings.Contains(object, "__$") {
+		return nil, fmt.Errorf("bytecode has unlinked library references")
+	}
+	if !strings.HasPrefix(object, "0x") {
+		object = "0x" + object
+	}
+	return hexutil.Decode(object)
+}
+
+// LoadArtifact reads and parses the forge artifact at path.
+func LoadArtifact(path string) (*Artifact, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	artifact, err := ParseArtifact(bz)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return artifact, nil
+}
+
+// LoadForgeArtifact loads the artifact of contract, compiled from source (e.g. "Counter.sol"), from the forge out directory.
+// If source is empty, the contract is assumed to be in a source file of the same name.
+func LoadForgeArtifact(outDir, source, contract string) (*Artifact, error) {
+	if source == "" {
+		source = contract + ".sol"
+	}
+	return LoadArtifact(filepath.Join(outDir, source, contract+".json"))
+}
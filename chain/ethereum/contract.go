@@ -0,0 +1,188 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Contract is a contract deployed on an EthereumChain, bound to its ABI.
+type Contract struct {
+	Address common.Address
+	ABI     abi.ABI
+
+	chain *EthereumChain
+}
+
+// EventLog is an event log decoded with the ABI of a Contract.
+type EventLog struct {
+	// Name is the name of the event.
+	Name string
+
+	// Fields holds the indexed and non-indexed arguments of the event, by name.
+	Fields map[string]any
+
+	Log types.Log
+}
+
+// BindContract returns the Contract at address, with the ABI contractABI.
+func (c *EthereumChain) BindContract(address common.Address, contractABI abi.ABI) *Contract {
+	return &Contract{Address: address, ABI: contractABI, chain: c}
+}
+
+// DeployContractFromArtifact deploys the contract of a forge artifact from key,
+// passing args to its constructor, e.g. an artifact loaded with LoadForgeArtifact.
+func (c *EthereumChain) DeployContractFromArtifact(ctx context.Context, key *ecdsa.PrivateKey, artifact *Artifact, args ...any) (*Contract, *types.Receipt, error) {
+	return c.DeployContractFromABI(ctx, key, artifact.ABI, artifact.Bytecode, args...)
+}
+
+// DeployContractFromABI deploys the contract of bytecode from key, passing args ABI-encoded to its constructor,
+// and returns the deployed Contract with the receipt of the deployment.
+func (c *EthereumChain) DeployContractFromABI(ctx context.Context, key *ecdsa.PrivateKey, contractABI abi.ABI, bytecode []byte, args ...any) (*Contract, *types.Receipt, error) {
+	input, err := contractABI.Pack("", args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack constructor arguments: %w", err)
+	}
+
+	data := make([]byte, 0, len(bytecode)+len(input))
+	data = append(data, bytecode...)
+	data = append(data, input...)
+	receipt, err := c.SendTransaction(ctx, key, nil, nil, data)
+	if err != nil {
+		return nil, receipt, fmt.Errorf("failed to deploy contract: %w", err)
+	}
+
+	return c.BindContract(receipt.ContractAddress, contractABI), receipt, nil
+}
+
+// Call calls the view method of the contract with args, at the latest block, and returns its decoded outputs.
+func (ct *Contract) Call(ctx context.Context, method string, args ...any) ([]any, error) {
+	input, err := ct.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s arguments: %w", method, err)
+	}
+
+	output, err := ct.chain.rpcClient.CallContract(ctx, goethereum.CallMsg{To: &ct.Address, Data: input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	results, err := ct.ABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s outputs: %w", method, err)
+	}
+	return results, nil
+}
+
+// Transact sends a transaction from key calling method of the contract with args, and waits for its receipt.
+func (ct *Contract) Transact(ctx context.Context, key *ecdsa.PrivateKey, method string, args ...any) (*types.Receipt, error) {
+	return ct.TransactWithValue(ctx, key, nil, method, args...)
+}
+
+// TransactWithValue is Transact, also sending value wei to a payable method.
+func (ct *Contract) TransactWithValue(ctx context.Context, key *ecdsa.PrivateKey, value *big.Int, method string, args ...any) (*types.Receipt, error) {
+	input, err := ct.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s arguments: %w", method, err)
+	}
+
+	receipt, err := ct.chain.SendTransaction(ctx, key, &ct.Address, value, input)
+	if err != nil {
+		return receipt, fmt.Errorf("failed to transact %s: %w", method, err)
+	}
+	return receipt, nil
+}
+
+// FilterEvents returns the decoded logs of event emitted by the contract between fromBlock and toBlock, inclusive.
+// A nil toBlock is the latest block.
+// Each of indexed filters the indexed argument of the same position to any of its values, as with abi.MakeTopics;
+// a nil or empty filter matches any value.
+func (ct *Contract) FilterEvents(ctx context.Context, event string, fromBlock, toBlock *big.Int, indexed ...[]any) ([]EventLog, error) {
+	ev, ok := ct.ABI.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in contract abi", event)
+	}
+
+	topics, err := abi.MakeTopics(indexed...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make %s topics: %w", event, err)
+	}
+	topics = append([][]common.Hash{{ev.ID}}, topics...)
+
+	logs, err := ct.chain.rpcClient.FilterLogs(ctx, goethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{ct.Address},
+		Topics:    topics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter %s logs: %w", event, err)
+	}
+
+	events := make([]EventLog, 0, len(logs))
+	for _, log := range logs {
+		decoded, err := ct.DecodeEvent(event, log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, decoded)
+	}
+	return events, nil
+}
+
+// ReceiptEvents returns the decoded logs of event emitted by the contract in the transaction of receipt.
+func (ct *Contract) ReceiptEvents(receipt *types.Receipt, event string) ([]EventLog, error) {
+	ev, ok := ct.ABI.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in contract abi", event)
+	}
+
+	var events []EventLog
+	for _, log := range receipt.Logs {
+		if log.Address != ct.Address || len(log.Topics) == 0 || log.Topics[0] != ev.ID {
+			continue
+		}
+		decoded, err := ct.DecodeEvent(event, *log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, decoded)
+	}
+	return events, nil
+}
+
+// DecodeEvent decodes log as event of the contract ABI.
+func (ct *Contract) DecodeEvent(event string, log types.Log) (EventLog, error) {
+	ev, ok := ct.ABI.Events[event]
+	if !ok {
+		return EventLog{}, fmt.Errorf("event %s not found in contract abi", event)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != ev.ID {
+		return EventLog{}, fmt.Errorf("log %d of tx %s is not event %s", log.Index, log.TxHash, event)
+	}
+
+	fields := make(map[string]any)
+	if len(log.Data) > 0 {
+		if err := ct.ABI.UnpackIntoMap(fields, event, log.Data); err != nil {
+			return EventLog{}, fmt.Errorf("failed to unpack %s data: %w", event, err)
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range ev.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return EventLog{}, fmt.Errorf("failed to parse %s topics: %w", event, err)
+	}
+
+	return EventLog{Name: ev.Name, Fields: fields, Log: log}, nil
+}
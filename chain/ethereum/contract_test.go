@@ -0,0 +1,70 @@
+package ethereum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+const counterArtifact = `{
+  "abi": [
+    {"type": "constructor", "inputs": [{"name": "start", "type": "uint256"}], "stateMutability": "nonpayable"},
+    {"type": "function", "name": "count", "inputs": [], "outputs": [{"name": "", "type": "uint256"}], "stateMutability": "view"},
+    {"type": "event", "name": "Incremented", "anonymous": false, "inputs": [
+      {"name": "by", "type": "address", "indexed": true},
+      {"name": "value", "type": "uint256", "indexed": false}
+    ]}
+  ],
+  "bytecode": {"object": "0x6080", "linkReferences": {}},
+  "deployedBytecode": {"object": "0x6001", "linkReferences": {}},
+  "methodIdentifiers": {"count()": "06661abd"}
+}`
+
+func TestParseArtifact(t *testing.T) {
+	artifact, err := ethereum.ParseArtifact([]byte(counterArtifact))
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x80}, artifact.Bytecode)
+	require.Equal(t, []byte{0x60, 0x01}, artifact.DeployedBytecode)
+	require.Contains(t, artifact.ABI.Methods, "count")
+	require.Len(t, artifact.ABI.Constructor.Inputs, 1)
+
+	_, err = ethereum.ParseArtifact([]byte(`{"abi": [], "bytecode": {"object": "0x60__$abc$__"}}`))
+	require.ErrorContains(t, err, "unlinked library")
+}
+
+func TestDecodeEvent(t *testing.T) {
+	artifact, err := ethereum.ParseArtifact([]byte(counterArtifact))
+	require.NoError(t, err)
+	chain := ethereum.NewEthereumChain(t.Name(), ibc.ChainConfig{}, zaptest.NewLogger(t))
+	contract := chain.BindContract(common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3"), artifact.ABI)
+
+	by := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	event := artifact.ABI.Events["Incremented"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(7))
+	require.NoError(t, err)
+	log := &types.Log{
+		Address: contract.Address,
+		Topics:  []common.Hash{event.ID, common.BytesToHash(by.Bytes())},
+		Data:    data,
+	}
+
+	decoded, err := contract.DecodeEvent("Incremented", *log)
+	require.NoError(t, err)
+	require.Equal(t, "Incremented", decoded.Name)
+	require.Equal(t, by, decoded.Fields["by"])
+	require.Equal(t, big.NewInt(7), decoded.Fields["value"])
+
+	events, err := contract.ReceiptEvents(&types.Receipt{Logs: []*types.Log{log, {Address: by, Topics: log.Topics}}}, "Incremented")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = contract.DecodeEvent("Incremented", types.Log{Topics: []common.Hash{{}}})
+	require.ErrorContains(t, err, "is not event Incremented")
+}
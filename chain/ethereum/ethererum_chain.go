@@ -2,8 +2,13 @@ package ethereum
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
 	"time"
 
 	dockerimagetypes "github.com/docker/docker/api/types/image"
@@ -12,12 +17,15 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	dockerclient "github.com/moby/moby/client"
 	"go.uber.org/zap"
 
 	sdkmath "cosmossdk.io/math"
 
+	"github.com/cosmos/go-bip39"
+
 	"github.com/cosmos/interchaintest/v11/dockerutil"
 	"github.com/cosmos/interchaintest/v11/ibc"
 	"github.com/cosmos/interchaintest/v11/testutil"
@@ -50,6 +58,9 @@ type EthereumChain struct {
 
 	hostRPCPort string
 	rpcClient   *ethclient.Client
+
+	// txLocks serializes the transactions of each sender, from nonce assignment until the receipt.
+	txLocks sync.Map // common.Address -> *sync.Mutex
 }
 
 func NewEthereumChain(testName string, chainConfig ibc.ChainConfig, log *zap.Logger) *EthereumChain {
@@ -134,6 +145,15 @@ func (c *EthereumChain) pullImages(ctx context.Context, cli *dockerclient.Client
 }
 
 func (c *EthereumChain) Start(ctx context.Context, cmd []string, mount []mount.Mount) error {
+	if err := c.StartContainer(ctx, cmd, mount); err != nil {
+		return err
+	}
+	return testutil.WaitForBlocks(ctx, 2, c)
+}
+
+// StartContainer creates and starts the node container running cmd, and dials its RPC,
+// without waiting for blocks, e.g. for nodes which only produce blocks once their peers are started.
+func (c *EthereumChain) StartContainer(ctx context.Context, cmd []string, mount []mount.Mount) error {
 	usingPorts := nat.PortMap{}
 	for k, v := range natPorts {
 		usingPorts[k] = v
@@ -190,7 +210,27 @@ func (c *EthereumChain) Start(ctx context.Context, cmd []string, mount []mount.M
 		}
 	}
 
-	return testutil.WaitForBlocks(ctx, 2, c)
+	return nil
+}
+
+// ContainerLifecycle returns the lifecycle of the node container, e.g. to pause it.
+func (c *EthereumChain) ContainerLifecycle() *dockerutil.ContainerLifecycle {
+	return c.containerLifecycle
+}
+
+// VolumeName returns the name of the docker volume mounted at HomeDir.
+func (c *EthereumChain) VolumeName() string {
+	return c.volumeName
+}
+
+// NetworkID returns the ID of the docker network of the chain.
+func (c *EthereumChain) NetworkID() string {
+	return c.networkID
+}
+
+// DockerClient returns the docker client of the chain.
+func (c *EthereumChain) DockerClient() *dockerclient.Client {
+	return c.dockerClient
 }
 
 func (c *EthereumChain) HostName() string {
@@ -211,6 +251,26 @@ func (c *EthereumChain) Exec(ctx context.Context, cmd []string, env []string) (s
 	return res.Stdout, res.Stderr, res.Err
 }
 
+// ReadFile reads the contents of a single file at the specified path in the docker filesystem.
+// relPath describes the location of the file in the docker volume relative to the home directory.
+func (c *EthereumChain) ReadFile(ctx context.Context, relPath string) ([]byte, error) {
+	fr := dockerutil.NewFileRetriever(c.log, c.dockerClient, c.testName)
+	bz, err := fr.SingleFileContent(ctx, c.volumeName, relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file at %s: %w", relPath, err)
+	}
+	return bz, nil
+}
+
+// WriteFile writes the single file containing content, at relPath within the docker volume relative to the home directory.
+func (c *EthereumChain) WriteFile(ctx context.Context, relPath string, content []byte) error {
+	fw := dockerutil.NewFileWriter(c.log, c.dockerClient, c.testName)
+	if err := fw.WriteFile(ctx, c.volumeName, relPath, content); err != nil {
+		return fmt.Errorf("failed to write file at %s: %w", relPath, err)
+	}
+	return nil
+}
+
 func (c *EthereumChain) Logger() *zap.Logger {
 	return c.log.With(
 		zap.String("chain_id", c.cfg.ChainID),
@@ -234,6 +294,12 @@ func (c *EthereumChain) GetHostWSAddress() string {
 	return "ws://" + c.hostRPCPort
 }
 
+// RPCClient returns the client of the node's JSON-RPC, dialed from the host.
+// Note that this will not return a valid value until after Start returns.
+func (c *EthereumChain) RPCClient() *ethclient.Client {
+	return c.rpcClient
+}
+
 func (c *EthereumChain) Height(ctx context.Context) (int64, error) {
 	time.Sleep(time.Millisecond * 200) // TODO: slow down WaitForBlocks instead of here
 	height, err := c.rpcClient.BlockNumber(ctx)
@@ -250,3 +316,53 @@ func (c *EthereumChain) GetBalance(ctx context.Context, address string, denom st
 	}
 	return sdkmath.NewIntFromBigInt(balance), nil
 }
+
+// ExportState returns the JSON dump of the state at height, as returned by the debug_dumpBlock RPC of geth.
+func (c *EthereumChain) ExportState(ctx context.Context, height int64) (string, error) {
+	var dump json.RawMessage
+	if err := c.rpcClient.Client().CallContext(ctx, &dump, "debug_dumpBlock", hexutil.EncodeUint64(uint64(height))); err != nil {
+		return "", fmt.Errorf("failed to dump state at height %d: %w", height, err)
+	}
+	return string(dump), nil
+}
+
+// GetGasFeesInNativeDenom returns the fee in wei for gasPaid at the configured GasPrices.
+// The fee actually paid by a transaction depends on the base fee of its block; see ReceiptFee.
+func (c *EthereumChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	fees := new(big.Int).Mul(big.NewInt(gasPaid), c.gasPrice())
+	if !fees.IsInt64() {
+		c.log.Warn("Gas fees overflow int64", zap.Int64("gas_paid", gasPaid), zap.String("fees", fees.String()))
+		return math.MaxInt64
+	}
+	return fees.Int64()
+}
+
+func (c *EthereumChain) gasPrice() *big.Int {
+	gasPrice, ok := new(big.Int).SetString(strings.TrimSuffix(c.cfg.GasPrices, c.cfg.Denom), 10)
+	if !ok {
+		c.log.Warn("Invalid configured gas price", zap.String("gas_prices", c.cfg.GasPrices))
+		return new(big.Int)
+	}
+	return gasPrice
+}
+
+// BuildRelayerWallet returns a wallet of a new mnemonic, derived on the ethereum HD path, so that the
+// relayer can restore it. Genesis wallets are not funded on ethereum chains, so the wallet must be
+// funded with SendFunds before it is used.
+func (c *EthereumChain) BuildRelayerWallet(ctx context.Context, keyName string) (ibc.Wallet, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entropy: %w", err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
+	}
+
+	privKey, err := PrivateKeyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWallet(keyName, crypto.PubkeyToAddress(privKey.PublicKey).Bytes(), mnemonic), nil
+}
@@ -1,16 +1,23 @@
 package foundry
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types/mount"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.uber.org/zap"
 
@@ -27,6 +34,10 @@ type AnvilChain struct {
 
 	// Mutex for reading/writing keystoreMap (once wallet is created, it doesn't change)
 	MapAccess sync.Mutex
+
+	// blockTime is the interval of anvil's interval mining, or zero if blocks are mined for each transaction.
+	blockTime   time.Duration
+	blockTimeMu sync.Mutex
 }
 
 func NewAnvilChain(testName string, chainConfig ibc.ChainConfig, log *zap.Logger) *AnvilChain {
@@ -49,9 +60,23 @@ func (c *AnvilChain) Start(testName string, ctx context.Context, additionalGenes
 	}
 
 	cmd = append(cmd, c.Config().AdditionalStartArgs...)
+	blockTime, err := blockTimeArg(c.Config().AdditionalStartArgs)
+	if err != nil {
+		return err
+	}
+	c.blockTimeMu.Lock()
+	c.blockTime = blockTime
+	c.blockTimeMu.Unlock()
+
+	overrides := c.Config().ConfigFileOverrides
+	for _, flag := range []string{ForkURLOverride, ForkBlockNumberOverride} {
+		if value, ok := overrides[flag]; ok {
+			cmd = append(cmd, flag, fmt.Sprint(value))
+		}
+	}
 
 	var mounts []mount.Mount
-	if loadState, ok := c.Config().ConfigFileOverrides["--load-state"].(string); ok {
+	if loadState, ok := overrides[LoadStateOverride].(string); ok {
 		pwd, err := os.Getwd()
 		if err != nil {
 			return err
@@ -65,12 +90,89 @@ func (c *AnvilChain) Start(testName string, ctx context.Context, additionalGenes
 				Target: dockerJSONFile,
 			},
 		}
-		cmd = append(cmd, "--load-state", dockerJSONFile)
+		cmd = append(cmd, LoadStateOverride, dockerJSONFile)
+	} else if state, ok := overrides[LoadStateJSONOverride].(string); ok {
+		const stateFile = "state.json"
+		if err := c.WriteFile(ctx, stateFile, []byte(state)); err != nil {
+			return err
+		}
+		cmd = append(cmd, LoadStateOverride, path.Join(c.HomeDir(), stateFile))
 	}
 
 	return c.EthereumChain.Start(ctx, cmd, mounts)
 }
 
+// blockTimeArg returns the interval of the "--block-time" flag of args, in seconds, or zero if it is not set.
+func blockTimeArg(args []string) (time.Duration, error) {
+	for i, arg := range args {
+		var value string
+		switch {
+		case arg == "--block-time" || arg == "-b":
+			if i+1 == len(args) {
+				return 0, fmt.Errorf("missing value of %s", arg)
+			}
+			value = args[i+1]
+		case strings.HasPrefix(arg, "--block-time="):
+			value = strings.TrimPrefix(arg, "--block-time=")
+		default:
+			continue
+		}
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid block time %q: %w", value, err)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return 0, nil
+}
+
+// ExportState returns the JSON state dump of anvil_dumpState, which a new chain can load with WithLoadStateJSON.
+// Anvil only dumps its latest state, so height must be the current height. Interval mining is paused during the
+// export, so that no block is mined between checking the height and dumping the state.
+func (c *AnvilChain) ExportState(ctx context.Context, height int64) (string, error) {
+	c.blockTimeMu.Lock()
+	defer c.blockTimeMu.Unlock()
+	// evm_setIntervalMining takes whole seconds, so a shorter block time could not be restored.
+	if c.blockTime >= time.Second {
+		if err := c.rpcCall(ctx, nil, "evm_setIntervalMining", uint64(0)); err != nil {
+			return "", err
+		}
+		defer func() {
+			// The context of the export may be done, but mining must resume.
+			if err := c.rpcCall(context.Background(), nil, "evm_setIntervalMining", uint64(c.blockTime/time.Second)); err != nil {
+				c.Logger().Error("Failed to resume interval mining", zap.Error(err))
+			}
+		}()
+	}
+
+	current, err := c.Height(ctx)
+	if err != nil {
+		return "", err
+	}
+	if height != current {
+		return "", fmt.Errorf("cannot export the state at height %d, only the current height %d", height, current)
+	}
+
+	var dump hexutil.Bytes
+	if err := c.RPCClient().Client().CallContext(ctx, &dump, "anvil_dumpState"); err != nil {
+		return "", fmt.Errorf("failed to dump state: %w", err)
+	}
+
+	// Recent anvil versions gzip the dump.
+	if !bytes.HasPrefix(dump, []byte{0x1f, 0x8b}) {
+		return string(dump), nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(dump))
+	if err != nil {
+		return "", fmt.Errorf("failed to read gzipped state dump: %w", err)
+	}
+	state, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress state dump: %w", err)
+	}
+	return string(state), nil
+}
+
 type NewWalletOutput struct {
 	Address string `json:"address"`
 	Path    string `json:"path"`
@@ -160,6 +262,31 @@ func (c *AnvilChain) GetAddress(ctx context.Context, keyName string) ([]byte, er
 	return hexutil.MustDecode(addr), nil
 }
 
+// PrivateKey returns the private key of keyName, decrypted from its keystore,
+// to sign the transactions of the EthereumChain contract API.
+func (c *AnvilChain) PrivateKey(ctx context.Context, keyName string) (*ecdsa.PrivateKey, error) {
+	c.MapAccess.Lock()
+	account, ok := c.keystoreMap[keyName]
+	c.MapAccess.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("keyname (%s) not found", keyName)
+	}
+
+	relPath, err := filepath.Rel(c.HomeDir(), account.keystore)
+	if err != nil {
+		return nil, err
+	}
+	keyJSON, err := c.ReadFile(ctx, relPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore of %s: %w", keyName, err)
+	}
+	return key.PrivateKey, nil
+}
+
 func (c *AnvilChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
 	_, err := c.SendFundsWithNote(ctx, keyName, amount, "")
 	return err
@@ -0,0 +1,150 @@
+package foundry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// rpcCall calls method of the anvil JSON-RPC with args, decoding its result into result if it is non-nil.
+func (c *AnvilChain) rpcCall(ctx context.Context, result any, method string, args ...any) error {
+	if err := c.RPCClient().Client().CallContext(ctx, result, method, args...); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+// Mine mines blocks blocks immediately, with timestamps interval apart.
+// A zero interval uses anvil's default of one second.
+func (c *AnvilChain) Mine(ctx context.Context, blocks uint64, interval time.Duration) error {
+	args := []any{hexutil.Uint64(blocks)}
+	if interval > 0 {
+		args = append(args, hexutil.Uint64(interval/time.Second))
+	}
+	return c.rpcCall(ctx, nil, "anvil_mine", args...)
+}
+
+// SetIntervalMining sets the block time of anvil's interval mining to interval. A zero interval disables
+// interval mining, so that blocks are only mined for transactions and by Mine.
+func (c *AnvilChain) SetIntervalMining(ctx context.Context, interval time.Duration) error {
+	c.blockTimeMu.Lock()
+	defer c.blockTimeMu.Unlock()
+	if err := c.rpcCall(ctx, nil, "evm_setIntervalMining", uint64(interval/time.Second)); err != nil {
+		return err
+	}
+	c.blockTime = interval
+	return nil
+}
+
+// IncreaseTime moves the timestamp of the next blocks forward by d, and returns the total time offset of the chain.
+func (c *AnvilChain) IncreaseTime(ctx context.Context, d time.Duration) (time.Duration, error) {
+	var result json.RawMessage
+	if err := c.rpcCall(ctx, &result, "evm_increaseTime", hexutil.Uint64(d/time.Second)); err != nil {
+		return 0, err
+	}
+
+	// The offset is a JSON number, or a hex quantity on some anvil versions.
+	var offset int64
+	if err := json.Unmarshal(result, &offset); err != nil {
+		var hexOffset hexutil.Uint64
+		if err := json.Unmarshal(result, &hexOffset); err != nil {
+			return 0, fmt.Errorf("invalid evm_increaseTime result %s: %w", result, err)
+		}
+		offset = int64(hexOffset)
+	}
+	return time.Duration(offset) * time.Second, nil
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block, which must be after the latest block.
+func (c *AnvilChain) SetNextBlockTimestamp(ctx context.Context, t time.Time) error {
+	return c.rpcCall(ctx, nil, "evm_setNextBlockTimestamp", hexutil.Uint64(t.Unix()))
+}
+
+// SetBalance sets the balance of address to balance wei.
+func (c *AnvilChain) SetBalance(ctx context.Context, address common.Address, balance *big.Int) error {
+	return c.rpcCall(ctx, nil, "anvil_setBalance", address, (*hexutil.Big)(balance))
+}
+
+// ImpersonateAccount allows transactions from address to be sent unsigned, with SendUnsignedTransaction,
+// until StopImpersonatingAccount is called.
+func (c *AnvilChain) ImpersonateAccount(ctx context.Context, address common.Address) error {
+	return c.rpcCall(ctx, nil, "anvil_impersonateAccount", address)
+}
+
+// StopImpersonatingAccount stops the impersonation of address by ImpersonateAccount.
+func (c *AnvilChain) StopImpersonatingAccount(ctx context.Context, address common.Address) error {
+	return c.rpcCall(ctx, nil, "anvil_stopImpersonatingAccount", address)
+}
+
+// SendUnsignedTransaction sends a transaction from an impersonated or unlocked address, of value wei and data
+// to the address to, or creating a contract if to is nil, and waits for its receipt.
+// An error is returned if the transaction reverted, together with its receipt.
+func (c *AnvilChain) SendUnsignedTransaction(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	tx := map[string]any{
+		"from":  from,
+		"input": hexutil.Bytes(data),
+	}
+	if to != nil {
+		tx["to"] = *to
+	}
+	if value != nil {
+		tx["value"] = (*hexutil.Big)(value)
+	}
+
+	var txHash common.Hash
+	if err := c.rpcCall(ctx, &txHash, "eth_sendTransaction", tx); err != nil {
+		return nil, err
+	}
+
+	receipt, err := c.WaitForReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted at height %d", txHash, receipt.BlockNumber)
+	}
+	return receipt, nil
+}
+
+// SetCode sets the runtime bytecode of address to code, e.g. the DeployedBytecode of an ethereum.Artifact.
+func (c *AnvilChain) SetCode(ctx context.Context, address common.Address, code []byte) error {
+	return c.rpcCall(ctx, nil, "anvil_setCode", address, hexutil.Bytes(code))
+}
+
+// SetStorageAt sets the storage slot of address to value.
+func (c *AnvilChain) SetStorageAt(ctx context.Context, address common.Address, slot, value common.Hash) error {
+	return c.rpcCall(ctx, nil, "anvil_setStorageAt", address, slot, value)
+}
+
+// Snapshot snapshots the state of the chain, and returns the id with which Revert restores it.
+func (c *AnvilChain) Snapshot(ctx context.Context) (string, error) {
+	var id hexutil.Big
+	if err := c.rpcCall(ctx, &id, "evm_snapshot"); err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// Revert restores the state of the chain, including its height and time, to the snapshot id taken by Snapshot.
+// The snapshot, and every later snapshot, is discarded, so a new snapshot must be taken to revert again.
+func (c *AnvilChain) Revert(ctx context.Context, id string) error {
+	snapshotID, err := hexutil.DecodeBig(id)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot id %q: %w", id, err)
+	}
+
+	var reverted bool
+	if err := c.rpcCall(ctx, &reverted, "evm_revert", (*hexutil.Big)(snapshotID)); err != nil {
+		return err
+	}
+	if !reverted {
+		return fmt.Errorf("snapshot %s not found", id)
+	}
+	return nil
+}
@@ -1,13 +1,69 @@
 package foundry
 
 import (
+	"strconv"
+
 	"github.com/cosmos/interchaintest/v11/ibc"
 )
 
+// ConfigFileOverrides keys of anvil chains. Except for LoadStateJSONOverride, they are passed as flags to anvil.
+const (
+	// ForkURLOverride is the RPC of the chain to fork, which anvil reads state from on demand.
+	ForkURLOverride = "--fork-url"
+	// ForkBlockNumberOverride is the block of the forked chain to fork at, the latest block if unset.
+	ForkBlockNumberOverride = "--fork-block-number"
+	// LoadStateOverride is the path, relative to the working directory, of a state dump to load.
+	LoadStateOverride = "--load-state"
+	// LoadStateJSONOverride is a state dump to load, e.g. returned by the ExportState of another AnvilChain.
+	LoadStateJSONOverride = "load-state-json"
+)
+
+// AnvilOption configures the ChainConfig of DefaultEthereumAnvilChainConfig.
+type AnvilOption func(*ibc.ChainConfig)
+
+// WithForkURL forks the chain whose RPC is forkURL, e.g. the GetRPCAddress of another chain in the test network.
+// The forked chain must be running before the anvil chain is started.
+func WithForkURL(forkURL string) AnvilOption {
+	return withOverride(ForkURLOverride, forkURL)
+}
+
+// WithForkChain forks chain, which must be running before the anvil chain is started.
+func WithForkChain(chain ibc.Chain) AnvilOption {
+	return WithForkURL(chain.GetRPCAddress())
+}
+
+// WithForkBlockNumber forks at height, rather than at the latest block of the forked chain.
+func WithForkBlockNumber(height uint64) AnvilOption {
+	return withOverride(ForkBlockNumberOverride, strconv.FormatUint(height, 10))
+}
+
+// WithLoadState loads the state dump at path, relative to the working directory.
+func WithLoadState(path string) AnvilOption {
+	return withOverride(LoadStateOverride, path)
+}
+
+// WithLoadStateJSON loads the state dump state, e.g. returned by the ExportState of another AnvilChain,
+// so that the state need not be kept in a file.
+func WithLoadStateJSON(state string) AnvilOption {
+	return withOverride(LoadStateJSONOverride, state)
+}
+
+func withOverride(key string, value any) AnvilOption {
+	return func(cfg *ibc.ChainConfig) {
+		if cfg.ConfigFileOverrides == nil {
+			cfg.ConfigFileOverrides = make(map[string]any)
+		}
+		cfg.ConfigFileOverrides[key] = value
+	}
+}
+
+// DefaultEthereumAnvilChainConfig returns the config of an anvil chain, with the given options,
+// e.g. to fork another chain or to start from a state dump.
 func DefaultEthereumAnvilChainConfig(
 	name string,
+	opts ...AnvilOption,
 ) ibc.ChainConfig {
-	return ibc.ChainConfig{
+	cfg := ibc.ChainConfig{
 		Type:           "ethereum",
 		Name:           name,
 		ChainID:        "31337", // default anvil chain-id
@@ -33,4 +89,8 @@ func DefaultEthereumAnvilChainConfig(
 			"--block-base-fee-per-gas", "0",
 		},
 	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
 }
@@ -40,6 +40,42 @@ func DefaultEthereumGethChainConfig(
 	}
 }
 
+// DefaultEthereumGethCliqueChainConfig returns the config of a multi-node geth network with Clique consensus,
+// e.g. with NumValidators and NumFullNodes set on its ChainSpec. Clique block production was removed in geth v1.14,
+// so it uses the last geth release supporting it.
+func DefaultEthereumGethCliqueChainConfig(
+	name string,
+) ibc.ChainConfig {
+	return ibc.ChainConfig{
+		Type:           "ethereum",
+		Name:           name,
+		ChainID:        "1337",
+		Bech32Prefix:   "n/a",
+		CoinType:       "60",
+		Denom:          "wei",
+		GasPrices:      "2000000000", // 2gwei, default 1M
+		GasAdjustment:  0,
+		TrustingPeriod: "0",
+		NoHostMount:    false,
+		Images: []ibc.DockerImage{
+			{
+				Repository: "ethereum/client-go",
+				Version:    "v1.13.15",
+				UIDGID:     "1025:1025",
+			},
+		},
+		Bin: "geth",
+		AdditionalStartArgs: []string{
+			"--verbosity", "4", // Level = debug
+			"--rpc.txfeecap", "50.0", // 50 eth
+			"--rpc.gascap", "30000000", // 30M
+			"--gpo.percentile", "150", // default 60
+			"--gpo.ignoreprice", "1000000000", // 1gwei, default 2
+			"--rpc.enabledeprecatedpersonal", // required (in this version) for recover key and unlocking accounts
+		},
+	}
+}
+
 func DefaultBscChainConfig(
 	name string,
 ) ibc.ChainConfig {
@@ -2,13 +2,16 @@ package geth
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"path"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/mount"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.uber.org/zap"
 
@@ -24,6 +27,19 @@ var _ ibc.Chain = &GethChain{}
 type GethChain struct {
 	*ethereum.EthereumChain
 
+	testName string
+
+	numValidators int
+	numFullNodes  int
+
+	// Validators are the block producing nodes of the chain, and FullNodes the others.
+	// Validators[0] is the chain's own container, which serves the chain-level RPC.
+	Validators GethNodes
+	FullNodes  GethNodes
+
+	// peerAddress is the enode URL of node 0 in a network.
+	peerAddress string
+
 	keynameToAccountMap map[string]*NodeWallet
 	nextAcctNum         int
 
@@ -31,9 +47,12 @@ type GethChain struct {
 	MapAccess sync.Mutex
 }
 
+// NewGethChain returns a geth chain of a single "--dev" node.
 func NewGethChain(testName string, chainConfig ibc.ChainConfig, log *zap.Logger) *GethChain {
 	return &GethChain{
 		EthereumChain: ethereum.NewEthereumChain(testName, chainConfig, log),
+		testName:      testName,
+		numValidators: 1,
 		keynameToAccountMap: map[string]*NodeWallet{
 			"faucet": {
 				accountNum: 0,
@@ -43,7 +62,44 @@ func NewGethChain(testName string, chainConfig ibc.ChainConfig, log *zap.Logger)
 	}
 }
 
+// NewGethNetworkChain returns a geth chain of numValidators and numFullNodes nodes.
+// A single validator runs as a "--dev" node. More nodes are started as a network, with a generated genesis,
+// whose validators run beacon client sidecars if the chain has SidecarConfigs, or else are Clique signers.
+// Clique requires a geth image older than v1.14, e.g. the one of DefaultEthereumGethCliqueChainConfig.
+// A chain with SidecarConfigs must have a single node: the nodes of a beacon network would each need a consensus
+// client, all sharing one beacon genesis, which is not supported.
+func NewGethNetworkChain(testName string, chainConfig ibc.ChainConfig, numValidators, numFullNodes int, log *zap.Logger) (*GethChain, error) {
+	if numValidators < 1 {
+		return nil, fmt.Errorf("geth chain needs at least one validator, got %d", numValidators)
+	}
+	if numFullNodes < 0 {
+		return nil, fmt.Errorf("invalid number of geth full nodes %d", numFullNodes)
+	}
+
+	c := NewGethChain(testName, chainConfig, log)
+	c.numValidators, c.numFullNodes = numValidators, numFullNodes
+	if c.beacon() && numValidators+numFullNodes > 1 {
+		return nil, fmt.Errorf("a geth chain with SidecarConfigs for beacon clients supports a single node, "+
+			"got %d validators and %d full nodes", numValidators, numFullNodes)
+	}
+	if c.network() && !c.beacon() && !cliqueSupported(chainConfig) {
+		return nil, fmt.Errorf("a geth network of %d validators and %d full nodes needs SidecarConfigs for beacon clients, "+
+			"or a geth image supporting Clique, older than %s, as in DefaultEthereumGethCliqueChainConfig",
+			numValidators, numFullNodes, cliqueRemovedVersion)
+	}
+	return c, nil
+}
+
 func (c *GethChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	if c.network() {
+		return c.startNetwork(ctx, additionalGenesisWallets)
+	}
+
+	node, err := c.newNode(ctx, 0, true)
+	if err != nil {
+		return err
+	}
+
 	cmd := []string{
 		c.Config().Bin,
 		"--dev", "--datadir", c.HomeDir(), "-http", "--http.addr", "0.0.0.0", "--http.port", "8545", "--allow-insecure-unlock",
@@ -54,7 +110,22 @@ func (c *GethChain) Start(testName string, ctx context.Context, additionalGenesi
 
 	cmd = append(cmd, c.Config().AdditionalStartArgs...)
 
-	return c.EthereumChain.Start(ctx, cmd, []mount.Mount{})
+	if err := c.EthereumChain.Start(ctx, cmd, []mount.Mount{}); err != nil {
+		return err
+	}
+
+	node.containerLifecycle = c.ContainerLifecycle()
+	node.hostRPCAddress = c.GetHostRPCAddress()
+	node.rpcClient = c.RPCClient()
+	c.Validators = GethNodes{node}
+	return nil
+}
+
+// GetHostPeerAddress returns the enode URL of node 0 of a network, on the docker network, which a geth node
+// started by the test can add as a peer. A single "--dev" node does not accept peers, so it returns an empty string.
+// This will not return a valid address until the chain has been started.
+func (c *GethChain) GetHostPeerAddress() string {
+	return c.peerAddress
 }
 
 // JavaScriptExec() - Execute web3 code via geth's attach command.
@@ -185,6 +256,37 @@ func (c *GethChain) UnlockAccount(ctx context.Context, account *NodeWallet) erro
 	return err
 }
 
+// PrivateKey returns the private key of keyName, decrypted from its keystore file in the geth data directory,
+// to sign the transactions of the EthereumChain contract API.
+func (c *GethChain) PrivateKey(ctx context.Context, keyName string) (*ecdsa.PrivateKey, error) {
+	addr, err := c.GetAddress(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keystore files are named after the account's address, in lower case and without the 0x prefix.
+	stdout, _, err := c.Exec(ctx, []string{"ls", path.Join(c.HomeDir(), "keystore")}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keystore: %w", err)
+	}
+	suffix := "--" + hex.EncodeToString(addr)
+	for _, name := range strings.Fields(string(stdout)) {
+		if !strings.HasSuffix(strings.ToLower(name), suffix) {
+			continue
+		}
+		keyJSON, err := c.ReadFile(ctx, path.Join("keystore", name))
+		if err != nil {
+			return nil, err
+		}
+		key, err := keystore.DecryptKey(keyJSON, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore of %s: %w", keyName, err)
+		}
+		return key.PrivateKey, nil
+	}
+	return nil, fmt.Errorf("keystore of %s (%s) not found", keyName, hexutil.Encode(addr))
+}
+
 func (c *GethChain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
 	_, err := c.SendFundsWithNote(ctx, keyName, amount, "")
 	return err
@@ -0,0 +1,336 @@
+package geth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+const (
+	genesisFile    = "genesis.json"
+	passwordFile   = "password"
+	accountKeyFile = "account.key"
+	jwtSecretFile  = "jwtsecret"
+
+	enginePort = 8551
+
+	// cliquePeriod is the block time of Clique networks, in seconds.
+	cliquePeriod = 2
+	// cliqueRemovedVersion is the geth release which removed Clique block production.
+	cliqueRemovedVersion = "v1.14.0"
+)
+
+// faucetBalance is the genesis balance of the faucet of multi-node networks, in wei.
+var faucetBalance = new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+
+// network reports whether the chain is started as a network from a generated genesis, rather than as a "--dev" node.
+func (c *GethChain) network() bool {
+	return c.numValidators != 1 || c.numFullNodes > 0 || c.beacon()
+}
+
+// cliqueSupported reports whether the geth image of cfg can produce Clique blocks, i.e. is older than v1.14.
+// Images whose version is not a release, e.g. "latest", are assumed not to.
+func cliqueSupported(cfg ibc.ChainConfig) bool {
+	if len(cfg.Images) == 0 {
+		return false
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(cfg.Images[0].Version, "v"), "%d.%d", &major, &minor); err != nil {
+		return false
+	}
+	return major < 1 || (major == 1 && minor < 14)
+}
+
+// beacon reports whether blocks are produced by beacon client sidecars rather than by Clique signers.
+func (c *GethChain) beacon() bool {
+	return len(c.Config().SidecarConfigs) > 0
+}
+
+// startNetwork starts a network of numValidators and numFullNodes nodes from a generated genesis, in which the
+// faucet and additionalGenesisWallets are funded. Validators are Clique signers, unless SidecarConfigs are set:
+// then the nodes enable the engine API, and the sidecars are started to drive block production as beacon clients.
+func (c *GethChain) startNetwork(ctx context.Context, additionalGenesisWallets []ibc.WalletAmount) error {
+	faucet, err := crypto.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate faucet key: %w", err)
+	}
+
+	var nodes GethNodes
+	for i := 0; i < c.numValidators+c.numFullNodes; i++ {
+		n, err := c.newNode(ctx, i, i < c.numValidators)
+		if err != nil {
+			return err
+		}
+		switch {
+		case i == 0:
+			// The faucet is the first account of node 0, and also its Clique signer.
+			n.account = faucet
+		case n.Validator && !c.beacon():
+			if n.account, err = crypto.GenerateKey(); err != nil {
+				return fmt.Errorf("failed to generate signer key: %w", err)
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	c.Validators, c.FullNodes = nodes[:c.numValidators], nodes[c.numValidators:]
+
+	genbz, err := c.genesis(nodes, additionalGenesisWallets)
+	if err != nil {
+		return err
+	}
+	jwtSecret := make([]byte, 32)
+	if _, err := rand.Read(jwtSecret); err != nil {
+		return fmt.Errorf("failed to generate jwt secret: %w", err)
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range nodes {
+		eg.Go(func() error {
+			return n.initialize(egCtx, genbz, jwtSecret)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := c.startSidecars(ctx, nodes, true); err != nil {
+		return err
+	}
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range nodes {
+		eg.Go(func() error {
+			if n.Index > 0 {
+				return n.startContainer(egCtx, c.nodeCmd(n))
+			}
+			if err := c.EthereumChain.StartContainer(egCtx, c.nodeCmd(n), nil); err != nil {
+				return err
+			}
+			n.containerLifecycle = c.ContainerLifecycle()
+			n.hostRPCAddress = c.GetHostRPCAddress()
+			n.rpcClient = c.RPCClient()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := c.connectPeers(ctx, nodes); err != nil {
+		return err
+	}
+	peerAddress, err := nodes[0].Enode(ctx)
+	if err != nil {
+		return err
+	}
+	c.peerAddress = peerAddress
+
+	if err := c.startSidecars(ctx, nodes, false); err != nil {
+		return err
+	}
+
+	return testutil.WaitForBlocks(ctx, 2, c)
+}
+
+// genesis returns the genesis of the network of nodes, funding the faucet and wallets.
+func (c *GethChain) genesis(nodes GethNodes, wallets []ibc.WalletAmount) ([]byte, error) {
+	chainID, err := strconv.ParseInt(c.Config().ChainID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("geth chain id must be an integer: %w", err)
+	}
+
+	config := map[string]any{
+		"chainId":             chainID,
+		"homesteadBlock":      0,
+		"eip150Block":         0,
+		"eip155Block":         0,
+		"eip158Block":         0,
+		"byzantiumBlock":      0,
+		"constantinopleBlock": 0,
+		"petersburgBlock":     0,
+		"istanbulBlock":       0,
+		"muirGlacierBlock":    0,
+		"berlinBlock":         0,
+		"londonBlock":         0,
+	}
+
+	alloc := map[string]map[string]string{
+		nodes[0].Account().Hex(): {"balance": hexutil.EncodeBig(faucetBalance)},
+	}
+	for _, w := range wallets {
+		if !common.IsHexAddress(w.Address) {
+			return nil, fmt.Errorf("invalid genesis wallet address %q", w.Address)
+		}
+		alloc[common.HexToAddress(w.Address).Hex()] = map[string]string{"balance": hexutil.EncodeBig(w.Amount.BigInt())}
+	}
+
+	genesis := map[string]any{
+		"config":   config,
+		"gasLimit": hexutil.Uint64(30_000_000),
+		"alloc":    alloc,
+	}
+
+	if c.beacon() {
+		config["mergeNetsplitBlock"] = 0
+		config["terminalTotalDifficulty"] = 0
+		config["terminalTotalDifficultyPassed"] = true
+		config["shanghaiTime"] = 0
+		config["cancunTime"] = 0
+		genesis["difficulty"] = "0x0"
+	} else {
+		config["clique"] = map[string]any{"period": cliquePeriod, "epoch": 30000}
+		genesis["difficulty"] = "0x1"
+
+		// The extra data holds 32 vanity bytes, the sorted signers, and an empty 65 byte seal.
+		var signers []common.Address
+		for _, n := range nodes {
+			if n.Validator {
+				signers = append(signers, n.Account())
+			}
+		}
+		slices.SortFunc(signers, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+		extra := make([]byte, 32, 32+len(signers)*common.AddressLength+65)
+		for _, signer := range signers {
+			extra = append(extra, signer[:]...)
+		}
+		genesis["extraData"] = hexutil.Bytes(append(extra, make([]byte, 65)...))
+	}
+
+	genbz, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis: %w", err)
+	}
+	return genbz, nil
+}
+
+// initialize writes the genesis, the JWT secret and the node's account into its home directory,
+// and initializes its database from the genesis.
+func (n *GethNode) initialize(ctx context.Context, genbz, jwtSecret []byte) error {
+	home := n.chain.HomeDir()
+	files := map[string][]byte{
+		genesisFile:   genbz,
+		passwordFile:  {},
+		jwtSecretFile: []byte(hex.EncodeToString(jwtSecret)),
+	}
+	script := fmt.Sprintf("%s init --datadir %s %s", n.chain.Config().Bin, home, path.Join(home, genesisFile))
+	if n.account != nil {
+		files[accountKeyFile] = []byte(hex.EncodeToString(crypto.FromECDSA(n.account)))
+		script += fmt.Sprintf(" && %s account import --datadir %s --password %s %s && rm %s",
+			n.chain.Config().Bin, home, path.Join(home, passwordFile), path.Join(home, accountKeyFile), path.Join(home, accountKeyFile))
+	}
+
+	for relPath, content := range files {
+		if err := n.WriteFile(ctx, relPath, content); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err := n.Exec(ctx, []string{"sh", "-c", script}, nil); err != nil {
+		return fmt.Errorf("failed to initialize node %d: %w", n.Index, err)
+	}
+	return nil
+}
+
+// nodeCmd returns the start command of n.
+func (c *GethChain) nodeCmd(n *GethNode) []string {
+	home := c.HomeDir()
+	cmd := []string{
+		c.Config().Bin,
+		"--datadir", home,
+		"--networkid", c.Config().ChainID,
+		"--http", "--http.addr", "0.0.0.0", "--http.port", "8545", "--allow-insecure-unlock",
+		"--http.api", "eth,net,web3,miner,personal,txpool,debug,admin", "--http.corsdomain", "*", "--http.vhosts=*",
+		"--port", strconv.Itoa(p2pPort), "--nodiscover", "--syncmode", "full",
+		"--nodekeyhex", hex.EncodeToString(crypto.FromECDSA(n.nodeKey)),
+		"--miner.gasprice", c.Config().GasPrices,
+		"--rpc.allow-unprotected-txs",
+	}
+
+	if n.account != nil {
+		account := n.Account().Hex()
+		cmd = append(cmd, "--unlock", account, "--password", path.Join(home, passwordFile))
+		if n.Validator && !c.beacon() {
+			cmd = append(cmd, "--mine", "--miner.etherbase", account)
+		}
+	}
+	if c.beacon() {
+		cmd = append(cmd,
+			"--authrpc.addr", "0.0.0.0", "--authrpc.port", strconv.Itoa(enginePort), "--authrpc.vhosts=*",
+			"--authrpc.jwtsecret", path.Join(home, jwtSecretFile),
+		)
+	}
+
+	return append(cmd, c.Config().AdditionalStartArgs...)
+}
+
+// connectPeers connects every pair of nodes over the docker network, and waits until every node sees all of its peers.
+func (c *GethChain) connectPeers(ctx context.Context, nodes GethNodes) error {
+	enodes := make([]string, len(nodes))
+	for i, n := range nodes {
+		var err error
+		if enodes[i], err = n.Enode(ctx); err != nil {
+			return err
+		}
+	}
+
+	for i, n := range nodes {
+		for _, peer := range enodes[:i] {
+			if err := n.AddPeer(ctx, peer); err != nil {
+				return err
+			}
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	for _, n := range nodes {
+		for {
+			count, err := n.PeerCount(waitCtx)
+			if err == nil && count >= uint64(len(nodes)-1) {
+				break
+			}
+			select {
+			case <-waitCtx.Done():
+				return fmt.Errorf("node %d connected to %d of %d peers: %w", n.Index, count, len(nodes)-1, waitCtx.Err())
+			case <-time.After(time.Second):
+			}
+		}
+	}
+	return nil
+}
+
+// startSidecars starts the sidecars whose PreStart matches preStart: those which are validator processes
+// for each validator, and the others once, for node 0.
+func (c *GethChain) startSidecars(ctx context.Context, nodes GethNodes, preStart bool) error {
+	for _, cfg := range c.Config().SidecarConfigs {
+		if cfg.PreStart != preStart {
+			continue
+		}
+		for _, n := range nodes {
+			if (cfg.ValidatorProcess && !n.Validator) || (!cfg.ValidatorProcess && n.Index > 0) {
+				continue
+			}
+			if err := n.startSidecar(ctx, cfg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
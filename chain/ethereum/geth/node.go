@@ -0,0 +1,280 @@
+package geth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-connections/nat"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchaintest/v11/dockerutil"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+const (
+	rpcPort = "8545/tcp"
+	p2pPort = 30303
+)
+
+// GethNodes is a collection of GethNode.
+type GethNodes []*GethNode
+
+// GethNode is a geth node of a GethChain. Node 0 is the container of the chain itself,
+// which serves the chain-level RPC and holds the keystore of the chain's keys.
+type GethNode struct {
+	Index int
+
+	// Validator is true if the node produces blocks, as a Clique signer or with a beacon client sidecar.
+	Validator bool
+
+	chain *GethChain
+	log   *zap.Logger
+
+	name       string
+	hostName   string
+	volumeName string
+
+	containerLifecycle *dockerutil.ContainerLifecycle
+	sidecars           []*dockerutil.ContainerLifecycle
+
+	hostRPCAddress string
+	rpcClient      *ethclient.Client
+
+	nodeKey *ecdsa.PrivateKey
+	// account is the key unlocked on the node: the faucet on node 0, and the Clique signer on other validators.
+	account *ecdsa.PrivateKey
+}
+
+// newNode returns node index of the chain, creating its volume unless it is node 0, which uses the chain's volume.
+func (c *GethChain) newNode(ctx context.Context, index int, validator bool) (*GethNode, error) {
+	nodeKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key: %w", err)
+	}
+
+	n := &GethNode{
+		Index:     index,
+		Validator: validator,
+		chain:     c,
+		log:       c.Logger().With(zap.Int("node", index)),
+		nodeKey:   nodeKey,
+	}
+	if index == 0 {
+		n.name, n.hostName, n.volumeName = c.Name(), c.HostName(), c.VolumeName()
+		return n, nil
+	}
+
+	cfg := c.Config()
+	n.name = fmt.Sprintf("%s-%s-%s-%d-%s", cfg.Name, cfg.Bin, cfg.ChainID, index, dockerutil.SanitizeContainerName(c.testName))
+	n.hostName = dockerutil.CondenseHostName(n.name)
+	n.containerLifecycle = dockerutil.NewContainerLifecycle(n.log, c.DockerClient(), n.name)
+
+	v, err := c.DockerClient().VolumeCreate(ctx, volume.CreateOptions{
+		Labels: map[string]string{
+			dockerutil.CleanupLabel: c.testName,
+
+			dockerutil.NodeOwnerLabel: n.name,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating volume for geth node: %w", err)
+	}
+	n.volumeName = v.Name
+
+	image := cfg.Images[0]
+	if err := dockerutil.SetVolumeOwner(ctx, dockerutil.VolumeOwnerOptions{
+		Log: n.log,
+
+		Client: c.DockerClient(),
+
+		VolumeName: v.Name,
+		ImageRef:   image.Ref(),
+		TestName:   c.testName,
+		UidGid:     image.UIDGID,
+	}); err != nil {
+		return nil, fmt.Errorf("set volume owner: %w", err)
+	}
+
+	return n, nil
+}
+
+// Name returns the container name of the node.
+func (n *GethNode) Name() string {
+	return n.name
+}
+
+// HostName returns the host name of the node in the docker network.
+func (n *GethNode) HostName() string {
+	return n.hostName
+}
+
+// GetRPCAddress returns the rpc address of the node, reachable by other containers in the docker network.
+func (n *GethNode) GetRPCAddress() string {
+	return fmt.Sprintf("http://%s:8545", n.hostName)
+}
+
+// GetHostRPCAddress returns the rpc address of the node, reachable by processes on the host machine.
+// Note that this will not return a valid value until after the chain is started.
+func (n *GethNode) GetHostRPCAddress() string {
+	return n.hostRPCAddress
+}
+
+// RPCClient returns the client of the node's JSON-RPC, dialed from the host.
+// Note that this will not return a valid value until after the chain is started.
+func (n *GethNode) RPCClient() *ethclient.Client {
+	return n.rpcClient
+}
+
+// Account returns the address of the account unlocked on the node, which is the Clique signer of validators.
+func (n *GethNode) Account() common.Address {
+	if n.account == nil {
+		return common.Address{}
+	}
+	return crypto.PubkeyToAddress(n.account.PublicKey)
+}
+
+// Height returns the latest block height of the node.
+func (n *GethNode) Height(ctx context.Context) (int64, error) {
+	height, err := n.rpcClient.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get height of node %d: %w", n.Index, err)
+	}
+	return int64(height), nil
+}
+
+// PeerCount returns the number of peers connected to the node.
+func (n *GethNode) PeerCount(ctx context.Context) (uint64, error) {
+	count, err := n.rpcClient.PeerCount(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get peer count of node %d: %w", n.Index, err)
+	}
+	return count, nil
+}
+
+// Enode returns the enode URL of the node on the docker network.
+func (n *GethNode) Enode(ctx context.Context) (string, error) {
+	ip, err := dockerutil.ContainerIP(ctx, n.chain.DockerClient(), n.containerLifecycle.ContainerID(), n.chain.NetworkID())
+	if err != nil {
+		return "", err
+	}
+	return enode.NewV4(&n.nodeKey.PublicKey, net.ParseIP(ip), p2pPort, p2pPort).URLv4(), nil
+}
+
+// AddPeer connects the node to the peer with the enode URL peerEnode.
+func (n *GethNode) AddPeer(ctx context.Context, peerEnode string) error {
+	var added bool
+	if err := n.rpcClient.Client().CallContext(ctx, &added, "admin_addPeer", peerEnode); err != nil {
+		return fmt.Errorf("failed to add peer to node %d: %w", n.Index, err)
+	}
+	if !added {
+		return fmt.Errorf("node %d did not add peer %s", n.Index, peerEnode)
+	}
+	return nil
+}
+
+// PauseContainer pauses the node container, e.g. to test reorgs and finality while it is partitioned from its peers.
+func (n *GethNode) PauseContainer(ctx context.Context) error {
+	return n.containerLifecycle.PauseContainer(ctx)
+}
+
+// UnpauseContainer unpauses the node container paused by PauseContainer.
+func (n *GethNode) UnpauseContainer(ctx context.Context) error {
+	return n.containerLifecycle.UnpauseContainer(ctx)
+}
+
+// Bind returns the volume bind of the node's home directory.
+func (n *GethNode) Bind() []string {
+	return []string{fmt.Sprintf("%s:%s", n.volumeName, n.chain.HomeDir())}
+}
+
+// Exec runs cmd in a one-off container of the chain image, with the node's home directory mounted.
+func (n *GethNode) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
+	image := n.chain.Config().Images[0]
+	job := dockerutil.NewImage(n.log, n.chain.DockerClient(), n.chain.NetworkID(), n.chain.testName, image.Repository, image.Version)
+	res := job.Run(ctx, cmd, dockerutil.ContainerOptions{
+		Env:   env,
+		Binds: n.Bind(),
+	})
+	return res.Stdout, res.Stderr, res.Err
+}
+
+// WriteFile writes the single file containing content, at relPath within the node's home directory.
+func (n *GethNode) WriteFile(ctx context.Context, relPath string, content []byte) error {
+	fw := dockerutil.NewFileWriter(n.log, n.chain.DockerClient(), n.chain.testName)
+	if err := fw.WriteFile(ctx, n.volumeName, relPath, content); err != nil {
+		return fmt.Errorf("failed to write file at %s: %w", relPath, err)
+	}
+	return nil
+}
+
+// startContainer creates and starts the container of a node other than node 0 running cmd, and dials its RPC.
+// Node 0 is started by the chain.
+func (n *GethNode) startContainer(ctx context.Context, cmd []string) error {
+	ports := nat.PortMap{nat.Port(rpcPort): {}}
+	if err := n.containerLifecycle.CreateContainer(ctx, n.chain.testName, n.chain.NetworkID(), n.chain.Config().Images[0], ports, "", n.Bind(), nil, n.hostName, cmd, nil, []string{}); err != nil {
+		return err
+	}
+
+	n.log.Info("Starting container", zap.String("container", n.name))
+	if err := n.containerLifecycle.StartContainer(ctx); err != nil {
+		return err
+	}
+
+	hostPorts, err := n.containerLifecycle.GetHostPorts(ctx, rpcPort)
+	if err != nil {
+		return err
+	}
+	n.hostRPCAddress = "http://" + hostPorts[0]
+
+	// The rpc takes a moment to come up.
+	for attempt := 0; ; attempt++ {
+		time.Sleep(2 * time.Second)
+		n.rpcClient, err = ethclient.DialContext(ctx, n.hostRPCAddress)
+		if err == nil {
+			return nil
+		}
+		if attempt == 2 {
+			return fmt.Errorf("failed to dial ETH rpc host(%s): %w", n.hostRPCAddress, err)
+		}
+	}
+}
+
+// startSidecar creates and starts the sidecar process of cfg for the node, with the node's volume mounted at its home directory,
+// so that it can read the genesis and the JWT secret of the engine API.
+func (n *GethNode) startSidecar(ctx context.Context, cfg ibc.SidecarConfig) error {
+	homeDir := cfg.HomeDir
+	if homeDir == "" {
+		homeDir = "/home/sidecar"
+	}
+
+	name := fmt.Sprintf("%s-%s-%d-%s", n.chain.Config().ChainID, cfg.ProcessName, n.Index, dockerutil.SanitizeContainerName(n.chain.testName))
+	lifecycle := dockerutil.NewContainerLifecycle(n.log, n.chain.DockerClient(), name)
+
+	ports := nat.PortMap{}
+	for _, port := range cfg.Ports {
+		ports[nat.Port(port)] = []nat.PortBinding{}
+	}
+	env := append([]string{
+		"EXECUTION_ENDPOINT=" + fmt.Sprintf("http://%s:%d", n.hostName, enginePort),
+		"JWT_SECRET_PATH=" + homeDir + "/" + jwtSecretFile,
+		"GENESIS_PATH=" + homeDir + "/" + genesisFile,
+	}, cfg.Env...)
+
+	binds := []string{fmt.Sprintf("%s:%s", n.volumeName, homeDir)}
+	if err := lifecycle.CreateContainer(ctx, n.chain.testName, n.chain.NetworkID(), cfg.Image, ports, "", binds, nil, dockerutil.CondenseHostName(name), cfg.StartCmd, env, []string{}); err != nil {
+		return fmt.Errorf("failed to create sidecar %s: %w", cfg.ProcessName, err)
+	}
+	if err := lifecycle.StartContainer(ctx); err != nil {
+		return fmt.Errorf("failed to start sidecar %s: %w", cfg.ProcessName, err)
+	}
+	n.sidecars = append(n.sidecars, lifecycle)
+	return nil
+}
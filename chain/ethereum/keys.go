@@ -0,0 +1,23 @@
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+)
+
+// PrivateKeyFromMnemonic derives the private key of the first account of mnemonic, on the ethereum HD path.
+func PrivateKeyFromMnemonic(mnemonic string) (*ecdsa.PrivateKey, error) {
+	derivedPriv, err := hd.Secp256k1.Derive()(mnemonic, "", hd.CreateHDPath(60, 0, 0).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	privKey, err := crypto.ToECDSA(derivedPriv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+	return privKey, nil
+}
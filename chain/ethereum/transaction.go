@@ -0,0 +1,118 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SendTransaction signs a transaction from key, of value wei and data to the address to, or creating a contract if to is nil,
+// and waits for its receipt. The gas limit is estimated by the node.
+// An error is returned if the transaction reverted, together with its receipt.
+func (c *EthereumChain) SendTransaction(ctx context.Context, key *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	lock, _ := c.txLocks.LoadOrStore(from, new(sync.Mutex))
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	tx, err := c.newTx(ctx, from, to, value, data)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := c.rpcClient.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain id: %w", err)
+	}
+	signedTx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := c.rpcClient.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	receipt, err := c.WaitForReceipt(ctx, signedTx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s reverted at height %d", signedTx.Hash(), receipt.BlockNumber)
+	}
+	return receipt, nil
+}
+
+// newTx returns an unsigned transaction from the sender, with its pending nonce and the suggested fees.
+// A dynamic fee transaction is used if the chain has a base fee, and a legacy transaction otherwise.
+func (c *EthereumChain) newTx(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (types.TxData, error) {
+	nonce, err := c.rpcClient.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %w", from, err)
+	}
+	gas, err := c.rpcClient.EstimateGas(ctx, goethereum.CallMsg{From: from, To: to, Value: value, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	head, err := c.rpcClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		gasPrice, err := c.rpcClient.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		return &types.LegacyTx{Nonce: nonce, GasPrice: gasPrice, Gas: gas, To: to, Value: value, Data: data}, nil
+	}
+
+	tipCap, err := c.rpcClient.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+	// Leave room for the base fee to double before the transaction is included.
+	feeCap := new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	return &types.DynamicFeeTx{Nonce: nonce, GasTipCap: tipCap, GasFeeCap: feeCap, Gas: gas, To: to, Value: value, Data: data}, nil
+}
+
+// ReceiptFee returns the fee in wei paid by the transaction of receipt, i.e. its gas used at its effective gas price.
+func ReceiptFee(receipt *types.Receipt) *big.Int {
+	if receipt.EffectiveGasPrice == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+}
+
+// WaitForReceipt polls for the receipt of the transaction txHash, until it is included or ctx is done.
+func (c *EthereumChain) WaitForReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		receipt, err := c.rpcClient.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, goethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get receipt of %s: %w", txHash, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for receipt of %s: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
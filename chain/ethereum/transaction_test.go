@@ -0,0 +1,18 @@
+package ethereum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+)
+
+func TestReceiptFee(t *testing.T) {
+	receipt := &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(2_000_000_000)}
+	require.Equal(t, big.NewInt(42_000_000_000_000), ethereum.ReceiptFee(receipt))
+
+	require.Zero(t, ethereum.ReceiptFee(&types.Receipt{GasUsed: 21000}).Sign())
+}
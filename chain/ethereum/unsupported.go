@@ -0,0 +1,68 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// ErrNotSupported is matched, with errors.Is, by the errors of ibc.Chain methods
+// which have no Ethereum equivalent, e.g. IBC transfers and packet queries.
+var ErrNotSupported = errors.New("not supported on ethereum chains")
+
+// NotSupportedError is returned by ibc.Chain methods which have no Ethereum equivalent.
+type NotSupportedError struct {
+	// Method is the name of the unsupported method.
+	Method string
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Method, ErrNotSupported)
+}
+
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+func notSupported(method string) error {
+	return &NotSupportedError{Method: method}
+}
+
+// PanicFunctionName panics with the name of its caller.
+//
+// Deprecated: ibc.Chain methods which have no Ethereum equivalent return a *NotSupportedError,
+// matching ErrNotSupported, instead of panicking.
+func PanicFunctionName() {
+	pc, _, _, _ := runtime.Caller(1)
+	panic(runtime.FuncForPC(pc).Name() + " not implemented")
+}
+
+// GetGRPCAddress returns an empty string, as ethereum nodes do not serve gRPC.
+func (c *EthereumChain) GetGRPCAddress() string {
+	return ""
+}
+
+// GetHostGRPCAddress returns an empty string, as ethereum nodes do not serve gRPC.
+func (c *EthereumChain) GetHostGRPCAddress() string {
+	return ""
+}
+
+// GetHostPeerAddress returns an empty string, as the p2p port of the node is not exposed to the host.
+func (*EthereumChain) GetHostPeerAddress() string {
+	return ""
+}
+
+func (c *EthereumChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, options ibc.TransferOptions) (ibc.Tx, error) {
+	return ibc.Tx{}, notSupported("SendIBCTransfer")
+}
+
+func (c *EthereumChain) Acknowledgements(ctx context.Context, height int64) ([]ibc.PacketAcknowledgement, error) {
+	return nil, notSupported("Acknowledgements")
+}
+
+func (c *EthereumChain) Timeouts(ctx context.Context, height int64) ([]ibc.PacketTimeout, error) {
+	return nil, notSupported("Timeouts")
+}
@@ -0,0 +1,52 @@
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+func TestNotSupported(t *testing.T) {
+	chain := ethereum.NewEthereumChain(t.Name(), ibc.ChainConfig{}, zaptest.NewLogger(t))
+
+	_, err := chain.SendIBCTransfer(context.Background(), "channel-0", "faucet", ibc.WalletAmount{}, ibc.TransferOptions{})
+	require.ErrorIs(t, err, ethereum.ErrNotSupported)
+	var notSupported *ethereum.NotSupportedError
+	require.True(t, errors.As(err, &notSupported))
+	require.Equal(t, "SendIBCTransfer", notSupported.Method)
+	require.EqualError(t, err, "SendIBCTransfer: not supported on ethereum chains")
+
+	_, err = chain.Acknowledgements(context.Background(), 1)
+	require.ErrorIs(t, err, ethereum.ErrNotSupported)
+	_, err = chain.Timeouts(context.Background(), 1)
+	require.ErrorIs(t, err, ethereum.ErrNotSupported)
+
+	require.NotErrorIs(t, errors.New("not supported"), ethereum.ErrNotSupported)
+}
+
+func TestGetGasFeesInNativeDenom(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		gasPrices string
+		gasPaid   int64
+		want      int64
+	}{
+		{name: "configured gas price", gasPrices: "2000000000wei", gasPaid: 21000, want: 42_000_000_000_000},
+		{name: "no denom", gasPrices: "3", gasPaid: 100, want: 300},
+		{name: "invalid gas price", gasPrices: "0.5wei", gasPaid: 21000, want: 0},
+		{name: "overflow", gasPrices: "1000000000000wei", gasPaid: math.MaxInt64 / 2, want: math.MaxInt64},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := ibc.ChainConfig{Denom: "wei", GasPrices: tc.gasPrices}
+			chain := ethereum.NewEthereumChain(t.Name(), cfg, zaptest.NewLogger(t))
+			require.Equal(t, tc.want, chain.GetGasFeesInNativeDenom(tc.gasPaid))
+		})
+	}
+}
@@ -138,7 +138,19 @@ func buildChain(log *zap.Logger, testName string, cfg ibc.ChainConfig, numValida
 		case "anvil":
 			return foundry.NewAnvilChain(testName, cfg, log), nil
 		case "geth":
-			return geth.NewGethChain(testName, cfg, log), nil
+			// Unlike cosmos chains, geth chains default to a single "--dev" node.
+			gethValidators, gethFullNodes := 1, 0
+			if numValidators != nil {
+				gethValidators = *numValidators
+			}
+			if numFullNodes != nil {
+				gethFullNodes = *numFullNodes
+			}
+			c, err := geth.NewGethNetworkChain(testName, cfg, gethValidators, gethFullNodes, log)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
 		default:
 			return nil, fmt.Errorf("unknown binary: %s for ethereum chain type, must be anvil or geth", cfg.Bin)
 		}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cosmos/interchaintest/v11/blockdb"
 	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
 )
 
 // chainSet is an unordered collection of ibc.Chain,
@@ -48,6 +49,24 @@ func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
 	return cs
 }
 
+// gasProfiledChain is implemented by chains which can record the gas used by their transactions.
+type gasProfiledChain interface {
+	SetGasProfiler(p *testreporter.GasProfiler)
+}
+
+// SetGasProfiler sets p on every chain in the set which supports gas profiling.
+// This method is a nop if p is nil.
+func (cs *chainSet) SetGasProfiler(p *testreporter.GasProfiler) {
+	if p == nil {
+		return
+	}
+	for c := range cs.chains {
+		if pc, ok := c.(gasProfiledChain); ok {
+			pc.SetGasProfiler(p)
+		}
+	}
+}
+
 // Initialize concurrently calls Initialize against each chain in the set.
 // Each chain may run a docker pull command,
 // so with a cold image cache, running concurrently may save some time.
@@ -37,7 +37,8 @@ type ChainSpec struct {
 
 	// How many validators and how many full nodes to use
 	// when instantiating the chain.
-	// If unspecified, NumValidators defaults to 2 and NumFullNodes defaults to 1.
+	// If unspecified, NumValidators defaults to 2 and NumFullNodes defaults to 1,
+	// except for geth chains, which default to a single validator and no full nodes.
 	NumValidators, NumFullNodes *int
 
 	// Generate the automatic suffix on demand when needed.
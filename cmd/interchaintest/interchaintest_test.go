@@ -105,7 +105,7 @@ func setUpTestMatrix() error {
 		numValsOne := 1
 		numFullNodesZero := 0
 
-		testMatrix.Relayers = []string{"rly", "hermes"}
+		testMatrix.Relayers = []string{"rly", "hermes", "inprocess"}
 		testMatrix.ChainSets = [][]*interchaintest.ChainSpec{
 			{
 				{Name: testutil.TestSimd, Version: testutil.SimdVersion, ChainName: "c1", NumValidators: &numValsOne, NumFullNodes: &numFullNodesZero},
@@ -177,8 +177,10 @@ func getRelayerFactory(name string, logger *zap.Logger) (interchaintest.RelayerF
 		return interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger, relayer.StartupFlags("-b", "100")), nil
 	case "hermes":
 		return interchaintest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
+	case "inprocess":
+		return interchaintest.NewBuiltinRelayerFactory(ibc.InProcess, logger), nil
 	default:
-		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", name)
+		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes, inprocess)", name)
 	}
 }
 
@@ -0,0 +1,120 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/types"
+
+	transfertypes "github.com/cosmos/ibc-go/v11/modules/apps/transfer/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/relayer"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestRelayerStepwise asserts that a packet sent between two chains is only delivered,
+// and its acknowledgement only returned, when explicitly relayed through RelayPackets and RelayAcks.
+func TestRelayerStepwise(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.RelayPackets)
+
+	client, network := interchaintest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network)
+
+	const pathName = "p"
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateChannelOpts: ibc.DefaultChannelOpts(),
+		})
+
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	c1FaucetAddrBytes, err := c1.GetAddress(ctx, interchaintest.FaucetAccountKeyName)
+	req.NoError(err)
+	c1FaucetAddr, err := types.Bech32ifyAddressBytes(c1.Config().Bech32Prefix, c1FaucetAddrBytes)
+	req.NoError(err)
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, c0.Config().ChainID, c1.Config().ChainID)
+	req.NoError(err)
+
+	beforeTransferHeight, err := c0.Height(ctx)
+	req.NoError(err)
+
+	const txAmount = 445566 // Arbitrary amount that is easy to find in logs.
+	tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, interchaintest.FaucetAccountKeyName, ibc.WalletAmount{
+		Address: c1FaucetAddr,
+		Denom:   c0.Config().Denom,
+		Amount:  math.NewInt(txAmount),
+	}, ibc.TransferOptions{})
+	req.NoError(err)
+	req.NoError(tx.Validate())
+
+	// Nothing relays in the background, so the packet must still be pending after a few blocks.
+	req.NoError(testutil.WaitForBlocks(ctx, 3, c0, c1))
+	ibcDenom := transfertypes.NewDenom(c0.Config().Denom, transfertypes.NewHop(channel.Counterparty.PortID, channel.Counterparty.ChannelID)).IBCDenom()
+	bal, err := c1.GetBalance(ctx, c1FaucetAddr, ibcDenom)
+	req.NoError(err)
+	req.True(bal.IsZero(), "packet was relayed without being requested")
+
+	var seqs []uint64
+	if len(missingCapabilities(rf, relayer.RelayPacketSequences)) == 0 {
+		seqs = []uint64{tx.Packet.Sequence}
+	}
+
+	relayed, err := r.RelayPackets(ctx, eRep, pathName, c0.Config().ChainID, channel.ChannelID, seqs...)
+	req.NoError(err)
+	if seqs != nil {
+		req.Equal(seqs, relayed)
+	}
+
+	bal, err = c1.GetBalance(ctx, c1FaucetAddr, ibcDenom)
+	req.NoError(err)
+	req.True(bal.Equal(math.NewInt(txAmount)), "unexpected balance after relaying packet: %s", bal)
+
+	relayed, err = r.RelayAcks(ctx, eRep, pathName, c0.Config().ChainID, channel.ChannelID, seqs...)
+	req.NoError(err)
+	if seqs != nil {
+		req.Equal(seqs, relayed)
+	}
+
+	afterRelayHeight, err := c0.Height(ctx)
+	req.NoError(err)
+
+	_, err = testutil.PollForAck(ctx, c0, beforeTransferHeight, afterRelayHeight+5, tx.Packet)
+	req.NoError(err)
+}
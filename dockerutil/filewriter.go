@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
@@ -30,6 +31,12 @@ func NewFileWriter(log *zap.Logger, cli *client.Client, testName string) *FileWr
 
 // WriteFile writes the single file containing content, at relPath within the given volume.
 func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string, content []byte) error {
+	return w.WriteFileFrom(ctx, volumeName, relPath, bytes.NewReader(content), int64(len(content)))
+}
+
+// WriteFileFrom writes the single file containing the size bytes read from r, at relPath within the given volume.
+// The content is streamed into the volume, so large files need not be held in memory.
+func (w *FileWriter) WriteFileFrom(ctx context.Context, volumeName, relPath string, r io.Reader, size int64) error {
 	const mountPath = "/mnt/dockervolume"
 
 	if err := EnsureBusybox(ctx, w.cli); err != nil {
@@ -84,33 +91,17 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 		}
 	}()
 
-	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
-	if err := tw.WriteHeader(&tar.Header{
-		Name: relPath,
-
-		Size: int64(len(content)),
-		Mode: 0o600,
-		// Not setting uname because the container will chown it anyway.
-
-		ModTime: time.Now(),
-
-		Format: tar.FormatPAX,
-	}); err != nil {
-		return fmt.Errorf("writing tar header: %w", err)
-	}
-	if _, err := tw.Write(content); err != nil {
-		return fmt.Errorf("writing content to tar: %w", err)
-	}
-	if err := tw.Close(); err != nil {
-		return fmt.Errorf("closing tar writer: %w", err)
-	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, relPath, r, size))
+	}()
+	defer pr.Close()
 
 	if err := w.cli.CopyToContainer(
 		ctx,
 		cc.ID,
 		mountPath,
-		&buf,
+		pr,
 		container.CopyToContainerOptions{},
 	); err != nil {
 		return fmt.Errorf("copying tar to container: %w", err)
@@ -147,3 +138,28 @@ func (w *FileWriter) WriteFile(ctx context.Context, volumeName, relPath string,
 
 	return nil
 }
+
+// writeTar writes a tar archive to w holding the single file relPath, containing the size bytes read from r.
+func writeTar(w io.Writer, relPath string, r io.Reader, size int64) error {
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: relPath,
+
+		Size: size,
+		Mode: 0o600,
+		// Not setting uname because the container will chown it anyway.
+
+		ModTime: time.Now(),
+
+		Format: tar.FormatPAX,
+	}); err != nil {
+		return fmt.Errorf("writing tar header: %w", err)
+	}
+	if _, err := io.CopyN(tw, r, size); err != nil {
+		return fmt.Errorf("writing content to tar: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar writer: %w", err)
+	}
+	return nil
+}
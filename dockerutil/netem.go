@@ -0,0 +1,290 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	dockerimagetypes "github.com/docker/docker/api/types/image"
+	"github.com/moby/moby/client"
+	"github.com/moby/moby/pkg/stdcopy"
+	"go.uber.org/zap"
+)
+
+// DefaultNetworkFaultImage is the helper image used to run tc commands.
+// It must provide sh, ip, awk, and tc; use NetworkFaultInjector.WithImage to run another image.
+const DefaultNetworkFaultImage = "nicolaka/netshoot:v0.13"
+
+// NetworkFault describes degraded network conditions for traffic leaving a container.
+// Zero values leave the corresponding property unaffected.
+type NetworkFault struct {
+	// Added delay for each packet, with optional random variation of up to Jitter.
+	Latency time.Duration
+	Jitter  time.Duration
+
+	// Percentage of packets dropped, from 0 to 100.
+	LossPercent float64
+
+	// Bandwidth limit, in kilobits per second.
+	RateKbit uint64
+
+	// Container IDs whose traffic is affected.
+	// If empty, all traffic leaving the container is affected.
+	Targets []string
+}
+
+// Partition returns a NetworkFault that drops every packet to the given containers.
+func Partition(targets ...string) NetworkFault {
+	return NetworkFault{LossPercent: 100, Targets: targets}
+}
+
+// Validate checks that the fault describes a valid netem configuration.
+func (f NetworkFault) Validate() error {
+	if f.Latency < 0 || f.Jitter < 0 {
+		return errors.New("latency and jitter must not be negative")
+	}
+	if f.Jitter > 0 && f.Latency == 0 {
+		return errors.New("jitter requires latency")
+	}
+	if f.LossPercent < 0 || f.LossPercent > 100 {
+		return fmt.Errorf("loss percent must be between 0 and 100, got %v", f.LossPercent)
+	}
+	if f.Latency == 0 && f.LossPercent == 0 && f.RateKbit == 0 {
+		return errors.New("fault must set at least one of latency, loss percent, or rate")
+	}
+	return nil
+}
+
+// netemArgs returns the arguments to "tc qdisc ... netem" describing f.
+func (f NetworkFault) netemArgs() []string {
+	var args []string
+	if f.Latency > 0 {
+		args = append(args, "delay", strconv.FormatInt(f.Latency.Microseconds(), 10)+"us")
+		if f.Jitter > 0 {
+			args = append(args, strconv.FormatInt(f.Jitter.Microseconds(), 10)+"us")
+		}
+	}
+	if f.LossPercent > 0 {
+		args = append(args, "loss", strconv.FormatFloat(f.LossPercent, 'f', -1, 64)+"%")
+	}
+	if f.RateKbit > 0 {
+		args = append(args, "rate", strconv.FormatUint(f.RateKbit, 10)+"kbit")
+	}
+	return args
+}
+
+// netemScript returns a shell script applying netemArgs to the interface holding the address in $1.
+// If targetIPs is non-empty, only packets to those addresses are affected.
+// Any previously applied fault is removed first.
+func netemScript(netemArgs []string, targetIPs []string) string {
+	lines := []string{
+		"set -e",
+		`dev=$(ip -o -4 addr show | awk -v ip="$1" 'index($4, ip "/") == 1 { print $2; exit }')`,
+		`[ -n "$dev" ] || { echo "no interface with address $1" >&2; exit 1; }`,
+		`tc qdisc del dev "$dev" root 2>/dev/null || true`,
+	}
+	if len(netemArgs) == 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	netem := strings.Join(netemArgs, " ")
+	if len(targetIPs) == 0 {
+		lines = append(lines, `tc qdisc add dev "$dev" root netem `+netem)
+		return strings.Join(lines, "\n")
+	}
+
+	// Unmatched traffic uses the default prio bands 1-3; matched traffic goes to band 4, which carries the netem qdisc.
+	lines = append(lines,
+		`tc qdisc add dev "$dev" root handle 1: prio bands 4`,
+		`tc qdisc add dev "$dev" parent 1:4 handle 40: netem `+netem,
+	)
+	for _, ip := range targetIPs {
+		lines = append(lines, `tc filter add dev "$dev" parent 1: protocol ip prio 1 u32 match ip dst `+ip+`/32 flowid 1:4`)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NetworkFaultInjector applies network faults between containers on a test network,
+// using tc/netem in a short-lived helper container that shares the target container's network namespace.
+// The target containers' processes keep running, so only their connectivity is affected.
+type NetworkFaultInjector struct {
+	log *zap.Logger
+	cli *client.Client
+
+	networkID string
+	testName  string
+
+	image string
+}
+
+// NewNetworkFaultInjector returns a NetworkFaultInjector for containers on the given network.
+//
+// "cli" and "networkID" are likely from DockerSetup.
+// "testName" is from a (*testing.T).Name() and should match the t.Name() from DockerSetup to ensure proper cleanup.
+func NewNetworkFaultInjector(log *zap.Logger, cli *client.Client, networkID, testName string) *NetworkFaultInjector {
+	return &NetworkFaultInjector{
+		log: log,
+		cli: cli,
+
+		networkID: networkID,
+		testName:  testName,
+
+		image: DefaultNetworkFaultImage,
+	}
+}
+
+// WithImage overrides the helper image used to run tc.
+func (n *NetworkFaultInjector) WithImage(ref string) *NetworkFaultInjector {
+	n.image = ref
+	return n
+}
+
+// Apply applies fault to traffic leaving containerID,
+// replacing any fault previously applied to that container.
+// Faults only affect egress traffic; apply a fault to both containers for symmetric conditions.
+func (n *NetworkFaultInjector) Apply(ctx context.Context, containerID string, fault NetworkFault) error {
+	if err := fault.Validate(); err != nil {
+		return err
+	}
+
+	targetIPs := make([]string, len(fault.Targets))
+	for i, t := range fault.Targets {
+		ip, err := n.containerIP(ctx, t)
+		if err != nil {
+			return err
+		}
+		targetIPs[i] = ip
+	}
+
+	return n.runTC(ctx, containerID, netemScript(fault.netemArgs(), targetIPs))
+}
+
+// Clear removes any fault applied to each of the given containers.
+func (n *NetworkFaultInjector) Clear(ctx context.Context, containerIDs ...string) error {
+	for _, id := range containerIDs {
+		if err := n.runTC(ctx, id, netemScript(nil, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Partition drops all traffic in both directions between every container in a and every container in b.
+// Traffic within each group is unaffected.
+// Partition replaces any fault previously applied to the given containers; use Clear to heal the partition.
+func (n *NetworkFaultInjector) Partition(ctx context.Context, a, b []string) error {
+	for _, id := range a {
+		if err := n.Apply(ctx, id, Partition(b...)); err != nil {
+			return err
+		}
+	}
+	for _, id := range b {
+		if err := n.Apply(ctx, id, Partition(a...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containerIP returns the IPv4 address of containerID on the injector's network.
+func (n *NetworkFaultInjector) containerIP(ctx context.Context, containerID string) (string, error) {
+	return ContainerIP(ctx, n.cli, containerID, n.networkID)
+}
+
+// runTC runs script in a helper container sharing the network namespace of containerID.
+func (n *NetworkFaultInjector) runTC(ctx context.Context, containerID, script string) error {
+	ip, err := n.containerIP(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := n.cli.ImageInspect(ctx, n.image); err != nil {
+		rc, err := n.cli.ImagePull(ctx, n.image, dockerimagetypes.PullOptions{})
+		if err != nil {
+			return fmt.Errorf("pull image %s: %w", n.image, err)
+		}
+		_, _ = io.Copy(io.Discard, rc)
+		_ = rc.Close()
+	}
+
+	containerName := fmt.Sprintf("%s-netem-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+	cc, err := n.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: n.image,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				script,
+				"_", // Meaningless arg0 for sh -c with positional args.
+				ip,
+			},
+
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: n.testName},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + containerID),
+			CapAdd:      []string{"NET_ADMIN"},
+		},
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating netem container: %w", err)
+	}
+
+	defer func() {
+		if err := n.cli.ContainerRemove(ctx, cc.ID, container.RemoveOptions{
+			Force: true,
+		}); err != nil {
+			n.log.Warn("Network fault: Failed to remove container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := n.cli.ContainerStart(ctx, cc.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("starting netem container: %w", err)
+	}
+
+	waitCh, errCh := n.cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for netem container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("applying network fault to container %s exited %d: %s", containerID, res.StatusCode, n.logs(ctx, cc.ID))
+		}
+	}
+
+	return nil
+}
+
+// logs returns the combined output of a stopped container, for error messages.
+func (n *NetworkFaultInjector) logs(ctx context.Context, containerID string) string {
+	rc, err := n.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	})
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = rc.Close() }()
+
+	var buf bytes.Buffer
+	_, _ = stdcopy.StdCopy(&buf, &buf, rc)
+	return strings.TrimSpace(buf.String())
+}
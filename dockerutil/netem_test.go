@@ -0,0 +1,55 @@
+package dockerutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkFault_Validate(t *testing.T) {
+	require.NoError(t, NetworkFault{Latency: time.Second, Jitter: time.Millisecond}.Validate())
+	require.NoError(t, Partition("abc").Validate())
+
+	require.Error(t, NetworkFault{}.Validate())
+	require.Error(t, NetworkFault{Jitter: time.Millisecond}.Validate())
+	require.Error(t, NetworkFault{LossPercent: 101}.Validate())
+	require.Error(t, NetworkFault{Latency: -time.Second}.Validate())
+}
+
+func TestNetworkFault_NetemArgs(t *testing.T) {
+	f := NetworkFault{
+		Latency:     150 * time.Millisecond,
+		Jitter:      10 * time.Millisecond,
+		LossPercent: 2.5,
+		RateKbit:    512,
+	}
+	require.Equal(t,
+		[]string{"delay", "150000us", "10000us", "loss", "2.5%", "rate", "512kbit"},
+		f.netemArgs(),
+	)
+
+	require.Equal(t, []string{"loss", "100%"}, Partition().netemArgs())
+}
+
+func TestNetemScript(t *testing.T) {
+	t.Run("clear", func(t *testing.T) {
+		script := netemScript(nil, nil)
+		require.Contains(t, script, `tc qdisc del dev "$dev" root`)
+		require.NotContains(t, script, "tc qdisc add")
+	})
+
+	t.Run("all traffic", func(t *testing.T) {
+		script := netemScript([]string{"delay", "100000us"}, nil)
+		require.True(t, strings.HasSuffix(script, `tc qdisc add dev "$dev" root netem delay 100000us`))
+		require.NotContains(t, script, "tc filter")
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		script := netemScript([]string{"loss", "100%"}, []string{"172.18.0.2", "172.18.0.3"})
+		require.Contains(t, script, `tc qdisc add dev "$dev" parent 1:4 handle 40: netem loss 100%`)
+		require.Contains(t, script, "match ip dst 172.18.0.2/32 flowid 1:4")
+		require.Contains(t, script, "match ip dst 172.18.0.3/32 flowid 1:4")
+	})
+}
@@ -0,0 +1,28 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/moby/moby/client"
+)
+
+// ContainerIP returns the IPv4 address of containerID on the network networkID.
+func ContainerIP(ctx context.Context, cli *client.Client, containerID, networkID string) (string, error) {
+	c, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("inspecting container %s: %w", containerID, err)
+	}
+	if c.NetworkSettings != nil {
+		for _, ep := range c.NetworkSettings.Networks {
+			if ep == nil || ep.NetworkID != networkID {
+				continue
+			}
+			if ip := net.ParseIP(ep.IPAddress); ip != nil && ip.To4() != nil {
+				return ip.String(), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("container %s has no IPv4 address on network %s", containerID, networkID)
+}
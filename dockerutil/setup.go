@@ -47,6 +47,10 @@ const (
 
 	// NodeOwnerLabel indicates the logical node owning a particular object (probably a volume).
 	NodeOwnerLabel = LabelPrefix + "node-owner"
+
+	// SnapshotLabel indicates the named snapshot that a volume belongs to.
+	// Snapshot volumes do not carry the CleanupLabel, so they outlive the test that created them.
+	SnapshotLabel = LabelPrefix + "snapshot"
 )
 
 // KeepVolumesOnFailure determines whether volumes associated with a test
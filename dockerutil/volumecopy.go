@@ -0,0 +1,160 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+)
+
+// VolumeCopyOptions contain the configuration for the CopyVolume function.
+type VolumeCopyOptions struct {
+	Log *zap.Logger
+
+	Client *client.Client
+
+	SrcVolume string
+	DstVolume string
+	TestName  string
+
+	// NoClobber keeps the files already in DstVolume, and only copies the files of SrcVolume that DstVolume lacks,
+	// rather than replacing the contents of DstVolume.
+	NoClobber bool
+}
+
+// CopyVolume replaces the contents of DstVolume with the contents of SrcVolume,
+// preserving file ownership and permissions.
+// With NoClobber set, the existing contents of DstVolume are kept instead.
+//
+// Neither volume should be mounted by a running container while the copy is in progress.
+func CopyVolume(ctx context.Context, opts VolumeCopyOptions) error {
+	if err := EnsureBusybox(ctx, opts.Client); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-copyvolume-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	const (
+		srcMountPath = "/mnt/src"
+		dstMountPath = "/mnt/dst"
+	)
+
+	// Clear the destination first so that files absent from the source do not survive the copy.
+	script := `find "$2" -mindepth 1 -delete && cp -a "$1/." "$2/"`
+	if opts.NoClobber {
+		script = `cp -an "$1/." "$2/"`
+	}
+	cc, err := opts.Client.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				script,
+				"_", // Meaningless arg0 for sh -c with positional args.
+				srcMountPath,
+				dstMountPath,
+			},
+
+			// Root user so we can read every file and preserve ownership.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: opts.TestName},
+		},
+		&container.HostConfig{
+			Binds: []string{
+				opts.SrcVolume + ":" + srcMountPath + ":ro",
+				opts.DstVolume + ":" + dstMountPath,
+			},
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if err := opts.Client.ContainerRemove(ctx, cc.ID, container.RemoveOptions{
+			Force: true,
+		}); err != nil {
+			opts.Log.Warn("Copy volume: Failed to remove container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := opts.Client.ContainerStart(ctx, cc.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("starting copy-volume container: %w", err)
+	}
+
+	waitCh, errCh := opts.Client.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return fmt.Errorf("waiting for copy-volume container: %w", err)
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for copy-volume container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("copying volume %s to %s exited %d", opts.SrcVolume, opts.DstVolume, res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotVolume creates a new volume labelled with the given snapshot name
+// and copies the contents of srcVolume into it, returning the new volume's name.
+//
+// Snapshot volumes are not removed by DockerCleanup; use RemoveSnapshotVolumes once the snapshot is no longer needed.
+func SnapshotVolume(ctx context.Context, log *zap.Logger, cli *client.Client, testName, snapshotName, srcVolume string) (string, error) {
+	v, err := cli.VolumeCreate(ctx, volumetypes.CreateOptions{
+		Labels: map[string]string{
+			SnapshotLabel: snapshotName,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating snapshot volume: %w", err)
+	}
+
+	if err := CopyVolume(ctx, VolumeCopyOptions{
+		Log:    log,
+		Client: cli,
+
+		SrcVolume: srcVolume,
+		DstVolume: v.Name,
+		TestName:  testName,
+	}); err != nil {
+		return "", fmt.Errorf("copying volume %s into snapshot %s: %w", srcVolume, snapshotName, err)
+	}
+
+	return v.Name, nil
+}
+
+// RemoveSnapshotVolumes removes every volume belonging to the named snapshot.
+func RemoveSnapshotVolumes(ctx context.Context, cli *client.Client, snapshotName string) error {
+	res, err := cli.VolumeList(ctx, volumetypes.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", SnapshotLabel+"="+snapshotName)),
+	})
+	if err != nil {
+		return fmt.Errorf("listing snapshot volumes: %w", err)
+	}
+
+	for _, v := range res.Volumes {
+		if err := cli.VolumeRemove(ctx, v.Name, true); err != nil && !cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("removing snapshot volume %s: %w", v.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
 	"github.com/cosmos/interchaintest/v11"
 	"github.com/cosmos/interchaintest/v11/chain/cosmos"
 	"github.com/cosmos/interchaintest/v11/ibc"
@@ -65,3 +67,55 @@ func TestBadInputParams(t *testing.T) {
 		_ = ic.Close()
 	})
 }
+
+func TestBadInputParamsValidated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	// The edited gov genesis is validated when the chain starts, before its nodes are started.
+	modifyGenesis := cosmos.ModifyGenesisState(func(b *cosmos.GenesisBuilder) error {
+		return cosmos.EditModuleGenesis(b, "gov", func(g *govv1.GenesisState) error {
+			g.Params.Quorum = "2"
+			return nil
+		})
+	})
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "juno",
+			ChainName: "juno",
+			Version:   "v19.0.0-alpha.3",
+			ChainConfig: ibc.ChainConfig{
+				Denom:         "ujuno",
+				Bech32Prefix:  "juno",
+				CoinType:      "118",
+				ModifyGenesis: modifyGenesis,
+				GasPrices:     "0ujuno",
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chains[0])
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	err = ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	})
+	require.ErrorContains(t, err, "invalid gov genesis")
+
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+}
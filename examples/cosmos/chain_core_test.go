@@ -228,8 +228,9 @@ func testAuthz(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, use
 
 	node := chain.GetNode()
 
-	txRes, _ := node.AuthzGrant(ctx, users[0], grantee, "generic", "--msg-type", "/cosmos.bank.v1beta1.MsgSend")
-	require.EqualValues(t, 0, txRes.Code)
+	txRes, err := node.AuthzGrant(ctx, users[0], grantee, "generic", "--msg-type", "/cosmos.bank.v1beta1.MsgSend")
+	require.NoError(t, err)
+	require.EqualValues(t, 0, txRes.TxResponse.Code)
 
 	grants, err := chain.AuthzQueryGrants(ctx, granter, grantee, "")
 	require.NoError(t, err)
@@ -271,7 +272,7 @@ func testAuthz(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, use
 
 	resp, err := node.AuthzExec(ctx, users[1], nestedCmd)
 	require.NoError(t, err)
-	require.EqualValues(t, 0, resp.Code)
+	require.EqualValues(t, 0, resp.TxResponse.Code)
 
 	balanceAfter, err := chain.GetBalance(ctx, granter, chain.Config().Denom)
 	require.NoError(t, err)
@@ -295,7 +296,7 @@ func testBank(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, user
 	require.NoError(t, err)
 
 	// send multiple
-	err = chain.GetNode().BankMultiSend(ctx, users[0].KeyName(), []string{user1, user2}, sdkmath.NewInt(sendAmt), chain.Config().Denom)
+	_, err = chain.GetNode().BankMultiSend(ctx, users[0].KeyName(), []string{user1, user2}, sdkmath.NewInt(sendAmt), chain.Config().Denom)
 	require.NoError(t, err)
 
 	// == balances ==
@@ -403,14 +404,14 @@ func testDistribution(ctx context.Context, t *testing.T, chain *cosmos.CosmosCha
 	})
 
 	t.Run("withdraw-all-rewards", func(t *testing.T) {
-		err = node.StakingDelegate(ctx, users[2].KeyName(), valAddr, fmt.Sprintf("%d%s", uint64(100*math.Pow10(6)), chain.Config().Denom))
+		_, err = node.StakingDelegate(ctx, users[2].KeyName(), valAddr, fmt.Sprintf("%d%s", uint64(100*math.Pow10(6)), chain.Config().Denom))
 		require.NoError(err)
 
 		before, err := chain.BankQueryBalance(ctx, acc.String(), chain.Config().Denom)
 		require.NoError(err)
 		t.Logf("before: %+v\n", before)
 
-		err = node.DistributionWithdrawAllRewards(ctx, users[2].KeyName())
+		_, err = node.DistributionWithdrawAllRewards(ctx, users[2].KeyName())
 		require.NoError(err)
 
 		after, err := chain.BankQueryBalance(ctx, acc.String(), chain.Config().Denom)
@@ -426,10 +427,10 @@ func testDistribution(ctx context.Context, t *testing.T, chain *cosmos.CosmosCha
 
 		amount := uint64(9_000 * math.Pow10(6))
 
-		err = node.DistributionFundCommunityPool(ctx, users[0].KeyName(), fmt.Sprintf("%d%s", amount, chain.Config().Denom))
+		_, err = node.DistributionFundCommunityPool(ctx, users[0].KeyName(), fmt.Sprintf("%d%s", amount, chain.Config().Denom))
 		require.NoError(err)
 
-		err = node.DistributionFundValidatorRewardsPool(ctx, users[0].KeyName(), valAddr, fmt.Sprintf("%d%s", uint64(100*math.Pow10(6)), chain.Config().Denom))
+		_, err = node.DistributionFundValidatorRewardsPool(ctx, users[0].KeyName(), valAddr, fmt.Sprintf("%d%s", uint64(100*math.Pow10(6)), chain.Config().Denom))
 		require.NoError(err)
 
 		bal2, err := chain.BankQueryBalance(ctx, acc.String(), chain.Config().Denom)
@@ -445,7 +446,7 @@ func testDistribution(ctx context.Context, t *testing.T, chain *cosmos.CosmosCha
 	})
 
 	t.Run("set-custiom-withdraw-address", func(t *testing.T) {
-		err = node.DistributionSetWithdrawAddr(ctx, users[0].KeyName(), newWithdrawAddr)
+		_, err = node.DistributionSetWithdrawAddr(ctx, users[0].KeyName(), newWithdrawAddr)
 		require.NoError(err)
 
 		withdrawAddr, err := chain.DistributionQueryDelegatorWithdrawAddress(ctx, users[0].FormattedAddress())
@@ -481,7 +482,7 @@ func testFeeGrant(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain,
 		granter := users[0]
 		grantee := users[1]
 
-		err = node.FeeGrant(ctx, granter.KeyName(), grantee.FormattedAddress(), fmt.Sprintf("%d%s", 1000, chain.Config().Denom), []string{"/cosmos.bank.v1beta1.MsgSend"}, time.Now().Add(time.Hour*24*365))
+		_, err = node.FeeGrant(ctx, granter.KeyName(), grantee.FormattedAddress(), fmt.Sprintf("%d%s", 1000, chain.Config().Denom), []string{"/cosmos.bank.v1beta1.MsgSend"}, time.Now().Add(time.Hour*24*365))
 		require.NoError(t, err)
 
 		g, err := chain.FeeGrantQueryAllowance(ctx, granter.FormattedAddress(), grantee.FormattedAddress())
@@ -507,7 +508,7 @@ func testFeeGrant(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain,
 		granter2 := users[2]
 		grantee2 := users[3]
 
-		err = node.FeeGrant(ctx, granter2.KeyName(), grantee2.FormattedAddress(), fmt.Sprintf("%d%s", 100_000, denom), nil, time.Unix(0, 0))
+		_, err = node.FeeGrant(ctx, granter2.KeyName(), grantee2.FormattedAddress(), fmt.Sprintf("%d%s", 100_000, denom), nil, time.Unix(0, 0))
 		require.NoError(t, err)
 
 		bal, err := chain.BankQueryBalance(ctx, granter2.FormattedAddress(), denom)
@@ -556,7 +557,7 @@ func testGov(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, users
 	require.Equal(t, proposal.Title, title)
 
 	// vote on the proposal
-	err = node.VoteOnProposal(ctx, users[0].KeyName(), 1, "yes")
+	_, err = node.VoteOnProposal(ctx, users[0].KeyName(), 1, "yes")
 	require.NoError(t, err)
 
 	v, err := chain.GovQueryVote(ctx, 1, users[0].FormattedAddress())
@@ -657,7 +658,7 @@ func testStaking(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, u
 	t.Run("delegations", func(t *testing.T) {
 		node := chain.GetNode()
 
-		err := node.StakingDelegate(ctx, users[0].KeyName(), val, "1000"+chain.Config().Denom)
+		_, err := node.StakingDelegate(ctx, users[0].KeyName(), val, "1000"+chain.Config().Denom)
 		require.NoError(t, err)
 
 		dels, err := chain.StakingQueryDelegations(ctx, users[0].FormattedAddress())
@@ -672,7 +673,7 @@ func testStaking(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, u
 		require.True(t, found)
 
 		// unbond
-		err = node.StakingUnbond(ctx, users[0].KeyName(), val, "25"+chain.Config().Denom)
+		_, err = node.StakingUnbond(ctx, users[0].KeyName(), val, "25"+chain.Config().Denom)
 		require.NoError(t, err)
 
 		unbonding, err := chain.StakingQueryUnbondingDelegation(ctx, user, val)
@@ -694,7 +695,7 @@ func testStaking(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, u
 		require.Equal(t, user, unbondingsFrom[0].DelegatorAddress)
 
 		// StakingCancelUnbond
-		err = node.StakingCancelUnbond(ctx, user, val, "25"+chain.Config().Denom, height)
+		_, err = node.StakingCancelUnbond(ctx, user, val, "25"+chain.Config().Denom, height)
 		require.NoError(t, err)
 
 		// ensure unbonding delegation is gone
@@ -716,7 +717,7 @@ func testVesting(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, a
 	t.Run("normal vesting account", func(t *testing.T) {
 		acc = "cosmos1w8arfu23uygwse72ym3krk2nhntlgdfv7zzuxz"
 
-		err = node.VestingCreateAccount(ctx, admin.KeyName(), acc, "111token", endTime)
+		_, err = node.VestingCreateAccount(ctx, admin.KeyName(), acc, "111token", endTime)
 		require.NoError(t, err)
 
 		res, err := chain.AuthQueryAccount(ctx, acc)
@@ -728,7 +729,7 @@ func testVesting(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, a
 	t.Run("perm locked account", func(t *testing.T) {
 		acc = "cosmos135e3r3l5333094zd37kw8s7htn7087pruvx7ke"
 
-		err = node.VestingCreatePermanentLockedAccount(ctx, admin.KeyName(), acc, "112token")
+		_, err = node.VestingCreatePermanentLockedAccount(ctx, admin.KeyName(), acc, "112token")
 		require.NoError(t, err)
 
 		res, err := chain.AuthQueryAccount(ctx, acc)
@@ -740,7 +741,7 @@ func testVesting(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, a
 	t.Run("periodic account", func(t *testing.T) {
 		acc = "cosmos1hkar47a0ysml3fhw2jgyrnrvwq9z8tk7zpw3jz"
 
-		err = node.VestingCreatePeriodicAccount(ctx, admin.KeyName(), acc, vestingcli.VestingData{
+		_, err = node.VestingCreatePeriodicAccount(ctx, admin.KeyName(), acc, vestingcli.VestingData{
 			StartTime: currentUnixSeconds,
 			Periods: []vestingcli.InputPeriod{
 				{
@@ -2,12 +2,17 @@ package cosmos_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"cosmossdk.io/math"
+
+	transfertypes "github.com/cosmos/ibc-go/v11/modules/apps/transfer/types"
+
 	"github.com/cosmos/interchaintest/v11"
 	"github.com/cosmos/interchaintest/v11/chain/cosmos"
 	"github.com/cosmos/interchaintest/v11/ibc"
@@ -76,3 +81,72 @@ func HaltChainAndExportGenesis(ctx context.Context, t *testing.T, chain *cosmos.
 
 	require.Greater(t, height, haltHeight, "height did not increment after halt")
 }
+
+func TestRestartFromExportChainID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	chains := interchaintest.CreateChainsWithChainSpecs(t, []*interchaintest.ChainSpec{
+		{Name: testutil.TestSimd, Version: testutil.SimdVersion, ChainName: "fork", NumValidators: &numValsOne, NumFullNodes: &numFullNodesZero},
+		{Name: testutil.TestSimd, Version: testutil.SimdVersion, ChainName: "counterparty", NumValidators: &numValsOne, NumFullNodes: &numFullNodesZero},
+	})
+	chain, counterparty := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	const pathName = "path"
+	ctx, _, r, _, eRep, _, _ := interchaintest.BuildInitialChainWithRelayer(t, chains, false, ibc.CosmosRly, nil, []interchaintest.InterchainLink{
+		{Chain1: chain, Chain2: counterparty, Path: pathName},
+	}, false)
+
+	prevChainID := chain.Config().ChainID
+	prevChannel, err := ibc.GetTransferChannel(ctx, r, eRep, prevChainID, counterparty.Config().ChainID)
+	require.NoError(t, err)
+
+	haltHeight, err := chain.Height(ctx)
+	require.NoError(t, err)
+	haltHeight += 3
+
+	// The counterparty's client of the chain cannot follow the new chain ID, so the chains are linked again on a new path.
+	const forkPathName = "fork-path"
+	newChainID := prevChainID + "-fork"
+	err = chain.RestartFromExport(ctx, haltHeight, cosmos.ModifyGenesis([]cosmos.GenesisKV{
+		cosmos.NewGenesisKV("chain_id", newChainID),
+	}), cosmos.RelinkOptions{
+		Relayer:      r,
+		Reporter:     eRep,
+		Counterparty: counterparty,
+		Path:         forkPathName,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newChainID, chain.Config().ChainID)
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltHeight, "height did not increment after restart")
+
+	// The exported state keeps the previous channel, next to the channel opened on the new path.
+	channels, err := r.GetChannels(ctx, eRep, newChainID)
+	require.NoError(t, err)
+	i := slices.IndexFunc(channels, func(c ibc.ChannelOutput) bool {
+		return c.PortID == "transfer" && c.ChannelID != prevChannel.ChannelID
+	})
+	require.GreaterOrEqual(t, i, 0, "no transfer channel opened after the restart")
+	channel := channels[i]
+
+	// Transfers from the restarted chain are relayed over the new channel.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), math.NewInt(10_000_000), chain, counterparty)
+	sender, receiver := users[0], users[1]
+	amount := math.NewInt(1_000)
+	_, err = chain.SendIBCTransfer(ctx, channel.ChannelID, sender.KeyName(), ibc.WalletAmount{
+		Address: receiver.FormattedAddress(),
+		Denom:   chain.Config().Denom,
+		Amount:  amount,
+	}, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.NoError(t, r.Flush(ctx, eRep, forkPathName, channel.ChannelID))
+
+	ibcDenom := transfertypes.NewDenom(chain.Config().Denom, transfertypes.NewHop(channel.Counterparty.PortID, channel.Counterparty.ChannelID)).IBCDenom()
+	balance, err := counterparty.GetBalance(ctx, receiver.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.True(t, balance.Equal(amount))
+}
@@ -370,9 +370,12 @@ func testTokenFactory(ctx context.Context, t *testing.T, chain *cosmos.CosmosCha
 	node := chain.GetNode()
 
 	subDenom := "ictest"
-	tfDenom, _, err := node.TokenFactoryCreateDenom(ctx, user, subDenom, 2500000)
+	tfDenom, res, err := node.TokenFactoryCreateDenom(ctx, user, subDenom, 2500000)
 	require.NoError(t, err)
 	require.Equal(t, tfDenom, "factory/"+user.FormattedAddress()+"/"+subDenom)
+	created, ok := res.MsgResponse()
+	require.True(t, ok)
+	require.Equal(t, tfDenom, created.NewTokenDenom)
 
 	// modify metadata
 	stdout, err := node.TokenFactoryMetadata(ctx, user.KeyName(), tfDenom, "SYMBOL", "description here", 6)
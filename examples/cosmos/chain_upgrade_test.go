@@ -0,0 +1,142 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"cosmossdk.io/math"
+
+	interchaintest "github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// gaiaUpgradeGenesis shortens the gov periods and disables the fee market, so that proposals pass quickly.
+var gaiaUpgradeGenesis = []cosmos.GenesisKV{
+	cosmos.NewGenesisKV("app_state.gov.params.voting_period", votingPeriod),
+	cosmos.NewGenesisKV("app_state.gov.params.max_deposit_period", maxDepositPeriod),
+	cosmos.NewGenesisKV("app_state.gov.params.min_deposit.0.denom", "uatom"),
+	cosmos.NewGenesisKV("app_state.feemarket.params.enabled", false),
+	cosmos.NewGenesisKV("app_state.feemarket.params.min_base_gas_price", "0.001"),
+	cosmos.NewGenesisKV("app_state.feemarket.params.max_block_utilization", "50000000"),
+	cosmos.NewGenesisKV("app_state.feemarket.state.base_gas_price", "0.001"),
+}
+
+// buildGaia builds and starts a gaia chain of version, with numVals validators and numFullNodes full nodes.
+func buildGaia(t *testing.T, version string, numVals, numFullNodes int) (context.Context, *cosmos.CosmosChain) {
+	t.Helper()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: version,
+			ChainConfig: ibc.ChainConfig{
+				GasPrices:     "0.001uatom",
+				ModifyGenesis: cosmos.ModifyGenesis(gaiaUpgradeGenesis),
+			},
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	return ctx, chain
+}
+
+func TestGaiaUpgradeViaGovernance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx, chain := buildGaia(t, "v24.0.0", 1, 1)
+	client := chain.GetNode().DockerClient
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), math.NewInt(10_000_000_000), chain, chain)
+
+	upgradeImage := ibc.DockerImage{Repository: "ghcr.io/cosmos/gaia", Version: "v25.1.0"}
+	haltHeight, err := chain.UpgradeViaGovernance(ctx, client, users[0].KeyName(), cosmos.GovUpgradeOptions{
+		Name:            "v25.1.0",
+		Image:           upgradeImage,
+		Deposit:         "500000000" + chain.Config().Denom,
+		HaltHeightDelta: haltHeightDelta,
+	})
+	require.NoError(t, err)
+
+	// Every node runs the new image, and the chain keeps producing blocks past the upgrade height.
+	for _, n := range chain.Nodes() {
+		require.Equal(t, upgradeImage.Version, n.Image.Version)
+	}
+	require.NoError(t, testutil.WaitForBlocks(ctx, blocksAfterUpgrade, chain))
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltHeight)
+
+	// Transactions succeed on the upgraded chain.
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: users[1].FormattedAddress(),
+		Denom:   chain.Config().Denom,
+		Amount:  math.NewInt(1_000),
+	}))
+}
+
+func TestGaiaRollingUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// With four validators, the chain keeps its quorum while any one of them restarts.
+	ctx, chain := buildGaia(t, "v25.1.0", 4, 1)
+	client := chain.GetNode().DockerClient
+
+	// A state-compatible patch release would be rolled out the same way. Restarting onto the same
+	// release keeps the example independent of which patch releases exist.
+	image := ibc.DockerImage{Repository: "ghcr.io/cosmos/gaia", Version: "v25.1.0"}
+
+	// Upgrading a single validator leaves the chain running a mix of nodes.
+	require.NoError(t, chain.UpgradeNodes(ctx, client, image, 0, chain.Validators[0]))
+
+	// Then upgrade the remaining validators one at a time, followed by the full node.
+	groups := []cosmos.ChainNodes{}
+	for _, v := range chain.Validators[1:] {
+		groups = append(groups, cosmos.ChainNodes{v})
+	}
+	require.NoError(t, chain.RollingUpgrade(ctx, client, cosmos.RollingUpgradeOptions{
+		Image:  image,
+		Groups: append(groups, chain.FullNodes),
+	}))
+	for _, n := range chain.Nodes() {
+		require.Equal(t, image.Repository, n.Image.Repository)
+	}
+
+	// The chain never halted, and every node is in sync.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3, chain))
+	heighters := make([]testutil.ChainHeighter, 0, len(chain.Nodes()))
+	for _, n := range chain.Nodes() {
+		heighters = append(heighters, n)
+	}
+	require.NoError(t, testutil.WaitForInSync(ctx, chain, heighters...))
+}
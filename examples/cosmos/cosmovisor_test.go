@@ -0,0 +1,113 @@
+package cosmos_test
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"cosmossdk.io/math"
+
+	interchaintest "github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// cosmovisorImageEnv names the environment variable holding the repository:tag of an image with a cosmovisor
+// binary on its PATH, as the gaia images do not ship cosmovisor.
+const cosmovisorImageEnv = "COSMOVISOR_IMAGE"
+
+func TestGaiaCosmovisorUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	ref := os.Getenv(cosmovisorImageEnv)
+	if ref == "" {
+		t.Skipf("%s is not set", cosmovisorImageEnv)
+	}
+	repository, version, _ := strings.Cut(ref, ":")
+
+	t.Parallel()
+
+	const upgradeName = "v25.1.0"
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: "v24.0.0",
+			ChainConfig: ibc.ChainConfig{
+				GasPrices:     "0.001uatom",
+				ModifyGenesis: cosmos.ModifyGenesis(gaiaUpgradeGenesis),
+				Cosmovisor: &ibc.CosmovisorConfig{
+					Image: ibc.DockerImage{Repository: repository, Version: version},
+					Upgrades: map[string]ibc.DockerImage{
+						upgradeName: {Repository: "ghcr.io/cosmos/gaia", Version: "v25.1.0"},
+					},
+					Env: []string{"DAEMON_POLL_INTERVAL=1s"},
+				},
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	ic := interchaintest.NewInterchain().AddChain(chain)
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), math.NewInt(10_000_000_000), chain, chain)
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	haltHeight := height + haltHeightDelta
+
+	tx, err := chain.UpgradeProposal(ctx, users[0].KeyName(), cosmos.SoftwareUpgradeProposal{
+		Deposit:     "500000000" + chain.Config().Denom,
+		Title:       "Upgrade to " + upgradeName,
+		Name:        upgradeName,
+		Description: "Upgrade to " + upgradeName + " under cosmovisor",
+		Height:      haltHeight,
+	})
+	require.NoError(t, err)
+	proposalID, err := strconv.ParseUint(tx.ProposalID, 10, 64)
+	require.NoError(t, err)
+	require.NoError(t, chain.VoteOnProposalAllValidators(ctx, proposalID, cosmos.ProposalVoteYes))
+
+	// The node is never restarted by the test: cosmovisor switches to the upgrade binary at the halt height,
+	// and the chain keeps producing blocks past it.
+	require.Eventually(t, func() bool {
+		h, err := chain.Height(ctx)
+		return err == nil && h > haltHeight+1
+	}, 3*time.Minute, time.Second)
+	require.NoError(t, testutil.WaitForBlocks(ctx, blocksAfterUpgrade, chain))
+
+	// The node image is unchanged, as the upgrade was applied inside the container.
+	require.Equal(t, "v24.0.0", chain.GetNode().Image.Version)
+
+	// Transactions succeed on the upgraded chain.
+	require.NoError(t, chain.SendFunds(ctx, users[0].KeyName(), ibc.WalletAmount{
+		Address: users[1].FormattedAddress(),
+		Denom:   chain.Config().Denom,
+		Amount:  math.NewInt(1_000),
+	}))
+}
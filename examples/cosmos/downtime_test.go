@@ -0,0 +1,80 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestValidatorDowntime takes a validator offline until it is jailed for downtime, then brings it back.
+func TestValidatorDowntime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// A short signing window jails the validator after a few missed blocks, and a short jail lets it unjail soon after.
+	slashingGenesis := []cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.slashing.params.signed_blocks_window", "10"),
+		cosmos.NewGenesisKV("app_state.slashing.params.min_signed_per_window", "0.500000000000000000"),
+		cosmos.NewGenesisKV("app_state.slashing.params.downtime_jail_duration", "10s"),
+	}
+
+	// With four validators of equal power, the other three keep producing blocks while one is offline.
+	const numDowntimeVals = 4
+	chains := interchaintest.CreateChainWithConfig(t, numDowntimeVals, numFullNodesZero, testutil.TestSimd, testutil.SimdVersion, ibc.ChainConfig{
+		ModifyGenesis: cosmos.ModifyGenesis(slashingGenesis),
+	})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	val := chain.Validators[numDowntimeVals-1]
+	report, err := chain.InduceDowntime(ctx, val, 2*time.Minute)
+	require.NoError(t, err)
+	require.True(t, report.Jailed, "validator was not jailed after %d blocks", len(report.Snapshots))
+	require.Greater(t, report.JailedHeight, report.StoppedHeight)
+	require.GreaterOrEqual(t, report.MaxMissedBlocks(), int64(5))
+
+	// Snapshots are taken block by block from the block after the node was stopped, each at its own height.
+	require.Equal(t, report.StoppedHeight+1, report.Snapshots[0].Height)
+	for i := 1; i < len(report.Snapshots); i++ {
+		require.Equal(t, report.Snapshots[i-1].Height+1, report.Snapshots[i].Height)
+	}
+	last := report.Last()
+	require.True(t, last.Jailed)
+	require.Equal(t, report.JailedHeight, last.Height)
+	require.True(t, last.Info.JailedUntil.After(last.BlockTime))
+
+	recovered, err := chain.RecoverFromDowntime(ctx, val)
+	require.NoError(t, err)
+	require.False(t, recovered.Jailed)
+	require.Equal(t, report.ConsAddress, recovered.Info.Address)
+	require.False(t, recovered.Info.Tombstoned)
+
+	bonded, err := chain.StakingQueryValidators(ctx, "BOND_STATUS_BONDED")
+	require.NoError(t, err)
+	require.Len(t, bonded, numDowntimeVals)
+}
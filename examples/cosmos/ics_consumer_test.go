@@ -0,0 +1,140 @@
+package cosmos_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"cosmossdk.io/math"
+
+	interchaintest "github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// icsConsumerConfig is the chain config of the consumer app built by the interchain-security repository.
+var icsConsumerConfig = ibc.ChainConfig{
+	Type:    "cosmos",
+	ChainID: "consumer-1",
+	Images: []ibc.DockerImage{
+		{Repository: "ghcr.io/cosmos/interchain-security", Version: "v7.0.1", UIDGID: "1025:1025"},
+	},
+	Bin:            "interchain-security-cd",
+	Bech32Prefix:   "consumer",
+	Denom:          "stake",
+	GasPrices:      "0.0stake",
+	GasAdjustment:  2.0,
+	TrustingPeriod: "336h",
+}
+
+func TestICSConsumerLaunch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// Ending an epoch every block makes the provider send validator set changes right away.
+	providerGenesis := append([]cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.provider.params.blocks_per_epoch", "1"),
+	}, gaiaUpgradeGenesis...)
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:    "gaia",
+			Version: "v25.1.0",
+			ChainConfig: ibc.ChainConfig{
+				ChainID:       "provider-1",
+				GasPrices:     "0.001uatom",
+				ModifyGenesis: cosmos.ModifyGenesis(providerGenesis),
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+		{
+			Name:          "ics-consumer",
+			ChainConfig:   icsConsumerConfig,
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	provider, consumer := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(t, client, network)
+
+	const ccvPath = "ccv"
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddRelayer(r, "relayer").
+		AddConsumerChain(provider, consumer, interchaintest.ConsumerChainOptions{
+			Relayer: r,
+			Path:    ccvPath,
+			Launch: cosmos.ConsumerLaunchOptions{
+				SpawnTimeDelay: 10 * time.Second,
+			},
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// The provider's validators produce the consumer's blocks, and the CCV channel is open.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, consumer))
+	consumerEnd, _, err := ibc.GetPathEnds(ctx, r, eRep, consumer.Config().ChainID, provider.Config().ChainID, "consumer")
+	require.NoError(t, err)
+
+	consumerPower := func() (int64, error) {
+		res, err := consumer.GetNode().Client.Validators(ctx, nil, nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		if len(res.Validators) != 1 {
+			return 0, fmt.Errorf("expected 1 consumer validator, got %d", len(res.Validators))
+		}
+		return res.Validators[0].VotingPower, nil
+	}
+	powerBefore, err := consumerPower()
+	require.NoError(t, err)
+
+	// Bonding more stake on the provider changes the validator's power there.
+	validators, err := provider.StakingQueryValidators(ctx, "BOND_STATUS_BONDED")
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), math.NewInt(10_000_000_000), provider)
+	_, err = provider.GetNode().StakingDelegate(ctx, users[0].KeyName(), validators[0].OperatorAddress, "1000000000"+provider.Config().Denom)
+	require.NoError(t, err)
+
+	// The provider sends the change to the consumer in a VSC packet, which is applied once relayed.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 2, provider))
+	require.NoError(t, r.Flush(ctx, eRep, ccvPath, consumerEnd.ChannelID))
+
+	validator, err := provider.StakingQueryValidator(ctx, validators[0].OperatorAddress)
+	require.NoError(t, err)
+	providerPower := validator.ConsensusPower(math.NewInt(1_000_000))
+	require.Greater(t, providerPower, powerBefore)
+
+	require.Eventually(t, func() bool {
+		power, err := consumerPower()
+		return err == nil && power == providerPower
+	}, time.Minute, time.Second)
+}
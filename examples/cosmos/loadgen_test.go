@@ -0,0 +1,158 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/loadgen"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+func TestLoadGenerator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	chains := interchaintest.CreateChainWithConfig(t, numValsOne, numFullNodesZero, testutil.TestSimd, testutil.SimdVersion, ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const txs = 200
+	gen, err := loadgen.New(t, ctx, chain, loadgen.Config{
+		TPS:              20,
+		Txs:              txs,
+		Wallets:          5,
+		Msgs:             loadgen.BankSend(sdk.NewCoins(sdk.NewInt64Coin(chain.Config().Denom, 1))),
+		InclusionTimeout: 2 * time.Minute,
+	})
+	require.NoError(t, err)
+	gen.WithLogger(zaptest.NewLogger(t))
+	require.Len(t, gen.Wallets(), 5)
+
+	res, err := gen.Run(ctx)
+	require.NoError(t, err)
+	t.Log(res)
+
+	// A chain this lightly loaded accepts and includes every transaction.
+	require.Equal(t, txs, res.Submitted)
+	require.Zero(t, res.Rejected, res.RejectedByKind)
+	require.Zero(t, res.Skipped)
+	require.Equal(t, txs, res.Included)
+	require.Zero(t, res.Failed)
+	require.NotEmpty(t, res.Blocks)
+	require.Positive(t, res.Latency.P50)
+}
+
+// TestLoadGeneratorCometMock runs the load generator against a chain whose blocks are committed by CometMock
+// at a fixed interval, so that the summary of the run does not depend on consensus timing.
+func TestLoadGeneratorCometMock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	const blockTimeMs = 1000
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "juno",
+			ChainName: "juno",
+			// CometMock requires an SDK version with patch: https://github.com/cosmos/cosmos-sdk/issues/16277.
+			Version: "v19.0.0-alpha.3",
+			ChainConfig: ibc.ChainConfig{
+				Denom:         "ujuno",
+				Bech32Prefix:  "juno",
+				CoinType:      "118",
+				ModifyGenesis: cosmos.ModifyGenesis(sdk47Genesis),
+				CometMock: ibc.CometMockConfig{
+					Image:       ibc.NewDockerImage("ghcr.io/informalsystems/cometmock", "v0.37.x", "1025:1025"),
+					BlockTimeMs: blockTimeMs,
+				},
+				GasPrices: "0ujuno",
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	const txs = 100
+	gen, err := loadgen.New(t, ctx, chain, loadgen.Config{
+		TPS:              10,
+		Txs:              txs,
+		Wallets:          5,
+		Msgs:             loadgen.BankSend(sdk.NewCoins(sdk.NewInt64Coin(chain.Config().Denom, 1))),
+		InclusionTimeout: time.Minute,
+	})
+	require.NoError(t, err)
+	gen.WithLogger(zaptest.NewLogger(t))
+
+	res, err := gen.Run(ctx)
+	require.NoError(t, err)
+	t.Log(res)
+
+	// Every transaction is accepted and included in the blocks committed during the run, and nothing else is.
+	require.Equal(t, txs, res.Submitted)
+	require.Zero(t, res.Rejected, res.RejectedByKind)
+	require.Zero(t, res.Skipped)
+	require.Equal(t, txs, res.Included)
+	require.Zero(t, res.Failed)
+	var blockTxs int
+	for i, b := range res.Blocks {
+		blockTxs += b.NumTxs
+		if i > 0 {
+			require.Equal(t, res.Blocks[i-1].Height+1, b.Height)
+		}
+	}
+	require.Equal(t, txs, blockTxs)
+
+	// Blocks are committed at CometMock's block time.
+	blockTime := blockTimeMs * time.Millisecond
+	require.GreaterOrEqual(t, res.MeanBlockInterval(), blockTime*9/10)
+	require.LessOrEqual(t, res.MeanBlockInterval(), blockTime*3/2)
+}
@@ -0,0 +1,84 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/dockerutil"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestNetworkFaults degrades the network between the validators of a chain, and checks that the chain
+// keeps producing blocks under latency, halts when its validators are split in two halves, and resumes once healed.
+func TestNetworkFaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// With four validators of equal power, neither half of a two-two partition holds more than 2/3 of the voting power.
+	const numFaultVals = 4
+	chains := interchaintest.CreateChainWithConfig(t, numFaultVals, numFullNodesZero, testutil.TestSimd, testutil.SimdVersion, ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	ids := make([]string, numFaultVals)
+	for i, val := range chain.Validators {
+		ids[i] = val.ContainerID()
+	}
+	injector := dockerutil.NewNetworkFaultInjector(zaptest.NewLogger(t), client, network, t.Name())
+
+	// Liveness is kept while a validator's traffic is delayed.
+	require.NoError(t, injector.Apply(ctx, ids[numFaultVals-1], dockerutil.NetworkFault{
+		Latency: 200 * time.Millisecond,
+		Jitter:  50 * time.Millisecond,
+	}))
+	waitCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	require.NoError(t, testutil.WaitForBlocks(waitCtx, 3, chain))
+	cancel()
+	require.NoError(t, injector.Clear(ctx, ids[numFaultVals-1]))
+
+	// Liveness is lost while the validators are partitioned in two halves.
+	// The chain is queried through the first validator, whose RPC stays reachable from the host.
+	require.NoError(t, injector.Partition(ctx, ids[:2], ids[2:]))
+	// A block may still be committed with votes sent before the partition.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 1, chain))
+	haltedHeight, err := chain.Height(ctx)
+	require.NoError(t, err)
+	time.Sleep(15 * time.Second)
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.LessOrEqual(t, height, haltedHeight+1, "chain produced blocks while partitioned")
+
+	// Healing the partition restores liveness.
+	require.NoError(t, injector.Clear(ctx, ids...))
+	waitCtx, cancel = context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	require.NoError(t, testutil.WaitForBlocks(waitCtx, 3, chain))
+	height, err = chain.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltedHeight+1)
+}
@@ -0,0 +1,72 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestStateSync adds a full node which restores the chain's state from a snapshot instead of replaying its blocks.
+func TestStateSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	chains := interchaintest.CreateChainWithConfig(t, numValsOne, numFullNodesZero, testutil.TestSimd, testutil.SimdVersion, ibc.ChainConfig{
+		// The validator takes a snapshot every 10 blocks, which new nodes restore from.
+		Snapshots: &ibc.SnapshotConfig{Interval: 10},
+	})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Commit some state before the snapshot is taken.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), math.NewInt(10_000_000), chain)
+	user := users[0]
+	require.NoError(t, testutil.WaitForBlocks(ctx, 12, chain))
+
+	added, err := chain.AddStateSyncFullNodes(ctx, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	node := added[0]
+
+	// The node has no blocks before the snapshot it restored, and is in sync with the chain.
+	stat, err := node.Client.Status(ctx)
+	require.NoError(t, err)
+	require.Greater(t, stat.SyncInfo.EarliestBlockHeight, int64(1))
+	require.False(t, stat.SyncInfo.CatchingUp)
+
+	// The restored state, queried from the new node, holds the account funded before the snapshot.
+	res, err := banktypes.NewQueryClient(node.GrpcConn).Balance(ctx, &banktypes.QueryBalanceRequest{
+		Address: user.FormattedAddress(),
+		Denom:   chain.Config().Denom,
+	})
+	require.NoError(t, err)
+	require.True(t, res.Balance.Amount.Equal(math.NewInt(10_000_000)))
+}
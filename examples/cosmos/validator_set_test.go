@@ -0,0 +1,110 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestValidatorSetChanges grows and shrinks the validator set of a running chain,
+// then has a validator double sign and checks that it is tombstoned for it.
+func TestValidatorSetChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	chains := interchaintest.CreateChainWithConfig(t, numValsOne, numFullNodesZero, testutil.TestSimd, testutil.SimdVersion, ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Each new validator holds a small share of the voting power, so the chain keeps
+	// producing blocks once the double signing validator is tombstoned.
+	stake := sdk.NewCoin(chain.Config().Denom, math.NewInt(500_000).MulRaw(1_000_000))
+	added, err := chain.AddValidators(ctx, 2, stake)
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	require.Len(t, chain.Validators, 3)
+
+	bonded, err := chain.StakingQueryValidators(ctx, "BOND_STATUS_BONDED")
+	require.NoError(t, err)
+	require.Len(t, bonded, 3)
+
+	require.NoError(t, chain.RemoveValidator(ctx, added[1]))
+	require.Len(t, chain.Validators, 2)
+
+	bonded, err = chain.StakingQueryValidators(ctx, "BOND_STATUS_BONDED")
+	require.NoError(t, err)
+	require.Len(t, bonded, 2)
+
+	// Double sign with the remaining new validator until it is tombstoned.
+	val := added[0]
+	consAddr, err := val.ValidatorConsAddress(ctx)
+	require.NoError(t, err)
+	valAddr, err := val.KeyBech32(ctx, "validator", "val")
+	require.NoError(t, err)
+
+	startHeight, err := chain.Height(ctx)
+	require.NoError(t, err)
+
+	doubleSigner, err := chain.InduceDoubleSign(ctx, val)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		info, err := chain.SlashingQuerySigningInfo(ctx, consAddr)
+		return err == nil && info.Tombstoned
+	}, 3*time.Minute, time.Second)
+	require.NoError(t, doubleSigner.StopContainer(ctx))
+
+	validator, err := chain.StakingQueryValidator(ctx, valAddr)
+	require.NoError(t, err)
+	require.True(t, validator.Jailed)
+	require.True(t, validator.Tokens.LT(stake.Amount), "validator was not slashed: %s", validator.Tokens)
+
+	// The equivocation was committed to the chain as duplicate vote evidence against the validator.
+	endHeight, err := chain.Height(ctx)
+	require.NoError(t, err)
+	var evidence *cmttypes.DuplicateVoteEvidence
+	for h := startHeight; h <= endHeight && evidence == nil; h++ {
+		block, err := chain.GetNode().Client.Block(ctx, &h)
+		require.NoError(t, err)
+		for _, ev := range block.Block.Evidence.Evidence {
+			if dve, ok := ev.(*cmttypes.DuplicateVoteEvidence); ok {
+				evidence = dve
+			}
+		}
+	}
+	require.NotNil(t, evidence, "no duplicate vote evidence committed between heights %d and %d", startHeight, endHeight)
+	evidenceAddr, err := chain.ConsAddressToBech32(sdk.ConsAddress(evidence.VoteA.ValidatorAddress))
+	require.NoError(t, err)
+	require.Equal(t, consAddr, evidenceAddr)
+}
@@ -0,0 +1,105 @@
+package ethereum_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum/foundry"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+)
+
+func TestAnvilCheatcodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	ctx := context.Background()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainName:   "ethereum",
+			Name:        "ethereum",
+			Version:     "latest",
+			ChainConfig: foundry.DefaultEthereumAnvilChainConfig("ethereum"),
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	anvil := chains[0].(*foundry.AnvilChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(anvil)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	user := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	// Stop interval mining, so that the chain only moves when the test mines.
+	require.NoError(t, anvil.SetIntervalMining(ctx, 0))
+
+	height, err := anvil.Height(ctx)
+	require.NoError(t, err)
+	header, err := anvil.RPCClient().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	snapshot, err := anvil.Snapshot(ctx)
+	require.NoError(t, err)
+
+	// Fund the user without a transaction, and fast forward the chain.
+	require.NoError(t, anvil.SetBalance(ctx, user, ethereum.ETHER.BigInt()))
+	balance, err := anvil.GetBalance(ctx, user.Hex(), "")
+	require.NoError(t, err)
+	require.True(t, ethereum.ETHER.Equal(balance))
+
+	_, err = anvil.IncreaseTime(ctx, 24*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, anvil.Mine(ctx, 100, 0))
+
+	newHeight, err := anvil.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, height+100, newHeight)
+	newHeader, err := anvil.RPCClient().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, newHeader.Time-header.Time, uint64((24 * time.Hour).Seconds()))
+
+	// Impersonate the user, and send funds back to the faucet.
+	faucet := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	require.NoError(t, anvil.ImpersonateAccount(ctx, user))
+	_, err = anvil.SendUnsignedTransaction(ctx, user, &faucet, ethereum.GWEI.BigInt(), nil)
+	require.NoError(t, err)
+	require.NoError(t, anvil.StopImpersonatingAccount(ctx, user))
+
+	// Reverting the snapshot restores the balance, height and head block.
+	require.NoError(t, anvil.Revert(ctx, snapshot))
+	balance, err = anvil.GetBalance(ctx, user.Hex(), "")
+	require.NoError(t, err)
+	require.True(t, balance.IsZero())
+	revertedHeight, err := anvil.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, height, revertedHeight)
+	revertedHeader, err := anvil.RPCClient().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), revertedHeader.Hash())
+
+	require.Error(t, anvil.Revert(ctx, snapshot))
+}
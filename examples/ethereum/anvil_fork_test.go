@@ -0,0 +1,102 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum/foundry"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+)
+
+// startAnvil builds and starts a single anvil chain of cfg.
+func startAnvil(t *testing.T, ctx context.Context, cli *client.Client, network string, cfg ibc.ChainConfig) *foundry.AnvilChain {
+	t.Helper()
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{ChainConfig: cfg},
+	})
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	anvil := chains[0].(*foundry.AnvilChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(anvil)
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           cli,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+	return anvil
+}
+
+func TestAnvilFork(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	// Not parallel: forge writes its artifacts into the shared contracts directory.
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	// Seed the source chain with a contract deployed by a forge script, which deploys a Counter at 42 and increments it.
+	source := startAnvil(t, ctx, client, network, foundry.DefaultEthereumAnvilChainConfig("source"))
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "user", ethereum.ETHER.MulRaw(2), source)[0]
+	deployCounterScript(t, ctx, source, user.KeyName())
+
+	artifact, err := ethereum.LoadArtifact(counterArtifact)
+	require.NoError(t, err)
+	sender := common.BytesToAddress(user.Address())
+	// The Counter is the first contract created by the user.
+	counterAddress := crypto.CreateAddress(sender, 0)
+	requireCount := func(chain *foundry.AnvilChain, want int64) {
+		t.Helper()
+		count, err := chain.BindContract(counterAddress, artifact.ABI).Call(ctx, "count")
+		require.NoError(t, err)
+		require.Equal(t, []any{big.NewInt(want)}, count)
+	}
+	requireCount(source, 43)
+
+	height, err := source.Height(ctx)
+	require.NoError(t, err)
+	state, err := source.ExportState(ctx, height)
+	require.NoError(t, err)
+
+	// Fork the running source chain over the docker network.
+	forked := startAnvil(t, ctx, client, network, foundry.DefaultEthereumAnvilChainConfig("forked",
+		foundry.WithForkChain(source),
+		foundry.WithForkBlockNumber(uint64(height)),
+	))
+	requireCount(forked, 43)
+
+	// Transactions on the fork do not affect the source chain.
+	key, err := source.PrivateKey(ctx, user.KeyName())
+	require.NoError(t, err)
+	_, err = forked.BindContract(counterAddress, artifact.ABI).Transact(ctx, key, "increment")
+	require.NoError(t, err)
+	requireCount(forked, 44)
+	requireCount(source, 43)
+
+	// Start a chain from the dumped state of the source chain, without a state file.
+	loaded := startAnvil(t, ctx, client, network, foundry.DefaultEthereumAnvilChainConfig("loaded",
+		foundry.WithLoadStateJSON(state),
+	))
+	code, err := loaded.RPCClient().CodeAt(ctx, counterAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, artifact.DeployedBytecode, code)
+	requireCount(loaded, 43)
+}
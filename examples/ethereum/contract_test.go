@@ -0,0 +1,96 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum/foundry"
+)
+
+// counterArtifact is the forge artifact of contracts/src/Counter.sol, written when the contracts are compiled by forge.
+const counterArtifact = "contracts/out/Counter.sol/Counter.json"
+
+// deployCounterScript compiles the contracts of the contracts directory and runs their deployment script
+// on anvil, signed by keyName.
+func deployCounterScript(t *testing.T, ctx context.Context, anvil *foundry.AnvilChain, keyName string) {
+	t.Helper()
+
+	stdout, stderr, err := anvil.ForgeScript(ctx, keyName, foundry.ForgeScriptOpts{
+		ContractRootDir:  "contracts",
+		SolidityContract: "script/Counter.s.sol",
+	})
+	require.NoError(t, err, "forge script failed\nstdout: %s\nstderr: %s", stdout, stderr)
+}
+
+// TestContract deploys a forge artifact on anvil, then calls it, transacts with it and filters its events.
+func TestContract(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	// Not parallel: forge writes its artifacts into the shared contracts directory.
+
+	client, network := interchaintest.DockerSetup(t)
+	ctx := context.Background()
+
+	anvil := startAnvil(t, ctx, client, network, foundry.DefaultEthereumAnvilChainConfig("ethereum"))
+	user := interchaintest.GetAndFundTestUsers(t, ctx, "user", ethereum.ETHER.MulRaw(2), anvil)[0]
+
+	// Running the deployment script compiles the contracts into their forge artifacts.
+	deployCounterScript(t, ctx, anvil, user.KeyName())
+	artifact, err := ethereum.LoadArtifact(counterArtifact)
+	require.NoError(t, err)
+
+	key, err := anvil.PrivateKey(ctx, user.KeyName())
+	require.NoError(t, err)
+	sender := common.BytesToAddress(user.Address())
+
+	counter, receipt, err := anvil.DeployContractFromArtifact(ctx, key, artifact, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, counter.Address, receipt.ContractAddress)
+
+	code, err := anvil.RPCClient().CodeAt(ctx, counter.Address, nil)
+	require.NoError(t, err)
+	require.Equal(t, artifact.DeployedBytecode, code)
+
+	count, err := counter.Call(ctx, "count")
+	require.NoError(t, err)
+	require.Equal(t, []any{big.NewInt(5)}, count)
+
+	receipt, err = counter.Transact(ctx, key, "increment")
+	require.NoError(t, err)
+
+	count, err = counter.Call(ctx, "count")
+	require.NoError(t, err)
+	require.Equal(t, []any{big.NewInt(6)}, count)
+
+	events, err := counter.ReceiptEvents(receipt, "Incremented")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, sender, events[0].Fields["by"])
+	require.Equal(t, big.NewInt(6), events[0].Fields["value"])
+
+	// Increment again, and filter both events by their indexed sender.
+	_, err = counter.Transact(ctx, key, "increment")
+	require.NoError(t, err)
+
+	events, err = counter.FilterEvents(ctx, "Incremented", receipt.BlockNumber, nil, []any{sender})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	for i, ev := range events {
+		require.Equal(t, "Incremented", ev.Name)
+		require.Equal(t, sender, ev.Fields["by"])
+		require.Equal(t, big.NewInt(int64(6+i)), ev.Fields["value"])
+	}
+
+	// No event was emitted by another sender.
+	events, err = counter.FilterEvents(ctx, "Incremented", receipt.BlockNumber, nil, []any{common.Address{}})
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+
+import {Counter} from "../src/Counter.sol";
+
+// The cheatcodes used by the script, declared here so that the example needs no forge-std dependency.
+interface Vm {
+    function startBroadcast() external;
+    function stopBroadcast() external;
+}
+
+// CounterScript deploys a Counter starting at 42, and increments it once.
+contract CounterScript {
+    Vm internal constant vm = Vm(address(uint160(uint256(keccak256("hevm cheat code")))));
+
+    function run() external returns (Counter counter) {
+        vm.startBroadcast();
+        counter = new Counter(42);
+        counter.increment();
+        vm.stopBroadcast();
+    }
+}
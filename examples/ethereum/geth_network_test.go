@@ -0,0 +1,207 @@
+package ethereum_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum"
+	"github.com/cosmos/interchaintest/v11/chain/ethereum/geth"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+func TestGethCliqueNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	ctx := context.Background()
+
+	numVals, numFullNodes := 2, 1
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainConfig:   geth.DefaultEthereumGethCliqueChainConfig("ethereum"),
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gethChain := chains[0].(*geth.GethChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gethChain)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.Len(t, gethChain.Validators, numVals)
+	require.Len(t, gethChain.FullNodes, numFullNodes)
+	nodes := append(append(geth.GethNodes{}, gethChain.Validators...), gethChain.FullNodes...)
+	for _, n := range nodes {
+		peers, err := n.PeerCount(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, len(nodes)-1, peers)
+	}
+
+	// The chain's peer address is the enode of node 0, which other nodes can add as a peer.
+	enode, err := gethChain.Validators[0].Enode(ctx)
+	require.NoError(t, err)
+	require.Equal(t, enode, gethChain.GetHostPeerAddress())
+
+	// Both signers take turns producing blocks.
+	require.NoError(t, testutil.WaitForBlocks(ctx, 5, gethChain))
+
+	// Funds sent through node 0 are visible on the full node.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "user", ethereum.ETHER, gethChain)
+	fullNode := gethChain.FullNodes[0]
+	balance, err := fullNode.RPCClient().BalanceAt(ctx, common.HexToAddress(users[0].FormattedAddress()), nil)
+	require.NoError(t, err)
+	require.Equal(t, ethereum.ETHER.BigInt(), balance)
+
+	// A paused full node catches up with the validators once unpaused.
+	require.NoError(t, fullNode.PauseContainer(ctx))
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3, gethChain))
+	require.NoError(t, fullNode.UnpauseContainer(ctx))
+
+	height, err := gethChain.Height(ctx)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		fullNodeHeight, err := fullNode.Height(ctx)
+		return err == nil && fullNodeHeight >= height
+	}, time.Minute, time.Second)
+}
+
+func TestGethNetworkConsensus(t *testing.T) {
+	build := func(cfg ibc.ChainConfig, numVals int) error {
+		_, err := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+			{ChainConfig: cfg, NumValidators: &numVals},
+		}).Chains(t.Name())
+		return err
+	}
+
+	// Geth v1.14 and later cannot produce Clique blocks, so their networks need beacon client sidecars.
+	require.ErrorContains(t, build(geth.DefaultEthereumGethChainConfig("ethereum"), 2), "SidecarConfigs")
+	require.NoError(t, build(geth.DefaultEthereumGethCliqueChainConfig("ethereum"), 2))
+
+	// A beacon network runs a single node.
+	require.NoError(t, build(gethBeaconChainConfig(), 1))
+	require.ErrorContains(t, build(gethBeaconChainConfig(), 2), "single node")
+}
+
+// gethBeaconChainConfig returns the config of a geth network whose blocks are proposed by a Teku beacon node,
+// which runs the validators of an interop genesis and drives each geth node through its engine API.
+func gethBeaconChainConfig() ibc.ChainConfig {
+	cfg := geth.DefaultEthereumGethChainConfig("ethereum")
+	// The "--dev" flags do not apply to a network.
+	cfg.AdditionalStartArgs = []string{
+		"--verbosity", "4", // Level = debug
+		"--rpc.txfeecap", "50.0", // 50 eth
+		"--rpc.gascap", "30000000", // 30M
+		"--rpc.enabledeprecatedpersonal",
+	}
+	cfg.SidecarConfigs = []ibc.SidecarConfig{
+		{
+			ProcessName: "teku",
+			Image: ibc.DockerImage{
+				Repository: "consensys/teku",
+				Version:    "24.8.0",
+				UIDGID:     "1025:1025",
+			},
+			HomeDir: "/home/teku",
+			// The genesis of the beacon chain is generated from the interop validator keys, and starts at the
+			// deneb fork, matching the cancun genesis of geth. Its first block is built on the geth genesis block.
+			StartCmd: []string{"sh", "-c", strings.Join([]string{
+				"exec /opt/teku/bin/teku",
+				"--network=minimal",
+				"--data-path=/tmp/teku",
+				"--ee-endpoint=$EXECUTION_ENDPOINT",
+				"--ee-jwt-secret-file=$JWT_SECRET_PATH",
+				"--p2p-enabled=false",
+				"--Xinterop-enabled=true",
+				"--Xinterop-genesis-time=$(date +%s)",
+				"--Xinterop-number-of-validators=64",
+				"--Xinterop-owned-validator-start-index=0",
+				"--Xinterop-owned-validator-count=64",
+				"--Xnetwork-altair-fork-epoch=0",
+				"--Xnetwork-bellatrix-fork-epoch=0",
+				"--Xnetwork-capella-fork-epoch=0",
+				"--Xnetwork-deneb-fork-epoch=0",
+				"--Xnetwork-total-terminal-difficulty-override=0",
+				"--validators-proposer-default-fee-recipient=0x0000000000000000000000000000000000000001",
+			}, " ")},
+			// A single beacon node, beside node 0, owns all the validators.
+			ValidatorProcess: false,
+		},
+	}
+	return cfg
+}
+
+func TestGethBeaconNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	client, network := interchaintest.DockerSetup(t)
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	ctx := context.Background()
+
+	numVals, numFullNodes := 1, 0
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			ChainConfig:   gethBeaconChainConfig(),
+			NumValidators: &numVals,
+			NumFullNodes:  &numFullNodes,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gethChain := chains[0].(*geth.GethChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(gethChain)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	// Blocks are produced by the beacon sidecar, as the network has no Clique signers.
+	require.Len(t, gethChain.Validators, numVals)
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3, gethChain))
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "user", ethereum.ETHER, gethChain)
+	balance, err := gethChain.Validators[0].RPCClient().BalanceAt(ctx, common.HexToAddress(users[0].FormattedAddress()), nil)
+	require.NoError(t, err)
+	require.Equal(t, ethereum.ETHER.BigInt(), balance)
+}
@@ -38,6 +38,10 @@ func TestCreateClient(t *testing.T) {
 			name:       "Hermes",
 			relayerImp: ibc.Hermes,
 		},
+		{
+			name:       "In-process",
+			relayerImp: ibc.InProcess,
+		},
 	}
 
 	for _, tt := range tests {
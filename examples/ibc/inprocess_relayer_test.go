@@ -0,0 +1,140 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"cosmossdk.io/math"
+
+	transfertypes "github.com/cosmos/ibc-go/v11/modules/apps/transfer/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+// TestInProcessRelayer relays transfers between two chains with the relayer running in the test process,
+// so that the relayer can be stepped through in a debugger.
+func TestInProcessRelayer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	ctx := context.Background()
+
+	chains := interchaintest.CreateChainsWithChainSpecs(t, []*interchaintest.ChainSpec{
+		{Name: testutil.TestSimd, Version: testutil.SimdVersion, ChainName: "chain1", NumValidators: &numVals, NumFullNodes: &numFullNodes},
+		{Name: testutil.TestSimd, Version: testutil.SimdVersion, ChainName: "chain2", NumValidators: &numVals, NumFullNodes: &numFullNodes},
+	})
+	chain1, chain2 := chains[0], chains[1]
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(ibc.InProcess, zaptest.NewLogger(t)).Build(t, client, network)
+	require.False(t, r.UseDockerNetwork())
+
+	const pathName = "inprocess-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(chain1).
+		AddChain(chain2).
+		AddRelayer(r, "relayer").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chain1,
+			Chain2:  chain2,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	fundAmount := math.NewInt(10_000_000)
+	users := interchaintest.GetAndFundTestUsers(t, ctx, t.Name(), fundAmount, chain1, chain2)
+	user1, user2 := users[0], users[1]
+
+	channel, err := ibc.GetTransferChannel(ctx, r, eRep, chain1.Config().ChainID, chain2.Config().ChainID)
+	require.NoError(t, err)
+	ibcDenom := transfertypes.NewDenom(chain1.Config().Denom, transfertypes.NewHop(channel.Counterparty.PortID, channel.Counterparty.ChannelID)).IBCDenom()
+
+	amount := math.NewInt(1_000)
+	transfer := ibc.WalletAmount{
+		Address: user2.FormattedAddress(),
+		Denom:   chain1.Config().Denom,
+		Amount:  amount,
+	}
+
+	// Send two transfers, and relay only the second one.
+	first, err := chain1.SendIBCTransfer(ctx, channel.ChannelID, user1.KeyName(), transfer, ibc.TransferOptions{})
+	require.NoError(t, err)
+	second, err := chain1.SendIBCTransfer(ctx, channel.ChannelID, user1.KeyName(), transfer, ibc.TransferOptions{})
+	require.NoError(t, err)
+
+	relayed, err := r.RelayPackets(ctx, eRep, pathName, chain1.Config().ChainID, channel.ChannelID, second.Packet.Sequence)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{second.Packet.Sequence}, relayed)
+
+	balance, err := chain2.GetBalance(ctx, user2.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.True(t, balance.Equal(amount))
+
+	relayed, err = r.RelayAcks(ctx, eRep, pathName, chain1.Config().ChainID, channel.ChannelID, second.Packet.Sequence)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{second.Packet.Sequence}, relayed)
+
+	// Flushing relays the first transfer and its acknowledgement.
+	require.NoError(t, r.Flush(ctx, eRep, pathName, channel.ChannelID))
+	balance, err = chain2.GetBalance(ctx, user2.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.True(t, balance.Equal(amount.MulRaw(2)))
+
+	relayed, err = r.RelayPackets(ctx, eRep, pathName, chain1.Config().ChainID, channel.ChannelID, first.Packet.Sequence)
+	require.NoError(t, err)
+	require.Empty(t, relayed)
+
+	// A transfer that times out before it is relayed is timed out on the sending chain, refunding the sender.
+	timedOut, err := chain1.SendIBCTransfer(ctx, channel.ChannelID, user1.KeyName(), transfer, ibc.TransferOptions{
+		Timeout: &ibc.IBCTimeout{NanoSeconds: uint64(time.Second)},
+	})
+	require.NoError(t, err)
+	balanceBefore, err := chain1.GetBalance(ctx, user1.FormattedAddress(), chain1.Config().Denom)
+	require.NoError(t, err)
+	require.NoError(t, testutil.WaitForBlocks(ctx, 3, chain1, chain2))
+
+	relayed, err = r.RelayPackets(ctx, eRep, pathName, chain1.Config().ChainID, channel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{timedOut.Packet.Sequence}, relayed)
+
+	balanceAfter, err := chain1.GetBalance(ctx, user1.FormattedAddress(), chain1.Config().Denom)
+	require.NoError(t, err)
+	require.True(t, balanceAfter.Equal(balanceBefore.Add(amount)), "sender was not refunded: %s", balanceAfter)
+
+	balance, err = chain2.GetBalance(ctx, user2.FormattedAddress(), ibcDenom)
+	require.NoError(t, err)
+	require.True(t, balance.Equal(amount.MulRaw(2)))
+
+	// The relayer also relays in the background.
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+	_, err = chain1.SendIBCTransfer(ctx, channel.ChannelID, user1.KeyName(), transfer, ibc.TransferOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		balance, err := chain2.GetBalance(ctx, user2.FormattedAddress(), ibcDenom)
+		return err == nil && balance.Equal(amount.MulRaw(3))
+	}, time.Minute, time.Second)
+}
@@ -4,4 +4,9 @@
 // The official spec documentation can be found at https://github.com/cosmos/ibc/tree/master/spec.
 //
 // Currently, the interfaces may be biased towards chains built with the cosmos sdk and the cosmos/relayer.
+//
+// IBC v2 (Eureka) packets are modeled by PacketV2, and can be sent, queried and polled for on cosmos chains.
+// None of the relayers in this repository relay IBC v2 packets yet: the relayer images and the in-process relayer
+// only relay channel packets. A PacketV2 is only acknowledged or timed out in a test which relays it itself,
+// e.g. by broadcasting MsgRecvPacket and MsgAcknowledgement of ibc-go's core/04-channel/v2 module.
 package ibc
@@ -61,31 +61,52 @@ func (packet Packet) Equal(other Packet) bool {
 	return reflect.DeepEqual(packet, other)
 }
 
+// AnyPacket is satisfied by the packet types of both IBC protocol versions.
+type AnyPacket interface {
+	Packet | PacketV2
+	Validate() error
+}
+
+// AckOf is an acknowledgement of a packet of either IBC protocol version.
+type AckOf[P AnyPacket] struct {
+	Packet          P
+	Acknowledgement []byte // an opaque value defined by the application logic; unset for v2 packets
+
+	// AppAcknowledgements holds one opaque acknowledgement per payload of a v2 packet.
+	AppAcknowledgements [][]byte
+}
+
 // PacketAcknowledgement signals the packet was processed and accepted by the counterparty chain.
 // See: https://github.com/cosmos/ibc/blob/52a9094a5bc8c5275e25c19d0b2d9e6fd80ba31c/spec/core/ics-004-channel-and-packet-semantics/README.md#writing-acknowledgements
-type PacketAcknowledgement struct {
-	Packet          Packet
-	Acknowledgement []byte // an opaque value defined by the application logic
-}
+type PacketAcknowledgement = AckOf[Packet]
+
+// PacketAcknowledgementV2 signals the v2 packet was processed by the counterparty chain.
+type PacketAcknowledgementV2 = AckOf[PacketV2]
 
 // Validate returns an error if the acknowledgement is not well-formed.
-func (ack PacketAcknowledgement) Validate() error {
+func (ack AckOf[P]) Validate() error {
 	var err error
-	if len(ack.Acknowledgement) == 0 {
+	if len(ack.Acknowledgement) == 0 && len(ack.AppAcknowledgements) == 0 {
 		multierr.AppendInto(&err, errors.New("packet acknowledgement cannot be empty"))
 	}
 	return multierr.Append(err, ack.Packet.Validate())
 }
 
+// TimeoutOf is a timeout of a packet of either IBC protocol version.
+type TimeoutOf[P AnyPacket] struct {
+	Packet P
+}
+
 // PacketTimeout signals a packet was not processed by the counterparty chain.
 // Indicates the sending chain should undo or rollback state.
 // Timeout conditions are block height and timestamp.
 // See: https://github.com/cosmos/ibc/blob/52a9094a5bc8c5275e25c19d0b2d9e6fd80ba31c/spec/core/ics-004-channel-and-packet-semantics/README.md#timeouts
-type PacketTimeout struct {
-	Packet Packet
-}
+type PacketTimeout = TimeoutOf[Packet]
+
+// PacketTimeoutV2 signals a v2 packet was not processed by the counterparty chain before its timeout timestamp.
+type PacketTimeoutV2 = TimeoutOf[PacketV2]
 
 // Validate returns an error if the timeout is not well-formed.
-func (timeout PacketTimeout) Validate() error {
+func (timeout TimeoutOf[P]) Validate() error {
 	return timeout.Packet.Validate()
 }
@@ -0,0 +1,87 @@
+package ibc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/multierr"
+
+	host "github.com/cosmos/ibc-go/v11/modules/core/24-host"
+)
+
+// PacketV2 is a packet sent between two light clients as defined by IBC v2 (Eureka).
+// Unlike Packet, it is routed by client IDs rather than by port and channel, and carries one or more payloads.
+// Proto defined at: github.com/cosmos/ibc-go/v11/proto/ibc/core/channel/v2/packet.proto.
+type PacketV2 struct {
+	Sequence     uint64 // the order of sends on the source client
+	SourceClient string // the client on the sending chain tracking the receiving chain
+	DestClient   string // the client on the receiving chain tracking the sending chain
+
+	// Indicates a timestamp (in seconds) on the destination chain after which the packet will no longer be processed.
+	// Unlike Packet, IBC v2 has no timeout height and measures timeouts in seconds.
+	TimeoutTimestamp uint64
+
+	Payloads []PayloadV2
+}
+
+// PayloadV2 is the application data carried by a PacketV2, addressed to a port on each side.
+type PayloadV2 struct {
+	SourcePort string // the application port on the sending chain
+	DestPort   string // the application port on the receiving chain
+	Version    string // the application version, e.g. "ics20-1"
+	Encoding   string // the encoding of Value, e.g. "application/json"
+	Value      []byte // an opaque value which can be defined by the application logic
+}
+
+// Validate returns an error if the packet is not well-formed.
+func (packet PacketV2) Validate() error {
+	var merr error
+	if packet.Sequence == 0 {
+		multierr.AppendInto(&merr, errors.New("packet sequence cannot be 0"))
+	}
+	if err := host.ClientIdentifierValidator(packet.SourceClient); err != nil {
+		multierr.AppendInto(&merr, fmt.Errorf("invalid packet source client: %w", err))
+	}
+	if err := host.ClientIdentifierValidator(packet.DestClient); err != nil {
+		multierr.AppendInto(&merr, fmt.Errorf("invalid packet destination client: %w", err))
+	}
+	if packet.TimeoutTimestamp == 0 {
+		multierr.AppendInto(&merr, errors.New("packet timeout timestamp cannot be 0"))
+	}
+	if len(packet.Payloads) == 0 {
+		multierr.AppendInto(&merr, errors.New("packet payloads cannot be empty"))
+	}
+	for i, payload := range packet.Payloads {
+		if err := payload.Validate(); err != nil {
+			multierr.AppendInto(&merr, fmt.Errorf("invalid packet payload %d: %w", i, err))
+		}
+	}
+	return merr
+}
+
+// Equal returns true if both packets are equal.
+func (packet PacketV2) Equal(other PacketV2) bool {
+	return reflect.DeepEqual(packet, other)
+}
+
+// Validate returns an error if the payload is not well-formed.
+func (payload PayloadV2) Validate() error {
+	var merr error
+	if err := host.PortIdentifierValidator(payload.SourcePort); err != nil {
+		multierr.AppendInto(&merr, fmt.Errorf("invalid source port: %w", err))
+	}
+	if err := host.PortIdentifierValidator(payload.DestPort); err != nil {
+		multierr.AppendInto(&merr, fmt.Errorf("invalid destination port: %w", err))
+	}
+	if payload.Version == "" {
+		multierr.AppendInto(&merr, errors.New("version cannot be empty"))
+	}
+	if payload.Encoding == "" {
+		multierr.AppendInto(&merr, errors.New("encoding cannot be empty"))
+	}
+	if len(payload.Value) == 0 {
+		multierr.AppendInto(&merr, errors.New("value cannot be empty"))
+	}
+	return merr
+}
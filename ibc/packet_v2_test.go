@@ -0,0 +1,103 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func validPacketV2() PacketV2 {
+	return PacketV2{
+		Sequence:         1,
+		SourceClient:     "07-tendermint-0",
+		DestClient:       "07-tendermint-1",
+		TimeoutTimestamp: 1700000000,
+		Payloads: []PayloadV2{{
+			SourcePort: "transfer",
+			DestPort:   "transfer",
+			Version:    "ics20-1",
+			Encoding:   "application/json",
+			Value:      []byte(`fake data`),
+		}},
+	}
+}
+
+func TestPacketV2_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		packet := validPacketV2()
+
+		require.NoError(t, packet.Validate())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var empty PacketV2
+		merr := empty.Validate()
+
+		require.Error(t, merr)
+		require.Greater(t, len(multierr.Errors(merr)), 1)
+
+		for _, tt := range []struct {
+			Mutate  func(*PacketV2)
+			WantErr string
+		}{
+			{func(p *PacketV2) { p.Sequence = 0 }, "packet sequence cannot be 0"},
+			{func(p *PacketV2) { p.SourceClient = "@" }, "invalid packet source client:"},
+			{func(p *PacketV2) { p.DestClient = "" }, "invalid packet destination client:"},
+			{func(p *PacketV2) { p.TimeoutTimestamp = 0 }, "packet timeout timestamp cannot be 0"},
+			{func(p *PacketV2) { p.Payloads = nil }, "packet payloads cannot be empty"},
+			{func(p *PacketV2) { p.Payloads[0].SourcePort = "@" }, "invalid packet payload 0: invalid source port:"},
+			{func(p *PacketV2) { p.Payloads[0].DestPort = "" }, "invalid packet payload 0: invalid destination port:"},
+			{func(p *PacketV2) { p.Payloads[0].Version = "" }, "invalid packet payload 0: version cannot be empty"},
+			{func(p *PacketV2) { p.Payloads[0].Encoding = "" }, "invalid packet payload 0: encoding cannot be empty"},
+			{func(p *PacketV2) { p.Payloads[0].Value = nil }, "invalid packet payload 0: value cannot be empty"},
+		} {
+			packet := validPacketV2()
+			tt.Mutate(&packet)
+			err := packet.Validate()
+			require.Error(t, err, tt.WantErr)
+			require.Contains(t, err.Error(), tt.WantErr)
+		}
+	})
+}
+
+func TestPacketV2_Equal(t *testing.T) {
+	other := validPacketV2()
+	other.Payloads[0].Value = []byte(`other`)
+
+	for _, tt := range []struct {
+		Left, Right PacketV2
+		WantEqual   bool
+	}{
+		{validPacketV2(), validPacketV2(), true},
+		{PacketV2{}, PacketV2{}, true},
+
+		{validPacketV2(), PacketV2{}, false},
+		{validPacketV2(), other, false},
+		{PacketV2{Sequence: 1}, PacketV2{Sequence: 2}, false},
+	} {
+		require.Equal(t, tt.WantEqual, tt.Left.Equal(tt.Right), tt)
+		require.Equal(t, tt.WantEqual, tt.Right.Equal(tt.Left), tt)
+	}
+}
+
+func TestPacketAcknowledgmentV2_Validate(t *testing.T) {
+	var ack PacketAcknowledgementV2
+	require.Error(t, ack.Validate())
+
+	ack.Packet = validPacketV2()
+	err := ack.Validate()
+	require.Error(t, err)
+	require.EqualError(t, err, "packet acknowledgement cannot be empty")
+
+	ack.AppAcknowledgements = [][]byte{[]byte(`ack`)}
+	require.NoError(t, ack.Validate())
+}
+
+func TestPacketTimeoutV2_Validate(t *testing.T) {
+	var timeout PacketTimeoutV2
+	require.Error(t, timeout.Validate())
+
+	timeout.Packet = validPacketV2()
+	require.NoError(t, timeout.Validate())
+}
@@ -3,6 +3,7 @@ package ibc
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v11/modules/core/04-channel/types"
@@ -73,6 +74,25 @@ type Relayer interface {
 	// Flush flushes any outstanding packets and then returns.
 	Flush(ctx context.Context, rep RelayerExecReporter, pathName string, channelID string) error
 
+	// RelayPackets relays the pending packets sent from srcChainID over channelID exactly once:
+	// each packet is received on the counterparty chain, or timed out if it can no longer be received.
+	// Unlike StartRelayer, nothing is relayed in the background,
+	// so tests control exactly when each packet is delivered.
+	// If sequences are given, only those packets are relayed.
+	// It returns the sequences of the relayed packets.
+	//
+	// Relayers without the relayer.RelayPacketSequences capability relay every pending packet on the channel,
+	// possibly in both directions, return no sequences, and fail if sequences are given.
+	RelayPackets(ctx context.Context, rep RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error)
+
+	// RelayAcks relays the pending acknowledgements of packets sent from srcChainID over channelID
+	// back to srcChainID, exactly once.
+	// If sequences are given, only the acknowledgements of those packets are relayed.
+	// It returns the sequences of the packets whose acknowledgements were relayed.
+	//
+	// The same limitations as RelayPackets apply to relayers without the relayer.RelayPacketSequences capability.
+	RelayAcks(ctx context.Context, rep RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error)
+
 	// CreateClients performs the client handshake steps necessary for creating a light client
 	// on src that tracks the state of dst, and a light client on dst that tracks the state of src.
 	CreateClients(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateClientOptions) error
@@ -116,6 +136,133 @@ type Relayer interface {
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
+	return getPortChannel(ctx, r, rep, srcChainID, dstChainID, "transfer")
+}
+
+// PathEnd identifies the light client, connection and channel on one chain of a linked path.
+type PathEnd struct {
+	ChainID      string `json:"chain_id"`
+	ClientID     string `json:"client_id"`
+	ConnectionID string `json:"connection_id"`
+	PortID       string `json:"port_id"`
+	ChannelID    string `json:"channel_id"`
+}
+
+// GetPathEnds returns the identifiers on both chains of the channel bound to portID on srcChainID,
+// whose connection is backed by a client tracking dstChainID.
+// The chains may have several clients and connections to each other, but an error is returned
+// unless exactly one such channel exists; use GetAllPathEnds to get every channel.
+func GetPathEnds(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID, portID string) (src, dst PathEnd, _ error) {
+	srcEnds, dstEnds, err := GetAllPathEnds(ctx, r, rep, srcChainID, dstChainID, portID)
+	if err != nil {
+		return src, dst, err
+	}
+
+	switch len(srcEnds) {
+	case 0:
+		return src, dst, fmt.Errorf("no %s channel found between chains: %s - %s", portID, srcChainID, dstChainID)
+	case 1:
+		return srcEnds[0], dstEnds[0], nil
+	default:
+		return src, dst, fmt.Errorf("found %d %s channels on %s to %s", len(srcEnds), portID, srcChainID, dstChainID)
+	}
+}
+
+// GetAllPathEnds returns the identifiers on both chains of every channel bound to portID on srcChainID,
+// whose connection is backed by a client tracking dstChainID. src[i] and dst[i] are the ends of the same channel.
+func GetAllPathEnds(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID, portID string) (src, dst []PathEnd, _ error) {
+	srcEnds, err := channelEnds(ctx, r, rep, srcChainID, dstChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	dstEnds, err := channelEnds(ctx, r, rep, dstChainID, srcChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, s := range srcEnds {
+		if s.PortID != portID {
+			continue
+		}
+
+		i := slices.IndexFunc(dstEnds, func(d channelEnd) bool {
+			return d.PortID == s.counterparty.PortID && d.ChannelID == s.counterparty.ChannelID &&
+				d.counterparty.ChannelID == s.ChannelID
+		})
+		if i < 0 {
+			return nil, nil, fmt.Errorf(
+				"channel %s on %s has no counterparty channel %s on %s",
+				s.ChannelID, srcChainID, s.counterparty.ChannelID, dstChainID,
+			)
+		}
+
+		src = append(src, s.PathEnd)
+		dst = append(dst, dstEnds[i].PathEnd)
+	}
+	return src, dst, nil
+}
+
+// channelEnd is the end of a channel on one chain, along with its counterparty.
+type channelEnd struct {
+	PathEnd
+	counterparty ChannelCounterparty
+}
+
+// channelEnds returns the ends on chainID of the channels over a single connection
+// backed by a client tracking counterpartyChainID.
+func channelEnds(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID, counterpartyChainID string) ([]channelEnd, error) {
+	clients, err := r.GetClients(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clients on %s: %w", chainID, err)
+	}
+	clientIDs := make(map[string]bool)
+	for _, client := range clients {
+		if client.ClientState.ChainID == counterpartyChainID {
+			clientIDs[client.ClientID] = true
+		}
+	}
+
+	connections, err := r.GetConnections(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections on %s: %w", chainID, err)
+	}
+	connectionClientIDs := make(map[string]string)
+	for _, connection := range connections {
+		if clientIDs[connection.ClientID] {
+			connectionClientIDs[connection.ID] = connection.ClientID
+		}
+	}
+
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+	var ends []channelEnd
+	for _, ch := range channels {
+		if len(ch.ConnectionHops) != 1 {
+			continue
+		}
+		clientID, ok := connectionClientIDs[ch.ConnectionHops[0]]
+		if !ok {
+			continue
+		}
+		ends = append(ends, channelEnd{
+			PathEnd: PathEnd{
+				ChainID:      chainID,
+				ClientID:     clientID,
+				ConnectionID: ch.ConnectionHops[0],
+				PortID:       ch.PortID,
+				ChannelID:    ch.ChannelID,
+			},
+			counterparty: ch.Counterparty,
+		})
+	}
+	return ends, nil
+}
+
+// getPortChannel returns the single channel bound to portID on srcChainID whose connection
+// is backed by the single client on srcChainID tracking dstChainID.
+func getPortChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID, portID string) (*ChannelOutput, error) {
 	srcClients, err := r.GetClients(ctx, rep, srcChainID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clients on source chain: %w", err)
@@ -176,16 +323,16 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 	for _, channel := range srcChannels {
 		ch := channel
 
-		if len(ch.ConnectionHops) == 1 && ch.ConnectionHops[0] == srcConnectionID && ch.PortID == "transfer" {
+		if len(ch.ConnectionHops) == 1 && ch.ConnectionHops[0] == srcConnectionID && ch.PortID == portID {
 			if srcChan != nil {
-				return nil, fmt.Errorf("found multiple transfer channels on %s for connection %s", srcChainID, srcConnectionID)
+				return nil, fmt.Errorf("found multiple %s channels on %s for connection %s", portID, srcChainID, srcConnectionID)
 			}
 			srcChan = &ch
 		}
 	}
 
 	if srcChan == nil {
-		return nil, fmt.Errorf("no transfer channel found between chains: %s - %s", srcChainID, dstChainID)
+		return nil, fmt.Errorf("no %s channel found between chains: %s - %s", portID, srcChainID, dstChainID)
 	}
 
 	return srcChan, nil
@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -63,3 +64,90 @@ func TestClientOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+// pathRelayer answers client, connection and channel queries from fixed data.
+type pathRelayer struct {
+	Relayer
+
+	clients     map[string]ClientOutputs
+	connections map[string]ConnectionOutputs
+	channels    map[string][]ChannelOutput
+}
+
+func (r pathRelayer) GetClients(_ context.Context, _ RelayerExecReporter, chainID string) (ClientOutputs, error) {
+	return r.clients[chainID], nil
+}
+
+func (r pathRelayer) GetConnections(_ context.Context, _ RelayerExecReporter, chainID string) (ConnectionOutputs, error) {
+	return r.connections[chainID], nil
+}
+
+func (r pathRelayer) GetChannels(_ context.Context, _ RelayerExecReporter, chainID string) ([]ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+func TestGetPathEnds(t *testing.T) {
+	r := pathRelayer{
+		clients: map[string]ClientOutputs{
+			"a": {{ClientID: "07-tendermint-0", ClientState: ClientState{ChainID: "b"}}},
+			"b": {
+				{ClientID: "07-tendermint-0", ClientState: ClientState{ChainID: "c"}},
+				{ClientID: "07-tendermint-1", ClientState: ClientState{ChainID: "a"}},
+			},
+		},
+		connections: map[string]ConnectionOutputs{
+			"a": {{ID: "connection-0", ClientID: "07-tendermint-0"}},
+			"b": {
+				{ID: "connection-0", ClientID: "07-tendermint-0"},
+				{ID: "connection-1", ClientID: "07-tendermint-1"},
+			},
+		},
+		channels: map[string][]ChannelOutput{
+			"a": {{
+				PortID: "transfer", ChannelID: "channel-0", ConnectionHops: []string{"connection-0"},
+				Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+			}},
+			"b": {
+				{
+					PortID: "transfer", ChannelID: "channel-0", ConnectionHops: []string{"connection-0"},
+					Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"},
+				},
+				{
+					PortID: "transfer", ChannelID: "channel-1", ConnectionHops: []string{"connection-1"},
+					Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"},
+				},
+			},
+		},
+	}
+
+	src, dst, err := GetPathEnds(context.Background(), r, NopRelayerExecReporter{}, "a", "b", "transfer")
+	require.NoError(t, err)
+	require.Equal(t, PathEnd{ChainID: "a", ClientID: "07-tendermint-0", ConnectionID: "connection-0", PortID: "transfer", ChannelID: "channel-0"}, src)
+	require.Equal(t, PathEnd{ChainID: "b", ClientID: "07-tendermint-1", ConnectionID: "connection-1", PortID: "transfer", ChannelID: "channel-1"}, dst)
+
+	_, _, err = GetPathEnds(context.Background(), r, NopRelayerExecReporter{}, "a", "b", "icahost")
+	require.Error(t, err)
+
+	// A second client, connection and transfer channel between a and b.
+	r.clients["a"] = append(r.clients["a"], &ClientOutput{ClientID: "07-tendermint-1", ClientState: ClientState{ChainID: "b"}})
+	r.clients["b"] = append(r.clients["b"], &ClientOutput{ClientID: "07-tendermint-2", ClientState: ClientState{ChainID: "a"}})
+	r.connections["a"] = append(r.connections["a"], &ConnectionOutput{ID: "connection-1", ClientID: "07-tendermint-1"})
+	r.connections["b"] = append(r.connections["b"], &ConnectionOutput{ID: "connection-2", ClientID: "07-tendermint-2"})
+	r.channels["a"] = append(r.channels["a"], ChannelOutput{
+		PortID: "transfer", ChannelID: "channel-1", ConnectionHops: []string{"connection-1"},
+		Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-2"},
+	})
+	r.channels["b"] = append(r.channels["b"], ChannelOutput{
+		PortID: "transfer", ChannelID: "channel-2", ConnectionHops: []string{"connection-2"},
+		Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+	})
+
+	srcs, dsts, err := GetAllPathEnds(context.Background(), r, NopRelayerExecReporter{}, "a", "b", "transfer")
+	require.NoError(t, err)
+	require.Len(t, srcs, 2)
+	require.Equal(t, PathEnd{ChainID: "a", ClientID: "07-tendermint-1", ConnectionID: "connection-1", PortID: "transfer", ChannelID: "channel-1"}, srcs[1])
+	require.Equal(t, PathEnd{ChainID: "b", ClientID: "07-tendermint-2", ConnectionID: "connection-2", PortID: "transfer", ChannelID: "channel-2"}, dsts[1])
+
+	_, _, err = GetPathEnds(context.Background(), r, NopRelayerExecReporter{}, "a", "b", "transfer")
+	require.ErrorContains(t, err, "found 2 transfer channels")
+}
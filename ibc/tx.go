@@ -20,15 +20,38 @@ type Tx struct {
 
 // Validate returns an error if the transaction is not well-formed.
 func (tx Tx) Validate() error {
+	err := validateTx(tx.Height, tx.TxHash, tx.GasSpent)
+	return multierr.Append(err, tx.Packet.Validate())
+}
+
+// TxV2 is a generalized transaction that sent an IBC v2 packet.
+type TxV2 struct {
+	// The block height.
+	Height int64
+	// The transaction hash.
+	TxHash string
+	// Amount of gas charged to the account.
+	GasSpent int64
+
+	Packet PacketV2
+}
+
+// Validate returns an error if the transaction is not well-formed.
+func (tx TxV2) Validate() error {
+	err := validateTx(tx.Height, tx.TxHash, tx.GasSpent)
+	return multierr.Append(err, tx.Packet.Validate())
+}
+
+func validateTx(height int64, txHash string, gasSpent int64) error {
 	var err error
-	if tx.Height == 0 {
+	if height == 0 {
 		err = multierr.Append(err, errors.New("tx height cannot be 0"))
 	}
-	if len(tx.TxHash) == 0 {
+	if len(txHash) == 0 {
 		err = multierr.Append(err, errors.New("tx hash cannot be empty"))
 	}
-	if tx.GasSpent == 0 {
+	if gasSpent == 0 {
 		err = multierr.Append(err, errors.New("tx gas spent cannot be 0"))
 	}
-	return multierr.Append(err, tx.Packet.Validate())
+	return err
 }
@@ -37,3 +37,19 @@ func TestTx_Validate(t *testing.T) {
 		require.Error(t, tx.Validate())
 	})
 }
+
+func TestTxV2_Validate(t *testing.T) {
+	tx := TxV2{
+		Height:   1,
+		TxHash:   "abc",
+		GasSpent: 10,
+		Packet:   validPacketV2(),
+	}
+	require.NoError(t, tx.Validate())
+
+	var empty TxV2
+	err := empty.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tx height cannot be 0")
+	require.Contains(t, err.Error(), "packet timeout timestamp cannot be 0")
+}
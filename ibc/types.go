@@ -93,6 +93,17 @@ type ChainConfig struct {
 	// Used if starting from an already populated genesis.json, e.g for hard fork upgrades.
 	// When nil, the chain will generate the number of validators specified in the ChainSpec.
 	Genesis *GenesisConfig
+	// If set, chain nodes run the chain binary under cosmovisor.
+	Cosmovisor *CosmovisorConfig `yaml:"cosmovisor"`
+	// If set, cosmos module helpers sign transactions in-process with the chain's codec and broadcast them
+	// over gRPC instead of shelling out to the chain binary's tx commands.
+	// Helpers without a gRPC path still run the CLI, and log a warning.
+	GRPCTx bool `yaml:"grpc-tx"`
+	// If set, chain nodes take state sync snapshots, so that full nodes can join with state sync.
+	Snapshots *SnapshotConfig `yaml:"snapshots"`
+	// If set, the chain starts from an exported genesis, such as a mainnet state export,
+	// with its validator set replaced by the chain's validators.
+	GenesisImport *GenesisImportConfig `yaml:"genesis-import"`
 }
 
 func (c ChainConfig) Clone() ChainConfig {
@@ -120,6 +131,21 @@ func (c ChainConfig) Clone() ChainConfig {
 		x.Genesis = &genesis
 	}
 
+	if c.Cosmovisor != nil {
+		cosmovisor := c.Cosmovisor.Clone()
+		x.Cosmovisor = &cosmovisor
+	}
+
+	if c.Snapshots != nil {
+		snapshots := *c.Snapshots
+		x.Snapshots = &snapshots
+	}
+
+	if c.GenesisImport != nil {
+		genesisImport := *c.GenesisImport
+		x.GenesisImport = &genesisImport
+	}
+
 	return x
 }
 
@@ -249,6 +275,22 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.Genesis = other.Genesis
 	}
 
+	if other.Cosmovisor != nil {
+		c.Cosmovisor = other.Cosmovisor
+	}
+
+	if other.GRPCTx {
+		c.GRPCTx = true
+	}
+
+	if other.Snapshots != nil {
+		c.Snapshots = other.Snapshots
+	}
+
+	if other.GenesisImport != nil {
+		c.GenesisImport = other.GenesisImport
+	}
+
 	return c
 }
 
@@ -304,6 +346,51 @@ type CometMockConfig struct {
 	BlockTimeMs int         `yaml:"block-time"`
 }
 
+// CosmovisorConfig describes running chain nodes under cosmovisor.
+//
+// The genesis binary is taken from the node's image.
+// Each upgrade binary is copied from its image into the node home at cosmovisor/upgrades/<name>/bin,
+// so a governance upgrade is applied by cosmovisor itself rather than by swapping the node's image.
+type CosmovisorConfig struct {
+	// Image containing the cosmovisor binary, which is copied into each node home.
+	// If the Repository is empty, cosmovisor must already be on the PATH of the chain image.
+	Image DockerImage `yaml:"image"`
+
+	// Upgrade plan name to the image containing the chain binary for that upgrade.
+	Upgrades map[string]DockerImage `yaml:"upgrades"`
+
+	// Sets DAEMON_ALLOW_DOWNLOAD_BINARIES, so that binaries listed in the upgrade plan info are fetched by cosmovisor.
+	AllowDownloadBinaries bool `yaml:"allow-download-binaries"`
+
+	// Additional environment variables for cosmovisor, e.g. DAEMON_POLL_INTERVAL=1s.
+	Env []string `yaml:"env"`
+}
+
+func (c CosmovisorConfig) Clone() CosmovisorConfig {
+	x := c
+
+	if c.Upgrades != nil {
+		x.Upgrades = make(map[string]DockerImage, len(c.Upgrades))
+		for name, image := range c.Upgrades {
+			x.Upgrades[name] = image
+		}
+	}
+
+	env := make([]string, len(c.Env))
+	copy(env, c.Env)
+	x.Env = env
+
+	return x
+}
+
+// SnapshotConfig configures the state sync snapshots taken by chain nodes, in the state-sync section of app.toml.
+type SnapshotConfig struct {
+	// Interval is the number of blocks between snapshots.
+	Interval uint64 `yaml:"interval"`
+	// KeepRecent is the number of recent snapshots kept, 2 if zero.
+	KeepRecent uint32 `yaml:"keep-recent"`
+}
+
 func NewDockerImage(repository, version, uidGID string) DockerImage {
 	return DockerImage{
 		Repository: repository,
@@ -423,6 +510,8 @@ type RelayerImplementation int64
 const (
 	CosmosRly RelayerImplementation = iota
 	Hermes
+	// InProcess is a relayer running in the test process rather than in Docker.
+	InProcess
 )
 
 // ChannelFilter provides the means for either creating an allowlist or a denylist of channels on the src chain
@@ -455,3 +544,13 @@ type GenesisConfig struct {
 	// If more than MaxVals validators are required to meet 2/3 VP, the test will fail.
 	MaxVals int
 }
+
+// GenesisImportConfig starts a chain from an exported genesis file, e.g. to test an upgrade against mainnet state.
+// The genesis is "testnetified": the chain ID is rewritten, and the staking, slashing and distribution state
+// is rewritten so that the chain's validators are the only validators, bonded with their genesis self-delegation.
+type GenesisImportConfig struct {
+	// Path to the exported genesis file on the host. Gzip-compressed files, e.g. testutil.GzipIt output, are decompressed.
+	// The rewritten genesis is written to a temporary file, which is streamed into the node volumes.
+	// ChainConfig.ModifyGenesis, if set, is applied to the rewritten genesis, which requires reading it into memory.
+	Path string `yaml:"path"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 
 	"github.com/moby/moby/client"
 	"go.uber.org/zap"
@@ -11,6 +12,7 @@ import (
 
 	sdkmath "cosmossdk.io/math"
 
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
 	"github.com/cosmos/interchaintest/v11/ibc"
 	"github.com/cosmos/interchaintest/v11/testreporter"
 )
@@ -29,6 +31,9 @@ type Interchain struct {
 	// Key: relayer and path name; Value: the two chains being linked.
 	links map[relayerPath]interchainLink
 
+	// Map of consumer chain to the provider that launches it.
+	consumers map[ibc.Chain]consumerChain
+
 	// Set to true after Build is called once.
 	built bool
 
@@ -41,6 +46,10 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Docker client and test name, set during Build and used by Snapshot.
+	cli      *client.Client
+	testName string
 }
 
 type interchainLink struct {
@@ -54,6 +63,33 @@ type interchainLink struct {
 	// If a zero value initialization is used, e.g. CreateChannelOptions{},
 	// then the default values will be used via ibc.DefaultChannelOpts.
 	createChannelOpts ibc.CreateChannelOptions
+
+	// If set, the link reuses the client, connection, and channel created by another relayer's path
+	// between the same chains, instead of creating its own.
+	sharedFrom *relayerPath
+
+	// Set for the CCV link between a consumer and its provider,
+	// whose clients are created by the provider at consumer launch.
+	// Its createChannelOpts are ccvChannelOpts.
+	ccv bool
+}
+
+// ccvChannelOpts returns the options of the CCV channel, from the consumer to the provider.
+func ccvChannelOpts() ibc.CreateChannelOptions {
+	return ibc.CreateChannelOptions{
+		SourcePortName: "consumer",
+		DestPortName:   "provider",
+		Order:          ibc.Ordered,
+		Version:        "1",
+	}
+}
+
+// portID returns the source port of the link's channel.
+func (l interchainLink) portID() string {
+	if l.createChannelOpts.SourcePortName == "" {
+		return ibc.DefaultChannelOpts().SourcePortName
+	}
+	return l.createChannelOpts.SourcePortName
 }
 
 // NewInterchain returns a new Interchain.
@@ -67,7 +103,8 @@ func NewInterchain() *Interchain {
 		chains:   make(map[ibc.Chain]string),
 		relayers: make(map[ibc.Relayer]string),
 
-		links: make(map[relayerPath]interchainLink),
+		links:     make(map[relayerPath]interchainLink),
+		consumers: make(map[ibc.Chain]consumerChain),
 	}
 }
 
@@ -214,6 +251,83 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 	return ic
 }
 
+// ConsumerChainOptions describes how AddConsumerChain launches an ICS consumer chain and links it to its provider.
+type ConsumerChainOptions struct {
+	// Relayer to use for the CCV channel between consumer and provider.
+	// If nil, the consumer is launched but no CCV channel is created.
+	Relayer ibc.Relayer
+
+	// Name of path to create.
+	Path string
+
+	// Launch configures how the provider creates and launches the consumer.
+	Launch cosmos.ConsumerLaunchOptions
+}
+
+type consumerChain struct {
+	provider *cosmos.CosmosChain
+	launch   cosmos.ConsumerLaunchOptions
+
+	// Set during Build, once the provider has launched the consumer.
+	consumerID string
+}
+
+// AddConsumerChain adds consumer to the Interchain as an ICS consumer of provider.
+// Both chains must be Cosmos chains already added with AddChain.
+//
+// During Build, the consumer is not started with the other chains. Instead, once the provider is running,
+// the provider creates the consumer, its validators opt in, and after the spawn time the consumer starts from the
+// genesis handed out by the provider. If opts.Relayer is set, the CCV channel is then opened on opts.Path
+// using the clients created by the launch.
+// If any validation fails, AddConsumerChain panics.
+func (ic *Interchain) AddConsumerChain(provider, consumer ibc.Chain, opts ConsumerChainOptions) *Interchain {
+	for _, c := range []ibc.Chain{provider, consumer} {
+		if _, exists := ic.chains[c]; !exists {
+			cfg := c.Config()
+			panic(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		}
+	}
+	p, ok := provider.(*cosmos.CosmosChain)
+	if !ok {
+		panic(fmt.Errorf("provider chain %s must be a cosmos chain, got %T", provider.Config().ChainID, provider))
+	}
+	if _, ok := consumer.(*cosmos.CosmosChain); !ok {
+		panic(fmt.Errorf("consumer chain %s must be a cosmos chain, got %T", consumer.Config().ChainID, consumer))
+	}
+	if provider == consumer {
+		panic(fmt.Errorf("chains must be different (both were %v)", provider))
+	}
+	if _, exists := ic.consumers[consumer]; exists {
+		panic(fmt.Errorf("chain %s is already a consumer chain", consumer.Config().ChainID))
+	}
+	if _, exists := ic.consumers[provider]; exists {
+		panic(fmt.Errorf("provider chain %s cannot itself be a consumer chain", provider.Config().ChainID))
+	}
+
+	ic.consumers[consumer] = consumerChain{provider: p, launch: opts.Launch}
+
+	if opts.Relayer == nil {
+		return ic
+	}
+	if _, exists := ic.relayers[opts.Relayer]; !exists {
+		panic(fmt.Errorf("relayer %v was never added to Interchain", opts.Relayer))
+	}
+
+	key := relayerPath{
+		Relayer: opts.Relayer,
+		Path:    opts.Path,
+	}
+	if _, exists := ic.links[key]; exists {
+		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
+	}
+	ic.links[key] = interchainLink{
+		chains:            [2]ibc.Chain{consumer, provider},
+		createChannelOpts: ccvChannelOpts(),
+		ccv:               true,
+	}
+	return ic
+}
+
 // InterchainBuildOptions describes configuration for (*Interchain).Build.
 type InterchainBuildOptions struct {
 	TestName string
@@ -232,6 +346,10 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// If set, records the gas used by the transactions of every chain supporting gas profiling,
+	// such as cosmos chains. Use (*testreporter.Reporter).GasProfiler to include the summary in the report.
+	GasProfiler *testreporter.GasProfiler
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
@@ -243,12 +361,15 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		panic(fmt.Errorf("Interchain.Build called more than once"))
 	}
 	ic.built = true
+	ic.cli = opts.Client
+	ic.testName = opts.TestName
 
 	chains := make([]ibc.Chain, 0, len(ic.chains))
 	for chain := range ic.chains {
 		chains = append(chains, chain)
 	}
 	ic.cs = newChainSet(ic.log, chains)
+	ic.cs.SetGasProfiler(opts.GasProfiler)
 
 	// Initialize the chains (pull docker images, etc.).
 	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
@@ -272,10 +393,22 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 
 	ic.log.Info("Received genesis wallet amounts")
 
-	if err := ic.cs.Start(ctx, opts.TestName, walletAmounts); err != nil {
+	// Consumer chains can only start once their provider is running and has launched them.
+	standalone := make([]ibc.Chain, 0, len(chains))
+	for _, c := range chains {
+		if _, ok := ic.consumers[c]; !ok {
+			standalone = append(standalone, c)
+		}
+	}
+	if err := newChainSet(ic.log, standalone).Start(ctx, opts.TestName, walletAmounts); err != nil {
 		return fmt.Errorf("failed to start chains: %w", err)
 	}
 
+	if err := ic.launchConsumers(ctx, opts.TestName, walletAmounts); err != nil {
+		// Error already wrapped with appropriate detail.
+		return err
+	}
+
 	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
@@ -309,6 +442,10 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 	// Now link the paths in parallel
 	// Creates clients, connections, and channels for each link/path.
 	for rp, link := range ic.links {
+		if link.sharedFrom != nil || link.ccv {
+			continue
+		}
+
 		c0 := link.chains[0]
 		c1 := link.chains[1]
 		eg.Go(func() error {
@@ -344,7 +481,46 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	if err := ic.linkConsumers(ctx, rep); err != nil {
+		// Error already wrapped with appropriate detail.
+		return err
+	}
+
+	// Point any redundant relayers at the channels created above.
+	for rp, link := range ic.links {
+		if link.sharedFrom == nil {
+			continue
+		}
+
+		c0 := link.chains[0]
+		c1 := link.chains[1]
+
+		src, dst, err := ibc.GetPathEnds(ctx, link.sharedFrom.Relayer, rep, ic.chains[c0], ic.chains[c1], link.portID())
+		if err != nil {
+			return fmt.Errorf(
+				"failed to query path %s on relayer %s between chains %s and %s: %w",
+				link.sharedFrom.Path, link.sharedFrom.Relayer, ic.chains[c0], ic.chains[c1], err,
+			)
+		}
+
+		if err := rp.Relayer.UpdatePath(ctx, rep, rp.Path, ibc.PathUpdateOptions{
+			SrcClientID: &src.ClientID,
+			SrcConnID:   &src.ConnectionID,
+			DstClientID: &dst.ClientID,
+			DstConnID:   &dst.ConnectionID,
+		}); err != nil {
+			return fmt.Errorf(
+				"failed to share path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
+			)
+		}
+	}
+
+	return nil
 }
 
 // WithLog sets the logger on the interchain object.
@@ -462,6 +638,93 @@ func (ic *Interchain) configureRelayerKeys(ctx context.Context, rep *testreporte
 	return nil
 }
 
+// launchConsumers has each provider launch its consumer chains, concurrently across consumers.
+func (ic *Interchain) launchConsumers(ctx context.Context, testName string, walletAmounts map[ibc.Chain][]ibc.WalletAmount) error {
+	var (
+		mu sync.Mutex
+		eg errgroup.Group
+	)
+	for c, cc := range ic.consumers {
+		eg.Go(func() error {
+			consumerID, err := cc.provider.LaunchConsumer(ctx, testName, c.(*cosmos.CosmosChain), cc.launch, walletAmounts[c]...)
+			if err != nil {
+				return fmt.Errorf("failed to launch consumer chain %s on provider %s: %w", ic.chains[c], ic.chains[cc.provider], err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			cc.consumerID = consumerID
+			ic.consumers[c] = cc
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// linkConsumers opens the CCV channel of every consumer chain with a relayer,
+// connecting the clients the provider and consumer created at launch.
+func (ic *Interchain) linkConsumers(ctx context.Context, rep *testreporter.RelayerExecReporter) error {
+	for rp, link := range ic.links {
+		if !link.ccv {
+			continue
+		}
+
+		consumer, provider := link.chains[0], link.chains[1]
+		cc := ic.consumers[consumer]
+
+		if err := ic.linkConsumer(ctx, rep, rp, consumer, cc); err != nil {
+			return fmt.Errorf(
+				"failed to link CCV path %s on relayer %s between consumer %s and provider %s: %w",
+				rp.Path, rp.Relayer, ic.chains[consumer], ic.chains[provider], err,
+			)
+		}
+	}
+	return nil
+}
+
+func (ic *Interchain) linkConsumer(ctx context.Context, rep *testreporter.RelayerExecReporter, rp relayerPath, consumer ibc.Chain, cc consumerChain) error {
+	providerChainID := cc.provider.Config().ChainID
+
+	consumerChains, err := cc.provider.GetNode().ListConsumerChains(ctx)
+	if err != nil {
+		return err
+	}
+	var providerClientID string
+	for _, c := range consumerChains.Chains {
+		if c.ConsumerID == cc.consumerID {
+			providerClientID = c.ClientID
+		}
+	}
+	if providerClientID == "" {
+		return fmt.Errorf("provider has no client for consumer %s", cc.consumerID)
+	}
+
+	clients, err := rp.Relayer.GetClients(ctx, rep, consumer.Config().ChainID)
+	if err != nil {
+		return err
+	}
+	var consumerClientID string
+	for _, c := range clients {
+		if c.ClientState.ChainID == providerChainID {
+			consumerClientID = c.ClientID
+		}
+	}
+	if consumerClientID == "" {
+		return fmt.Errorf("consumer has no client for provider %s", providerChainID)
+	}
+
+	if err := rp.Relayer.UpdatePath(ctx, rep, rp.Path, ibc.PathUpdateOptions{
+		SrcClientID: &consumerClientID,
+		DstClientID: &providerClientID,
+	}); err != nil {
+		return err
+	}
+	if err := rp.Relayer.CreateConnections(ctx, rep, rp.Path); err != nil {
+		return err
+	}
+	return rp.Relayer.CreateChannel(ctx, rep, rp.Path, ccvChannelOpts())
+}
+
 // relayerChain is a tuple of a Relayer and a Chain.
 type relayerChain struct {
 	R ibc.Relayer
@@ -0,0 +1,433 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/cosmos/interchaintest/v11/dockerutil"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testreporter"
+)
+
+// SnapshotChain is the subset of chain behavior required by (*Interchain).Snapshot and RestoreInterchain.
+// *cosmos.CosmosChain satisfies this interface.
+type SnapshotChain interface {
+	ibc.Chain
+
+	// NodeVolumes returns the volume backing each node's home directory, in a stable order.
+	NodeVolumes() []string
+
+	StopAllNodes(ctx context.Context) error
+	StartAllNodes(ctx context.Context) error
+
+	// StartWithExistingHome starts the chain from already populated node volumes.
+	StartWithExistingHome(ctx context.Context) error
+}
+
+// SnapshotRelayer is the subset of relayer behavior required by (*Interchain).Snapshot and RestoreInterchain.
+// The relayers built on relayer.DockerRelayer, such as rly and hermes, satisfy this interface.
+type SnapshotRelayer interface {
+	ibc.Relayer
+
+	// HomeVolume returns the volume backing the relayer's home directory.
+	HomeVolume() string
+
+	// AddWallet records wallet as the relayer's wallet on chainID, without restoring its key.
+	AddWallet(chainID string, wallet ibc.Wallet)
+}
+
+// InterchainSnapshot captures the state of a built Interchain,
+// so that later tests can restore it with RestoreInterchain
+// instead of repeating genesis and relayer path creation.
+//
+// The snapshot itself is plain data and may be persisted with Save and LoadInterchainSnapshot.
+// The chain state lives in Docker volumes labeled with the snapshot name,
+// which outlive the test that created them; call RemoveInterchainSnapshot once they are no longer needed.
+type InterchainSnapshot struct {
+	Name string `json:"name"`
+
+	Chains   []ChainSnapshot   `json:"chains"`
+	Relayers []RelayerSnapshot `json:"relayers"`
+}
+
+// ChainSnapshot holds the snapshot volumes for a single chain, in the order of SnapshotChain.NodeVolumes.
+type ChainSnapshot struct {
+	ChainID string   `json:"chain_id"`
+	Volumes []string `json:"volumes"`
+}
+
+// RelayerSnapshot holds the snapshot of a relayer's home volume, and its wallets and path identifiers,
+// keyed by the name given to AddRelayer. The relayer's keys only live in the volume.
+type RelayerSnapshot struct {
+	Name   string `json:"name"`
+	Volume string `json:"volume"`
+
+	Wallets []RelayerWalletSnapshot `json:"wallets"`
+	Paths   []PathSnapshot          `json:"paths"`
+}
+
+// RelayerWalletSnapshot is the relayer's wallet on a single chain.
+type RelayerWalletSnapshot struct {
+	ChainID string `json:"chain_id"`
+	KeyName string `json:"key_name"`
+	Address []byte `json:"address"`
+	Bech32  string `json:"bech32"`
+}
+
+// PathSnapshot holds the IBC identifiers of a linked relayer path.
+// Src corresponds to the first chain in the link, Dst to the second.
+type PathSnapshot struct {
+	Path string      `json:"path"`
+	Src  ibc.PathEnd `json:"src"`
+	Dst  ibc.PathEnd `json:"dst"`
+}
+
+// Save writes the snapshot as JSON to the given file path.
+func (s InterchainSnapshot) Save(path string) error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	return os.WriteFile(path, bz, 0o600)
+}
+
+// LoadInterchainSnapshot reads a snapshot previously written by (InterchainSnapshot).Save.
+func LoadInterchainSnapshot(path string) (InterchainSnapshot, error) {
+	var s InterchainSnapshot
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return s, fmt.Errorf("read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return s, fmt.Errorf("unmarshal snapshot: %w", err)
+	}
+	return s, nil
+}
+
+// RemoveInterchainSnapshot removes the Docker volumes belonging to the snapshot.
+func RemoveInterchainSnapshot(ctx context.Context, cli *client.Client, snapshot InterchainSnapshot) error {
+	return dockerutil.RemoveSnapshotVolumes(ctx, cli, snapshot.Name)
+}
+
+// Snapshot captures the current state of every chain, relayer home directory and relayer path in the Interchain.
+// Each chain is briefly stopped while its node volumes are copied, then started again.
+// Relayers are not stopped; it is the caller's responsibility to ensure
+// that no packets are in flight if the snapshot must be consistent across chains.
+//
+// Any existing snapshot with the same name is replaced.
+// Snapshot may only be called after Build, every chain must implement SnapshotChain,
+// and every relayer must implement SnapshotRelayer.
+func (ic *Interchain) Snapshot(ctx context.Context, name string) (InterchainSnapshot, error) {
+	if !ic.built {
+		return InterchainSnapshot{}, fmt.Errorf("Interchain.Snapshot called before Build")
+	}
+
+	snapshotChains := make(map[ibc.Chain]SnapshotChain, len(ic.chains))
+	for c, id := range ic.chains {
+		sc, ok := c.(SnapshotChain)
+		if !ok {
+			return InterchainSnapshot{}, fmt.Errorf("chain %s (%T) does not support snapshots", id, c)
+		}
+		snapshotChains[c] = sc
+	}
+	for r, relayerName := range ic.relayers {
+		if _, ok := r.(SnapshotRelayer); !ok {
+			return InterchainSnapshot{}, fmt.Errorf("relayer %s (%T) does not support snapshots", relayerName, r)
+		}
+	}
+
+	s := InterchainSnapshot{Name: name}
+
+	// Collect relayer state first, while the chains are still reachable.
+	// Using a nop reporter here because these queries are not part of the test under observation.
+	rep := ibc.NopRelayerExecReporter{}
+	relayerSnapshots := make(map[ibc.Relayer]*RelayerSnapshot, len(ic.relayers))
+	for r, chains := range ic.relayerChains() {
+		rs := &RelayerSnapshot{Name: ic.relayers[r]}
+		for _, c := range chains {
+			w := ic.relayerWallets[relayerChain{R: r, C: c}]
+			rs.Wallets = append(rs.Wallets, RelayerWalletSnapshot{
+				ChainID: ic.chains[c],
+				KeyName: w.KeyName(),
+				Address: w.Address(),
+				Bech32:  w.FormattedAddress(),
+			})
+		}
+		relayerSnapshots[r] = rs
+	}
+	for rp, link := range ic.links {
+		src, dst, err := ibc.GetPathEnds(ctx, rp.Relayer, rep, ic.chains[link.chains[0]], ic.chains[link.chains[1]], link.portID())
+		if err != nil {
+			return InterchainSnapshot{}, fmt.Errorf("failed to query path %s on relayer %s: %w", rp.Path, ic.relayers[rp.Relayer], err)
+		}
+		rs := relayerSnapshots[rp.Relayer]
+		rs.Paths = append(rs.Paths, PathSnapshot{Path: rp.Path, Src: src, Dst: dst})
+	}
+
+	if err := dockerutil.RemoveSnapshotVolumes(ctx, ic.cli, name); err != nil {
+		return InterchainSnapshot{}, err
+	}
+
+	for r, rs := range relayerSnapshots {
+		volume, err := dockerutil.SnapshotVolume(ctx, ic.log, ic.cli, ic.testName, name, r.(SnapshotRelayer).HomeVolume())
+		if err != nil {
+			return InterchainSnapshot{}, fmt.Errorf("failed to snapshot relayer %s: %w", rs.Name, err)
+		}
+		rs.Volume = volume
+		s.Relayers = append(s.Relayers, *rs)
+	}
+
+	var (
+		mu sync.Mutex
+		eg errgroup.Group
+	)
+	for c, sc := range snapshotChains {
+		chainID := ic.chains[c]
+		eg.Go(func() error {
+			if err := sc.StopAllNodes(ctx); err != nil {
+				return fmt.Errorf("failed to stop chain %s: %w", chainID, err)
+			}
+
+			srcVolumes := sc.NodeVolumes()
+			volumes := make([]string, len(srcVolumes))
+			var veg errgroup.Group
+			for i, v := range srcVolumes {
+				veg.Go(func() error {
+					snapVolume, err := dockerutil.SnapshotVolume(ctx, ic.log, ic.cli, ic.testName, name, v)
+					if err != nil {
+						return err
+					}
+					volumes[i] = snapVolume
+					return nil
+				})
+			}
+			if err := veg.Wait(); err != nil {
+				return fmt.Errorf("failed to snapshot chain %s: %w", chainID, err)
+			}
+
+			if err := sc.StartAllNodes(ctx); err != nil {
+				return fmt.Errorf("failed to restart chain %s: %w", chainID, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			s.Chains = append(s.Chains, ChainSnapshot{ChainID: chainID, Volumes: volumes})
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return InterchainSnapshot{}, err
+	}
+
+	ic.log.Info("Interchain snapshot created", zap.String("name", name), zap.Int("chains", len(s.Chains)))
+
+	return s, nil
+}
+
+// RestoreInterchain is an alternative to (*Interchain).Build which starts every chain
+// from the state recorded in snapshot, rather than from a fresh genesis.
+// The Interchain must declare the same chains, relayer names, and links as the one the snapshot was taken from.
+//
+// Relayers are configured for the restored chains, and the files of their snapshotted home directories,
+// such as their keys, are copied into their homes, keeping the newly written chain configuration.
+// Their paths are pointed at the existing clients and connections instead of creating new ones.
+// As with Build, it is the caller's responsibility to start the relayers.
+func RestoreInterchain(ctx context.Context, snapshot InterchainSnapshot, ic *Interchain, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
+	if ic.built {
+		panic(fmt.Errorf("RestoreInterchain called on an Interchain that was already built"))
+	}
+	ic.built = true
+	ic.cli = opts.Client
+	ic.testName = opts.TestName
+
+	chainSnapshots := make(map[string]ChainSnapshot, len(snapshot.Chains))
+	for _, cs := range snapshot.Chains {
+		chainSnapshots[cs.ChainID] = cs
+	}
+
+	snapshotChains := make(map[ibc.Chain]SnapshotChain, len(ic.chains))
+	chains := make([]ibc.Chain, 0, len(ic.chains))
+	for c, id := range ic.chains {
+		sc, ok := c.(SnapshotChain)
+		if !ok {
+			return fmt.Errorf("chain %s (%T) does not support snapshots", id, c)
+		}
+		if _, ok := chainSnapshots[id]; !ok {
+			return fmt.Errorf("snapshot %s has no state for chain %s", snapshot.Name, id)
+		}
+		snapshotChains[c] = sc
+		chains = append(chains, c)
+	}
+	ic.cs = newChainSet(ic.log, chains)
+	ic.cs.SetGasProfiler(opts.GasProfiler)
+
+	if err := ic.cs.Initialize(ctx, opts.TestName, opts.Client, opts.NetworkID); err != nil {
+		return fmt.Errorf("failed to initialize chains: %w", err)
+	}
+
+	ic.log.Info("Chains initialized")
+
+	var eg errgroup.Group
+	for c, sc := range snapshotChains {
+		chainID := ic.chains[c]
+		eg.Go(func() error {
+			dstVolumes := sc.NodeVolumes()
+			srcVolumes := chainSnapshots[chainID].Volumes
+			if len(dstVolumes) != len(srcVolumes) {
+				return fmt.Errorf(
+					"chain %s has %d nodes but snapshot %s recorded %d",
+					chainID, len(dstVolumes), snapshot.Name, len(srcVolumes),
+				)
+			}
+
+			var veg errgroup.Group
+			for i := range dstVolumes {
+				veg.Go(func() error {
+					return dockerutil.CopyVolume(ctx, dockerutil.VolumeCopyOptions{
+						Log:    ic.log,
+						Client: opts.Client,
+
+						SrcVolume: srcVolumes[i],
+						DstVolume: dstVolumes[i],
+						TestName:  opts.TestName,
+					})
+				})
+			}
+			if err := veg.Wait(); err != nil {
+				return fmt.Errorf("failed to restore volumes for chain %s: %w", chainID, err)
+			}
+
+			if err := sc.StartWithExistingHome(ctx); err != nil {
+				return fmt.Errorf("failed to start chain %s: %w", chainID, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	ic.log.Info("Chains restored from snapshot", zap.String("name", snapshot.Name))
+
+	if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+		return fmt.Errorf("failed to track blocks: %w", err)
+	}
+
+	relayerSnapshots := make(map[string]RelayerSnapshot, len(snapshot.Relayers))
+	for _, rs := range snapshot.Relayers {
+		relayerSnapshots[rs.Name] = rs
+	}
+
+	relayerChains := ic.relayerChains()
+	ic.relayerWallets = make(map[relayerChain]ibc.Wallet, len(relayerChains))
+	for r, chains := range relayerChains {
+		sr, ok := r.(SnapshotRelayer)
+		if !ok {
+			return fmt.Errorf("relayer %s (%T) does not support snapshots", ic.relayers[r], r)
+		}
+		rs, ok := relayerSnapshots[ic.relayers[r]]
+		if !ok {
+			return fmt.Errorf("snapshot %s has no state for relayer %s", snapshot.Name, ic.relayers[r])
+		}
+
+		for _, c := range chains {
+			rpcAddr, grpcAddr := c.GetRPCAddress(), c.GetGRPCAddress()
+			if !r.UseDockerNetwork() {
+				rpcAddr, grpcAddr = c.GetHostRPCAddress(), c.GetHostGRPCAddress()
+			}
+			if err := r.AddChainConfiguration(ctx, rep, c.Config(), ic.chains[c], rpcAddr, grpcAddr); err != nil {
+				return fmt.Errorf("failed to configure relayer %s for chain %s: %w", rs.Name, ic.chains[c], err)
+			}
+		}
+
+		// The chain configuration was just written for the new chain addresses, so only copy the files it lacks.
+		if err := dockerutil.CopyVolume(ctx, dockerutil.VolumeCopyOptions{
+			Log:    ic.log,
+			Client: opts.Client,
+
+			SrcVolume: rs.Volume,
+			DstVolume: sr.HomeVolume(),
+			TestName:  opts.TestName,
+			NoClobber: true,
+		}); err != nil {
+			return fmt.Errorf("failed to restore home of relayer %s: %w", rs.Name, err)
+		}
+
+		for _, c := range chains {
+			w, ok := rs.wallet(ic.chains[c])
+			if !ok {
+				return fmt.Errorf("snapshot %s has no wallet for relayer %s on chain %s", snapshot.Name, rs.Name, ic.chains[c])
+			}
+			sr.AddWallet(c.Config().ChainID, w)
+			ic.relayerWallets[relayerChain{R: r, C: c}] = w
+		}
+	}
+
+	if opts.SkipPathCreation {
+		return nil
+	}
+
+	for rp, link := range ic.links {
+		c0 := link.chains[0]
+		c1 := link.chains[1]
+
+		ps, ok := relayerSnapshots[ic.relayers[rp.Relayer]].path(rp.Path)
+		if !ok {
+			return fmt.Errorf("snapshot %s has no path %s for relayer %s", snapshot.Name, rp.Path, ic.relayers[rp.Relayer])
+		}
+
+		if err := rp.Relayer.GeneratePath(ctx, rep, ic.chains[c0], ic.chains[c1], rp.Path); err != nil {
+			return fmt.Errorf(
+				"failed to generate path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
+			)
+		}
+
+		if err := rp.Relayer.UpdatePath(ctx, rep, rp.Path, ibc.PathUpdateOptions{
+			SrcClientID: &ps.Src.ClientID,
+			SrcConnID:   &ps.Src.ConnectionID,
+			DstClientID: &ps.Dst.ClientID,
+			DstConnID:   &ps.Dst.ConnectionID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore path %s on relayer %s: %w", rp.Path, rp.Relayer, err)
+		}
+	}
+
+	return nil
+}
+
+func (rs RelayerSnapshot) wallet(chainID string) (ibc.Wallet, bool) {
+	for _, w := range rs.Wallets {
+		if w.ChainID == chainID {
+			return snapshotWallet{w: w}, true
+		}
+	}
+	return nil, false
+}
+
+func (rs RelayerSnapshot) path(name string) (PathSnapshot, bool) {
+	for _, p := range rs.Paths {
+		if p.Path == name {
+			return p, true
+		}
+	}
+	return PathSnapshot{}, false
+}
+
+// snapshotWallet adapts a RelayerWalletSnapshot to ibc.Wallet.
+// The mnemonic is not part of the snapshot, so it is always empty.
+type snapshotWallet struct {
+	w RelayerWalletSnapshot
+}
+
+func (w snapshotWallet) KeyName() string          { return w.w.KeyName }
+func (w snapshotWallet) FormattedAddress() string { return w.w.Bech32 }
+func (w snapshotWallet) Mnemonic() string         { return "" }
+func (w snapshotWallet) Address() []byte          { return w.w.Address }
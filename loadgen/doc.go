@@ -0,0 +1,28 @@
+// Package loadgen generates sustained transaction load against a Cosmos chain
+// to benchmark the chain, and the relayers connected to it, under load.
+//
+// A Generator funds a set of wallets from the chain's faucet, pre-signs every transaction up front
+// through a cosmos.Broadcaster, and then submits them at a fixed rate, spread across the chain's nodes.
+// Pre-signing keeps signing out of the measured path, so the submission rate is bounded by the chain
+// rather than by the test.
+//
+//	gen, err := loadgen.New(t, ctx, chain, loadgen.Config{
+//	  TPS:  50,
+//	  Txs:  1000,
+//	  Msgs: loadgen.BankSend(sdk.NewCoins(sdk.NewInt64Coin(chain.Config().Denom, 1))),
+//	})
+//	require.NoError(t, err)
+//	res, err := gen.Run(ctx)
+//	require.NoError(t, err)
+//	t.Log(res)
+//
+// The Result reports latency percentiles from submission to the first block including the transaction,
+// the inclusion rate, mempool rejections, and how full the blocks produced during the run were.
+// A rejected transaction stops its wallet, since the wallet's later transactions were signed
+// with sequences the chain no longer accepts; they are reported as skipped.
+//
+// Chains running with CometMock, see ibc.ChainConfig.CometMock, are supported for deterministic runs.
+// CometMock commits a block every CometMock.BlockTimeMs from its single validator, without consensus rounds,
+// so the blocks, and which transactions they include, depend only on the submission rate and the block time.
+// Latencies are still measured on the test's clock, so they vary across runs.
+package loadgen
@@ -0,0 +1,366 @@
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+const (
+	defaultWallets          = 10
+	defaultInclusionTimeout = time.Minute
+	blockPollInterval       = 100 * time.Millisecond
+)
+
+var defaultFundAmount = math.NewInt(10_000_000_000)
+
+// Config describes the load generated by a Generator.
+type Config struct {
+	// TPS is the rate at which transactions are submitted. Required.
+	TPS float64
+	// Txs is the total number of transactions submitted. Required.
+	Txs int
+	// Msgs builds the messages of every transaction. Required.
+	Msgs MsgsFunc
+
+	// Wallets is the number of funded wallets signing transactions, 10 if zero.
+	// Each wallet submits its transactions in sequence order to a single node,
+	// so the rate per wallet is TPS/Wallets.
+	Wallets int
+	// FundAmount is the amount of the chain's denom each wallet is funded with.
+	// If nil, each wallet is funded with 10_000_000_000.
+	FundAmount *math.Int
+
+	// Gas is the gas limit of every transaction, flags.DefaultGasLimit if zero.
+	// Transactions are not simulated, so it must cover the most expensive transaction built by Msgs.
+	Gas uint64
+
+	// Nodes are the nodes transactions are submitted to, round robin across wallets.
+	// If empty, all of the chain's nodes are used.
+	Nodes cosmos.ChainNodes
+
+	// InclusionTimeout bounds how long Run waits for submitted transactions to be included
+	// once the last one is submitted, one minute if zero.
+	InclusionTimeout time.Duration
+}
+
+// Generator submits pre-signed transactions to a chain at a configured rate.
+type Generator struct {
+	log   *zap.Logger
+	chain *cosmos.CosmosChain
+	cfg   Config
+
+	wallets []ibc.Wallet
+	// txs holds the signed transactions of each wallet, in sequence order.
+	txs [][]signedTx
+}
+
+type signedTx struct {
+	hash  string
+	bytes []byte
+}
+
+// New funds cfg.Wallets wallets on chain and pre-signs cfg.Txs transactions.
+// Transaction i is signed by wallet i % cfg.Wallets.
+func New(t *testing.T, ctx context.Context, chain *cosmos.CosmosChain, cfg Config) (*Generator, error) {
+	t.Helper()
+
+	if cfg.TPS <= 0 {
+		return nil, errors.New("loadgen: TPS must be positive")
+	}
+	if cfg.Txs <= 0 {
+		return nil, errors.New("loadgen: Txs must be positive")
+	}
+	if cfg.Msgs == nil {
+		return nil, errors.New("loadgen: Msgs is required")
+	}
+	if cfg.Wallets <= 0 {
+		cfg.Wallets = defaultWallets
+	}
+	cfg.Wallets = min(cfg.Wallets, cfg.Txs)
+	if cfg.FundAmount == nil {
+		cfg.FundAmount = &defaultFundAmount
+	}
+	if cfg.Gas == 0 {
+		cfg.Gas = flags.DefaultGasLimit
+	}
+	if len(cfg.Nodes) == 0 {
+		cfg.Nodes = chain.Nodes()
+	}
+	if cfg.InclusionTimeout <= 0 {
+		cfg.InclusionTimeout = defaultInclusionTimeout
+	}
+
+	chains := make([]ibc.Chain, cfg.Wallets)
+	for i := range chains {
+		chains[i] = chain
+	}
+	wallets := interchaintest.GetAndFundTestUsers(t, ctx, "loadgen", *cfg.FundAmount, chains...)
+	if err := testutil.WaitForBlocks(ctx, 2, chain); err != nil {
+		return nil, fmt.Errorf("wait for funded wallets: %w", err)
+	}
+
+	g := &Generator{
+		log:     zap.NewNop(),
+		chain:   chain,
+		cfg:     cfg,
+		wallets: wallets,
+		txs:     make([][]signedTx, len(wallets)),
+	}
+	if err := g.sign(t, ctx); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// WithLogger sets the logger progress is reported to.
+func (g *Generator) WithLogger(log *zap.Logger) *Generator {
+	g.log = log
+	return g
+}
+
+// Wallets returns the funded wallets signing the generated transactions.
+func (g *Generator) Wallets() []ibc.Wallet {
+	return g.wallets
+}
+
+// sign pre-signs every transaction through a cosmos.Broadcaster, with consecutive sequences per wallet.
+func (g *Generator) sign(t *testing.T, ctx context.Context) error {
+	b := cosmos.NewBroadcaster(t, g.chain)
+	for w, wallet := range g.wallets {
+		f, err := b.GetFactory(ctx, wallet)
+		if err != nil {
+			return fmt.Errorf("tx factory for %s: %w", wallet.KeyName(), err)
+		}
+		clientCtx, err := b.GetClientContext(ctx, wallet)
+		if err != nil {
+			return fmt.Errorf("client context for %s: %w", wallet.KeyName(), err)
+		}
+		f = f.WithGas(g.cfg.Gas).WithMemo("")
+		sequence := f.Sequence()
+
+		for i := w; i < g.cfg.Txs; i += len(g.wallets) {
+			msgs, err := g.cfg.Msgs(wallet, g.wallets, i)
+			if err != nil {
+				return fmt.Errorf("build msgs of tx %d: %w", i, err)
+			}
+			f := f.WithSequence(sequence)
+			builder, err := f.BuildUnsignedTx(msgs...)
+			if err != nil {
+				return fmt.Errorf("build tx %d: %w", i, err)
+			}
+			if err := tx.Sign(ctx, f, wallet.KeyName(), builder, true); err != nil {
+				return fmt.Errorf("sign tx %d: %w", i, err)
+			}
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(builder.GetTx())
+			if err != nil {
+				return fmt.Errorf("encode tx %d: %w", i, err)
+			}
+			g.txs[w] = append(g.txs[w], signedTx{
+				hash:  fmt.Sprintf("%X", cmttypes.Tx(txBytes).Hash()),
+				bytes: txBytes,
+			})
+			sequence++
+		}
+	}
+	return nil
+}
+
+// Run submits the pre-signed transactions at the configured rate, waits for them to be included,
+// and returns the run's statistics. Run may only be called once, as the transactions' sequences are consumed.
+func (g *Generator) Run(ctx context.Context) (Result, error) {
+	node := g.chain.GetNode()
+	startHeight, err := g.chain.Height(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("query start height: %w", err)
+	}
+	limits, err := g.blockLimits(ctx, node)
+	if err != nil {
+		return Result{}, err
+	}
+
+	rec := newRecorder(g.cfg.Txs)
+	collectCtx, stopCollecting := context.WithCancel(ctx)
+	defer stopCollecting()
+	collectorDone := make(chan error, 1)
+	go func() {
+		collectorDone <- g.collectBlocks(collectCtx, node, startHeight+1, limits, rec)
+	}()
+
+	started := time.Now()
+	g.submit(ctx, rec)
+	submitted := time.Now()
+	g.log.Info("Submitted load",
+		zap.String("chain_id", g.chain.Config().ChainID),
+		zap.Int("txs", g.cfg.Txs),
+		zap.Duration("elapsed", submitted.Sub(started)),
+	)
+
+	// Wait for every accepted transaction to be included, or for the inclusion timeout.
+	deadline := time.NewTimer(g.cfg.InclusionTimeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(blockPollInterval)
+	defer ticker.Stop()
+	for !rec.allIncluded() {
+		select {
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		case err := <-collectorDone:
+			return Result{}, fmt.Errorf("collect blocks: %w", err)
+		case <-deadline.C:
+			g.log.Info("Timed out waiting for load to be included", zap.String("chain_id", g.chain.Config().ChainID))
+			return rec.result(started), nil
+		case <-ticker.C:
+		}
+	}
+	return rec.result(started), nil
+}
+
+// blockLimits returns the block size and gas limits of the chain's consensus params.
+// With CometMock, which may not serve the consensus params, the limits are unknown if the query fails,
+// and the Result reports a block fullness of zero.
+func (g *Generator) blockLimits(ctx context.Context, node *cosmos.ChainNode) (cmttypes.BlockParams, error) {
+	params, err := node.Client.ConsensusParams(ctx, nil)
+	switch {
+	case err == nil:
+		return params.ConsensusParams.Block, nil
+	case g.chain.Config().UsesCometMock():
+		g.log.Info("CometMock did not serve the consensus params, block fullness is not reported", zap.Error(err))
+		return cmttypes.BlockParams{}, nil
+	default:
+		return cmttypes.BlockParams{}, fmt.Errorf("query consensus params: %w", err)
+	}
+}
+
+// submit dispatches a transaction every 1/TPS seconds, in the order they were signed.
+// Each wallet submits its own transactions in sequence order from a dedicated goroutine.
+// Once a transaction of a wallet is rejected, the wallet's later transactions would leave a gap
+// in its sequences, so they are skipped rather than submitted.
+func (g *Generator) submit(ctx context.Context, rec *recorder) {
+	queues := make([]chan signedTx, len(g.wallets))
+	stopped := make([]atomic.Bool, len(g.wallets))
+	var wg sync.WaitGroup
+	for w := range g.wallets {
+		queues[w] = make(chan signedTx, len(g.txs[w]))
+		node := g.cfg.Nodes[w%len(g.cfg.Nodes)]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for stx := range queues[w] {
+				if stopped[w].Load() {
+					rec.skipped()
+					continue
+				}
+				rec.submitted(stx.hash, time.Now())
+				res, err := node.Client.BroadcastTxSync(ctx, stx.bytes)
+				switch {
+				case err != nil:
+					rec.rejected(stx.hash, err.Error())
+					stopped[w].Store(true)
+				case res.Code != 0:
+					rec.rejected(stx.hash, fmt.Sprintf("code %d: %s", res.Code, res.Log))
+					stopped[w].Store(true)
+				}
+			}
+		}()
+	}
+
+	interval := time.Duration(float64(time.Second) / g.cfg.TPS)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+dispatch:
+	for i := range g.cfg.Txs {
+		w := i % len(g.wallets)
+		queues[w] <- g.txs[w][i/len(g.wallets)]
+		if stopped[w].Load() {
+			// The transaction is skipped, so it does not take up a slot of the rate.
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case <-ticker.C:
+		}
+	}
+	for _, q := range queues {
+		close(q)
+	}
+	wg.Wait()
+}
+
+// collectBlocks records every block from height onwards until ctx is done.
+func (g *Generator) collectBlocks(ctx context.Context, node *cosmos.ChainNode, height int64, limits cmttypes.BlockParams, rec *recorder) error {
+	ticker := time.NewTicker(blockPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		latest, err := g.chain.Height(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			g.log.Debug("Failed to query height", zap.Error(err))
+			continue
+		}
+		for ; height <= latest; height++ {
+			h := height
+			block, err := node.Client.Block(ctx, &h)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("block %d: %w", h, err)
+			}
+			results, err := node.Client.BlockResults(ctx, &h)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("block results %d: %w", h, err)
+			}
+
+			seenAt := time.Now()
+			stats := BlockStats{
+				Height:   h,
+				Time:     block.Block.Time,
+				NumTxs:   len(block.Block.Txs),
+				Bytes:    int64(block.Block.Size()),
+				MaxGas:   limits.MaxGas,
+				MaxBytes: limits.MaxBytes,
+			}
+			for i, txBytes := range block.Block.Txs {
+				var code uint32
+				if i < len(results.TxsResults) {
+					stats.GasUsed += results.TxsResults[i].GasUsed
+					code = results.TxsResults[i].Code
+				}
+				rec.included(fmt.Sprintf("%X", txBytes.Hash()), seenAt, code)
+			}
+			rec.block(stats)
+		}
+	}
+}
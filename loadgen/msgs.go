@@ -0,0 +1,62 @@
+package loadgen
+
+import (
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+
+	transfertypes "github.com/cosmos/ibc-go/v11/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// MsgsFunc builds the messages of the i-th transaction signed by sender.
+// wallets holds every wallet funded by the Generator, in a stable order, e.g. to pick a recipient.
+type MsgsFunc func(sender ibc.Wallet, wallets []ibc.Wallet, i int) ([]sdk.Msg, error)
+
+// BankSend sends amount from each wallet to the next funded wallet.
+func BankSend(amount sdk.Coins) MsgsFunc {
+	return func(sender ibc.Wallet, wallets []ibc.Wallet, i int) ([]sdk.Msg, error) {
+		to := wallets[(i+1)%len(wallets)]
+		return []sdk.Msg{&banktypes.MsgSend{
+			FromAddress: sender.FormattedAddress(),
+			ToAddress:   to.FormattedAddress(),
+			Amount:      amount,
+		}}, nil
+	}
+}
+
+// IBCTransfer sends amount over the ICS-20 transfer channelID to receiver, a bech32 address on the counterparty chain.
+// The transfers time out after timeout, measured from when they are signed.
+func IBCTransfer(channelID, receiver string, amount sdk.Coin, timeout time.Duration) MsgsFunc {
+	return func(sender ibc.Wallet, _ []ibc.Wallet, _ int) ([]sdk.Msg, error) {
+		timeoutTimestamp := uint64(time.Now().Add(timeout).UnixNano())
+		return []sdk.Msg{transfertypes.NewMsgTransfer(
+			"transfer",
+			channelID,
+			amount,
+			sender.FormattedAddress(),
+			receiver,
+			clienttypes.ZeroHeight(),
+			timeoutTimestamp,
+			"",
+		)}, nil
+	}
+}
+
+// ContractExecute executes the CosmWasm contract at contractAddr with the JSON message msg, sending funds along.
+// The chain's EncodingConfig must register the wasm types, e.g. with wasm.WasmEncoding.
+func ContractExecute(contractAddr string, msg []byte, funds sdk.Coins) MsgsFunc {
+	return func(sender ibc.Wallet, _ []ibc.Wallet, _ int) ([]sdk.Msg, error) {
+		return []sdk.Msg{&wasmtypes.MsgExecuteContract{
+			Sender:   sender.FormattedAddress(),
+			Contract: contractAddr,
+			Msg:      wasmtypes.RawContractMessage(msg),
+			Funds:    funds,
+		}}, nil
+	}
+}
@@ -0,0 +1,238 @@
+package loadgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Result holds the statistics of a load generation run.
+type Result struct {
+	// Submitted is the number of transactions submitted to a node.
+	Submitted int
+	// Rejected is the number of transactions the nodes did not accept into their mempool,
+	// by the error or CheckTx log returned by the node.
+	Rejected       int
+	RejectedByKind map[string]int
+	// Skipped is the number of transactions not submitted because an earlier transaction
+	// of the same wallet was rejected, which leaves a gap in the wallet's sequences.
+	Skipped int
+	// Included is the number of submitted transactions included in a block,
+	// of which Failed were included but failed during execution.
+	Included, Failed int
+
+	// Duration is the time from the first submission to the last inclusion.
+	Duration time.Duration
+
+	// Latency is the distribution of the time from submitting a transaction
+	// to observing the first block including it.
+	Latency Latency
+
+	// Blocks are the blocks committed during the run, in height order.
+	Blocks []BlockStats
+}
+
+// InclusionRate is the fraction of submitted transactions included in a block.
+func (r Result) InclusionRate() float64 {
+	if r.Submitted == 0 {
+		return 0
+	}
+	return float64(r.Included) / float64(r.Submitted)
+}
+
+// TPS is the rate at which transactions were included over the run.
+func (r Result) TPS() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Included) / r.Duration.Seconds()
+}
+
+// MeanBlockFullness is the mean Fullness of the blocks committed during the run.
+func (r Result) MeanBlockFullness() float64 {
+	if len(r.Blocks) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, b := range r.Blocks {
+		sum += b.Fullness()
+	}
+	return sum / float64(len(r.Blocks))
+}
+
+// MeanBlockInterval is the mean time between the consecutive blocks committed during the run,
+// or zero if fewer than two blocks were committed.
+func (r Result) MeanBlockInterval() time.Duration {
+	if len(r.Blocks) < 2 {
+		return 0
+	}
+	first, last := r.Blocks[0], r.Blocks[len(r.Blocks)-1]
+	return last.Time.Sub(first.Time) / time.Duration(len(r.Blocks)-1)
+}
+
+func (r Result) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "submitted=%d included=%d (%.1f%%) failed=%d rejected=%d skipped=%d tps=%.1f\n",
+		r.Submitted, r.Included, r.InclusionRate()*100, r.Failed, r.Rejected, r.Skipped, r.TPS())
+	fmt.Fprintf(&sb, "latency p50=%s p90=%s p99=%s max=%s\n", r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max)
+	fmt.Fprintf(&sb, "blocks=%d mean fullness=%.1f%% mean interval=%s", len(r.Blocks), r.MeanBlockFullness()*100, r.MeanBlockInterval())
+	for kind, n := range r.RejectedByKind {
+		fmt.Fprintf(&sb, "\nrejected %dx: %s", n, kind)
+	}
+	return sb.String()
+}
+
+// Latency summarizes a distribution of latencies.
+type Latency struct {
+	P50, P90, P99, Max time.Duration
+}
+
+// newLatency returns the percentiles of latencies, which it sorts.
+func newLatency(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return Latency{
+		P50: percentile(latencies, 50),
+		P90: percentile(latencies, 90),
+		P99: percentile(latencies, 99),
+		Max: latencies[len(latencies)-1],
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// BlockStats describes a block committed during a run.
+type BlockStats struct {
+	Height int64
+	Time   time.Time
+	NumTxs int
+
+	// GasUsed and Bytes are the gas used by the block's transactions and the block's size,
+	// against the consensus limits MaxGas and MaxBytes. A limit of -1 means unlimited.
+	GasUsed, MaxGas int64
+	Bytes, MaxBytes int64
+}
+
+// Fullness is the fraction of the block's gas limit used, or of its size limit if gas is unlimited.
+func (b BlockStats) Fullness() float64 {
+	switch {
+	case b.MaxGas > 0:
+		return float64(b.GasUsed) / float64(b.MaxGas)
+	case b.MaxBytes > 0:
+		return float64(b.Bytes) / float64(b.MaxBytes)
+	default:
+		return 0
+	}
+}
+
+// recorder collects the events of a run from the submitting and collecting goroutines.
+type recorder struct {
+	mu sync.Mutex
+
+	submittedAt map[string]time.Time
+	// pending holds the submitted transactions which were neither rejected nor included yet.
+	pending    map[string]struct{}
+	rejects    map[string]int
+	numRejects int
+	numSkipped int
+
+	latencies    []time.Duration
+	failed       int
+	lastIncluded time.Time
+	blocks       []BlockStats
+}
+
+func newRecorder(txs int) *recorder {
+	return &recorder{
+		submittedAt: make(map[string]time.Time, txs),
+		pending:     make(map[string]struct{}, txs),
+		rejects:     make(map[string]int),
+	}
+}
+
+func (r *recorder) submitted(hash string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.submittedAt[hash] = at
+	r.pending[hash] = struct{}{}
+}
+
+func (r *recorder) rejected(hash, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pending[hash]; !ok {
+		return
+	}
+	delete(r.pending, hash)
+	r.numRejects++
+	r.rejects[reason]++
+}
+
+func (r *recorder) skipped() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.numSkipped++
+}
+
+func (r *recorder) included(hash string, at time.Time, code uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	submittedAt, ok := r.submittedAt[hash]
+	if !ok {
+		// Not one of ours.
+		return
+	}
+	if _, ok := r.pending[hash]; !ok {
+		// Already included, or rejected by the node it was submitted to but gossiped by another.
+		return
+	}
+	delete(r.pending, hash)
+	r.latencies = append(r.latencies, at.Sub(submittedAt))
+	r.lastIncluded = at
+	if code != 0 {
+		r.failed++
+	}
+}
+
+func (r *recorder) block(b BlockStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocks = append(r.blocks, b)
+}
+
+// allIncluded reports whether every submitted transaction was either rejected or included.
+func (r *recorder) allIncluded() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending) == 0
+}
+
+func (r *recorder) result(started time.Time) Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := Result{
+		Submitted:      len(r.submittedAt),
+		Rejected:       r.numRejects,
+		RejectedByKind: make(map[string]int, len(r.rejects)),
+		Skipped:        r.numSkipped,
+		Included:       len(r.latencies),
+		Failed:         r.failed,
+		Latency:        newLatency(append([]time.Duration(nil), r.latencies...)),
+		Blocks:         append([]BlockStats(nil), r.blocks...),
+	}
+	for kind, n := range r.rejects {
+		res.RejectedByKind[kind] = n
+	}
+	if !r.lastIncluded.IsZero() {
+		res.Duration = r.lastIncluded.Sub(started)
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package loadgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLatency(t *testing.T) {
+	require.Equal(t, Latency{}, newLatency(nil))
+
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(t, Latency{
+		P50: 50 * time.Millisecond,
+		P90: 90 * time.Millisecond,
+		P99: 99 * time.Millisecond,
+		Max: 100 * time.Millisecond,
+	}, newLatency(latencies))
+
+	require.Equal(t, Latency{P50: time.Second, P90: time.Second, P99: time.Second, Max: time.Second}, newLatency([]time.Duration{time.Second}))
+}
+
+func TestRecorder(t *testing.T) {
+	started := time.Now()
+	rec := newRecorder(4)
+
+	rec.submitted("A", started)
+	rec.submitted("B", started)
+	rec.submitted("C", started)
+	rec.submitted("D", started)
+	require.False(t, rec.allIncluded())
+
+	rec.rejected("C", "mempool is full")
+	rec.rejected("D", "mempool is full")
+	rec.skipped()
+	rec.included("A", started.Add(time.Second), 0)
+	rec.included("B", started.Add(2*time.Second), 5)
+	// Transactions which are not part of the run, or already included, are ignored.
+	rec.included("E", started.Add(2*time.Second), 0)
+	rec.included("A", started.Add(3*time.Second), 0)
+	rec.block(BlockStats{Height: 2, GasUsed: 50, MaxGas: 100})
+	rec.block(BlockStats{Height: 3, Bytes: 10, MaxBytes: 100, MaxGas: -1})
+	require.True(t, rec.allIncluded())
+
+	res := rec.result(started)
+	require.Equal(t, 4, res.Submitted)
+	require.Equal(t, 2, res.Rejected)
+	require.Equal(t, map[string]int{"mempool is full": 2}, res.RejectedByKind)
+	require.Equal(t, 1, res.Skipped)
+	require.Equal(t, 2, res.Included)
+	require.Equal(t, 1, res.Failed)
+	require.Equal(t, 2*time.Second, res.Duration)
+	require.Equal(t, 2*time.Second, res.Latency.Max)
+	require.InDelta(t, 0.5, res.InclusionRate(), 1e-9)
+	require.InDelta(t, 1.0, res.TPS(), 1e-9)
+	require.InDelta(t, 0.3, res.MeanBlockFullness(), 1e-9)
+}
+
+func TestMeanBlockInterval(t *testing.T) {
+	start := time.Now()
+	require.Zero(t, Result{}.MeanBlockInterval())
+	require.Zero(t, Result{Blocks: []BlockStats{{Height: 2, Time: start}}}.MeanBlockInterval())
+	require.Equal(t, 200*time.Millisecond, Result{Blocks: []BlockStats{
+		{Height: 2, Time: start},
+		{Height: 3, Time: start.Add(150 * time.Millisecond)},
+		{Height: 4, Time: start.Add(400 * time.Millisecond)},
+	}}.MeanBlockInterval())
+}
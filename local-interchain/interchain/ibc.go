@@ -14,16 +14,23 @@ func VerifyIBCPaths(ibcpaths map[string][]int) error {
 		if len(v) == 1 {
 			return fmt.Errorf("ibc path '%s' has only 1 chain", k)
 		}
-		if len(v) > 2 {
-			return fmt.Errorf("ibc path '%s' has more than 2 chains", k)
-		}
 	}
 	return nil
 }
 
-// TODO: Allow for a single chain to IBC between multiple chains
+// LinkIBCPaths adds a link for every ibc path. A path shared by more than 2 chains
+// is expanded into a line of links, connecting the chains in config order.
 func LinkIBCPaths(ibcpaths map[string][]int, chains []ibc.Chain, ic *interchaintest.Interchain, r ibc.Relayer) {
 	for path, c := range ibcpaths {
+		if len(c) > 2 {
+			line := make([]ibc.Chain, len(c))
+			for i, idx := range c {
+				line[i] = chains[idx]
+			}
+			ic = ic.AddTopology(interchaintest.LineTopology(line...).WithRelayers(r).WithPathPrefix(path + "-"))
+			continue
+		}
+
 		chain1 := chains[c[0]]
 		chain2 := chains[c[1]]
 
@@ -46,30 +53,28 @@ func GetChannelConnections(ctx context.Context, ibcpaths map[string][]int, chain
 
 	channels := []types.IBCChannel{}
 
-	for _, c := range ibcpaths {
-		chain1 := chains[c[0]]
-		chain2 := chains[c[1]]
-
-		channel1, err := ibc.GetTransferChannel(ctx, r, eRep, chain1.Config().ChainID, chain2.Config().ChainID)
-		if err != nil {
-			panic(err)
+	for _, path := range ibcpaths {
+		for i := 1; i < len(path); i++ {
+			channels = append(channels, getHopChannels(ctx, chains[path[i-1]], chains[path[i]], r, eRep)...)
 		}
+	}
 
-		channels = append(channels, types.IBCChannel{
-			ChainID: chain1.Config().ChainID,
-			Channel: channel1,
-		})
+	return channels
+}
 
-		// this a duplicate?
-		channel2, err := ibc.GetTransferChannel(ctx, r, eRep, chain2.Config().ChainID, chain1.Config().ChainID)
-		if err != nil {
-			panic(err)
-		}
-		channels = append(channels, types.IBCChannel{
-			ChainID: chain2.Config().ChainID,
-			Channel: channel2,
-		})
+func getHopChannels(ctx context.Context, chain1, chain2 ibc.Chain, r ibc.Relayer, eRep ibc.RelayerExecReporter) []types.IBCChannel {
+	channel1, err := ibc.GetTransferChannel(ctx, r, eRep, chain1.Config().ChainID, chain2.Config().ChainID)
+	if err != nil {
+		panic(err)
 	}
 
-	return channels
+	channel2, err := ibc.GetTransferChannel(ctx, r, eRep, chain2.Config().ChainID, chain1.Config().ChainID)
+	if err != nil {
+		panic(err)
+	}
+
+	return []types.IBCChannel{
+		{ChainID: chain1.Config().ChainID, Channel: channel1},
+		{ChainID: chain2.Config().ChainID, Channel: channel2},
+	}
 }
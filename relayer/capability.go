@@ -17,6 +17,14 @@ const (
 
 	// Whether the relayer supports a one-off flush command.
 	Flush
+
+	// Whether the relayer supports relaying a channel's pending packets and acknowledgements on request,
+	// through RelayPackets and RelayAcks.
+	RelayPackets
+
+	// Whether RelayPackets and RelayAcks can relay a chosen subset of packets by sequence,
+	// and report which packets they relayed.
+	RelayPacketSequences
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -29,5 +37,8 @@ func FullCapabilities() map[Capability]bool {
 		HeightTimeout:    true,
 
 		Flush: true,
+
+		RelayPackets:         true,
+		RelayPacketSequences: true,
 	}
 }
@@ -11,11 +11,13 @@ func _() {
 	_ = x[TimestampTimeout-0]
 	_ = x[HeightTimeout-1]
 	_ = x[Flush-2]
+	_ = x[RelayPackets-3]
+	_ = x[RelayPacketSequences-4]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlush"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushRelayPacketsRelayPacketSequences"
 
-var _Capability_index = [...]uint8{0, 16, 29, 34}
+var _Capability_index = [...]uint8{0, 16, 29, 34, 46, 66}
 
 func (i Capability) String() string {
 	idx := int(i) - 0
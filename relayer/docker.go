@@ -7,6 +7,7 @@ import (
 	"io"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -54,6 +55,10 @@ type DockerRelayer struct {
 
 	// chainPkTypes contains a mapping of chainID to PkType for Hermes configuration
 	chainPkTypes map[string]string
+
+	// pathSrcChainIDs contains a mapping of path name to the path's source chain ID, guarded by pathMu
+	pathMu          sync.Mutex
+	pathSrcChainIDs map[string]string
 }
 
 var _ ibc.Relayer = (*DockerRelayer)(nil)
@@ -74,6 +79,8 @@ func NewDockerRelayer(ctx context.Context, log *zap.Logger, testName string, cli
 		testName: testName,
 
 		wallets: map[string]ibc.Wallet{},
+
+		pathSrcChainIDs: map[string]string{},
 	}
 
 	r.homeDir = defaultRlyHomeDirectory
@@ -259,18 +266,82 @@ func (r *DockerRelayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter,
 	return res.Err
 }
 
+// RelayPackets relays every pending packet on the channel once.
+// The relayer CLIs neither select nor report individual packets, so sequences are not supported.
+func (r *DockerRelayer) RelayPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error) {
+	if len(sequences) > 0 {
+		return nil, fmt.Errorf("%s does not support relaying packets by sequence", r.c.Name())
+	}
+	pathChannelID, err := r.pathChannelID(ctx, rep, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	cmd := r.c.RelayPackets(pathName, pathChannelID, r.HomeDir())
+	res := r.Exec(ctx, rep, cmd, nil)
+	return nil, res.Err
+}
+
+// RelayAcks relays every pending acknowledgement on the channel once.
+// The relayer CLIs neither select nor report individual packets, so sequences are not supported.
+func (r *DockerRelayer) RelayAcks(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error) {
+	if len(sequences) > 0 {
+		return nil, fmt.Errorf("%s does not support relaying acknowledgements by sequence", r.c.Name())
+	}
+	pathChannelID, err := r.pathChannelID(ctx, rep, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	cmd := r.c.RelayAcks(pathName, pathChannelID, r.HomeDir())
+	res := r.Exec(ctx, rep, cmd, nil)
+	return nil, res.Err
+}
+
+// pathChannelID returns the ID of the end, on the path's source chain, of the channel channelID on chainID,
+// as the relayer CLIs identify channels by their end on the path's source chain.
+func (r *DockerRelayer) pathChannelID(ctx context.Context, rep ibc.RelayerExecReporter, pathName, chainID, channelID string) (string, error) {
+	r.pathMu.Lock()
+	src, ok := r.pathSrcChainIDs[pathName]
+	r.pathMu.Unlock()
+	if !ok || src == chainID {
+		return channelID, nil
+	}
+
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return "", err
+	}
+	for _, ch := range channels {
+		if ch.ChannelID == channelID {
+			return ch.Counterparty.ChannelID, nil
+		}
+	}
+	return "", fmt.Errorf("channel %s not found on chain %s", channelID, chainID)
+}
+
 func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
 	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.HomeDir())
 	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err == nil {
+		r.setPathSrcChainID(pathName, srcChainID)
+	}
 	return res.Err
 }
 
 func (r *DockerRelayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.PathUpdateOptions) error {
 	cmd := r.c.UpdatePath(pathName, r.HomeDir(), opts)
 	res := r.Exec(ctx, rep, cmd, nil)
+	if res.Err == nil && opts.SrcChainID != nil {
+		r.setPathSrcChainID(pathName, *opts.SrcChainID)
+	}
 	return res.Err
 }
 
+func (r *DockerRelayer) setPathSrcChainID(pathName, srcChainID string) {
+	r.pathMu.Lock()
+	defer r.pathMu.Unlock()
+	r.pathSrcChainIDs[pathName] = srcChainID
+}
+
 func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
 	cmd := r.c.GetChannels(chainID, r.HomeDir())
 
@@ -478,6 +549,15 @@ func (r *DockerRelayer) ResumeRelayer(ctx context.Context) error {
 	return r.client.ContainerUnpause(ctx, r.containerLifecycle.ContainerID())
 }
 
+// ContainerID returns the ID of the running relayer container,
+// or an empty string if the relayer has not been started.
+func (r *DockerRelayer) ContainerID() string {
+	if r.containerLifecycle == nil {
+		return ""
+	}
+	return r.containerLifecycle.ContainerID()
+}
+
 func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
 	if r.customImage != nil {
 		return *r.customImage
@@ -518,6 +598,11 @@ func (r *DockerRelayer) HomeDir() string {
 	return r.homeDir
 }
 
+// HomeVolume returns the name of the Docker volume mounted at the relayer's home directory.
+func (r *DockerRelayer) HomeVolume() string {
+	return r.volumeName
+}
+
 func (r *DockerRelayer) HostName(pathName string) string {
 	return dockerutil.CondenseHostName(fmt.Sprintf("%s-%s", r.c.Name(), pathName))
 }
@@ -576,6 +661,8 @@ type RelayerCommander interface {
 	CreateClient(srcChainID, dstChainID, pathName string, opts ibc.CreateClientOptions, homeDir string) []string
 	CreateConnections(pathName, homeDir string) []string
 	Flush(pathName, channelID, homeDir string) []string
+	RelayPackets(pathName, channelID, homeDir string) []string
+	RelayAcks(pathName, channelID, homeDir string) []string
 	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string
 	UpdatePath(pathName, homeDir string, opts ibc.PathUpdateOptions) []string
 	GetChannels(chainID, homeDir string) []string
@@ -196,6 +196,14 @@ func (c commander) Flush(pathName, channelID, homeDir string) []string {
 	panic("flush implemented in hermes relayer not the commander")
 }
 
+func (c commander) RelayPackets(pathName, channelID, homeDir string) []string {
+	panic("relay packets implemented in hermes relayer not the commander")
+}
+
+func (c commander) RelayAcks(pathName, channelID, homeDir string) []string {
+	panic("relay acks implemented in hermes relayer not the commander")
+}
+
 func (c commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
 	panic("config content implemented in hermes relayer not the commander")
 }
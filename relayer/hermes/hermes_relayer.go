@@ -373,6 +373,69 @@ func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathNa
 	return res.Err
 }
 
+// RelayPackets receives the pending packets sent from srcChainID over channelID on the counterparty chain,
+// or times them out.
+// Hermes does not select or report individual packets, so sequences are not supported.
+func (r *Relayer) RelayPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error) {
+	if len(sequences) > 0 {
+		return nil, fmt.Errorf("hermes does not support relaying packets by sequence")
+	}
+	ch, dstChainID, err := r.pathChannel(ctx, rep, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	cmd := []string{hermes, "tx", "packet-recv", "--dst-chain", dstChainID, "--src-chain", srcChainID, "--src-port", ch.PortID, "--src-channel", channelID}
+	res := r.Exec(ctx, rep, cmd, nil)
+	return nil, res.Err
+}
+
+// RelayAcks relays the pending acknowledgements of packets sent from srcChainID over channelID back to srcChainID.
+// Hermes does not select or report individual packets, so sequences are not supported.
+func (r *Relayer) RelayAcks(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) ([]uint64, error) {
+	if len(sequences) > 0 {
+		return nil, fmt.Errorf("hermes does not support relaying acknowledgements by sequence")
+	}
+	ch, dstChainID, err := r.pathChannel(ctx, rep, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	// Acknowledgements travel in the opposite direction to their packets.
+	cmd := []string{hermes, "tx", "packet-ack", "--dst-chain", srcChainID, "--src-chain", dstChainID, "--src-port", ch.Counterparty.PortID, "--src-channel", ch.Counterparty.ChannelID}
+	res := r.Exec(ctx, rep, cmd, nil)
+	return nil, res.Err
+}
+
+// pathChannel returns the channel channelID on chainID, and the ID of the other chain of the path.
+func (r *Relayer) pathChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, chainID, channelID string) (ibc.ChannelOutput, string, error) {
+	r.lock.RLock()
+	path, ok := r.paths[pathName]
+	r.lock.RUnlock()
+	if !ok {
+		return ibc.ChannelOutput{}, "", fmt.Errorf("path %s not found", pathName)
+	}
+
+	var counterpartyChainID string
+	switch chainID {
+	case path.chainA.chainID:
+		counterpartyChainID = path.chainB.chainID
+	case path.chainB.chainID:
+		counterpartyChainID = path.chainA.chainID
+	default:
+		return ibc.ChannelOutput{}, "", fmt.Errorf("chain %s is not part of path %s", chainID, pathName)
+	}
+
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return ibc.ChannelOutput{}, "", err
+	}
+	for _, ch := range channels {
+		if ch.ChannelID == channelID {
+			return ch, counterpartyChainID, nil
+		}
+	}
+	return ibc.ChannelOutput{}, "", fmt.Errorf("channel %s not found on chain %s", channelID, chainID)
+}
+
 // GeneratePath establishes an in memory path representation. The concept does not exist in hermes, so it is handled
 // at the interchain test level.
 func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
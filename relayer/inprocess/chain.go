@@ -0,0 +1,384 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v11/modules/core/23-commitment/types"
+	ibcexported "github.com/cosmos/ibc-go/v11/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v11/modules/light-clients/07-tendermint"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/cosmos/interchaintest/v11/chain/cosmos"
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+const (
+	defaultGasAdjustment = 1.5
+	txInclusionTimeout   = 30 * time.Second
+)
+
+// chain is the relayer's connection to a single chain: its RPC and gRPC clients,
+// and the keyring holding the relayer's key on that chain.
+type chain struct {
+	cfg ibc.ChainConfig
+
+	rpc  *rpchttp.HTTP
+	grpc *grpc.ClientConn
+
+	enc testutil.TestEncodingConfig
+	kr  keyring.Keyring
+
+	// Wallet of the relayer on this chain, once a key has been restored or added.
+	// walletMu guards wallet, which is replaced by importKey while relaying may read it.
+	walletMu sync.Mutex
+	wallet   *Wallet
+
+	// Transactions are submitted one at a time, so that account sequences never conflict.
+	txMu sync.Mutex
+}
+
+func newChain(cfg ibc.ChainConfig, rpcAddr, grpcAddr string) (*chain, error) {
+	httpClient, err := libclient.DefaultHTTPClient(rpcAddr)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Timeout = 10 * time.Second
+	rpc, err := rpchttp.NewWithClient(rpcAddr, "/websocket", httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("rpc client for %s: %w", cfg.ChainID, err)
+	}
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("grpc dial for %s: %w", cfg.ChainID, err)
+	}
+
+	// The relayer only needs to encode IBC messages and decode IBC state,
+	// so the default encoding is sufficient regardless of the chain's own configuration.
+	enc := cosmos.DefaultEncoding()
+
+	return &chain{
+		cfg:  cfg,
+		rpc:  rpc,
+		grpc: conn,
+		enc:  enc,
+		kr:   keyring.NewInMemory(enc.Codec),
+	}, nil
+}
+
+// importKey adds a key to the chain's keyring and makes it the relayer's signing key.
+// If mnemonic is empty, a new mnemonic is generated.
+func (c *chain) importKey(keyName, mnemonic, coinType, signingAlgorithm string) (*Wallet, error) {
+	if signingAlgorithm != "" && signingAlgorithm != string(hd.Secp256k1Type) {
+		return nil, fmt.Errorf("unsupported signing algorithm %q for chain %s", signingAlgorithm, c.cfg.ChainID)
+	}
+
+	ct, err := strconv.ParseUint(coinType, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin type: %w", err)
+	}
+	hdPath := hd.CreateHDPath(uint32(ct), 0, 0).String()
+
+	var info *keyring.Record
+	if mnemonic == "" {
+		info, mnemonic, err = c.kr.NewMnemonic(keyName, keyring.English, hdPath, "", hd.Secp256k1)
+	} else {
+		info, err = c.kr.NewAccount(keyName, mnemonic, "", hdPath, hd.Secp256k1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import key %q for chain %s: %w", keyName, c.cfg.ChainID, err)
+	}
+
+	addr, err := info.GetAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address of key %q: %w", keyName, err)
+	}
+
+	bech32, err := sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet := NewWallet(keyName, bech32, mnemonic)
+	c.walletMu.Lock()
+	defer c.walletMu.Unlock()
+	c.wallet = wallet
+	return wallet, nil
+}
+
+// relayerWallet returns the wallet of the relayer on this chain, or nil if no key was restored or added.
+func (c *chain) relayerWallet() *Wallet {
+	c.walletMu.Lock()
+	defer c.walletMu.Unlock()
+	return c.wallet
+}
+
+// signer returns the address of the relayer on this chain.
+func (c *chain) signer() (string, error) {
+	wallet := c.relayerWallet()
+	if wallet == nil {
+		return "", fmt.Errorf("no relayer key for chain %s", c.cfg.ChainID)
+	}
+	return wallet.FormattedAddress(), nil
+}
+
+// sendMsgs signs msgs with the relayer key, broadcasts them in a single transaction,
+// and waits for the transaction to be included in a block.
+// It returns the events emitted by the transaction.
+func (c *chain) sendMsgs(ctx context.Context, msgs ...sdk.Msg) ([]abcitypes.Event, error) {
+	wallet := c.relayerWallet()
+	if wallet == nil {
+		return nil, fmt.Errorf("no relayer key for chain %s", c.cfg.ChainID)
+	}
+
+	c.txMu.Lock()
+	defer c.txMu.Unlock()
+
+	acc, err := authtypes.NewQueryClient(c.grpc).AccountInfo(ctx, &authtypes.QueryAccountInfoRequest{
+		Address: wallet.FormattedAddress(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relayer account on %s: %w", c.cfg.ChainID, err)
+	}
+
+	gasAdjustment := c.cfg.GasAdjustment
+	if gasAdjustment <= 0 {
+		gasAdjustment = defaultGasAdjustment
+	}
+
+	f := tx.Factory{}.
+		WithTxConfig(c.enc.TxConfig).
+		WithKeybase(c.kr).
+		WithChainID(c.cfg.ChainID).
+		WithAccountNumber(acc.Info.AccountNumber).
+		WithSequence(acc.Info.Sequence).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
+		WithGasAdjustment(gasAdjustment).
+		WithGasPrices(c.cfg.GasPrices).
+		WithMemo("interchaintest")
+
+	_, gas, err := tx.CalculateGas(c.grpc, f, msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx on %s: %w", c.cfg.ChainID, err)
+	}
+	f = f.WithGas(gas)
+
+	txb, err := f.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(ctx, f, wallet.KeyName(), txb, true); err != nil {
+		return nil, fmt.Errorf("failed to sign tx on %s: %w", c.cfg.ChainID, err)
+	}
+	txBytes, err := c.enc.TxConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.rpc.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast tx on %s: %w", c.cfg.ChainID, err)
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf("tx rejected by %s (code: %d): %s", c.cfg.ChainID, res.Code, res.Log)
+	}
+
+	return c.waitForTx(ctx, res.Hash)
+}
+
+// waitForTx polls for the transaction with the given hash until it is included in a block,
+// and returns its events.
+// It also waits for the following block, so that the transaction's state changes can be proven immediately.
+func (c *chain) waitForTx(ctx context.Context, hash []byte) ([]abcitypes.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, txInclusionTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	var res *coretypes.ResultTx
+	for {
+		if res == nil {
+			if r, err := c.rpc.Tx(ctx, hash, false); err == nil {
+				if r.TxResult.Code != 0 {
+					return nil, fmt.Errorf("tx %X failed on %s (code: %d): %s", hash, c.cfg.ChainID, r.TxResult.Code, r.TxResult.Log)
+				}
+				res = r
+			}
+		}
+		if res != nil {
+			if h, err := c.latestHeight(ctx); err == nil && h > res.Height {
+				return res.TxResult.Events, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %X not included on %s: %w", hash, c.cfg.ChainID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// latestHeight returns the chain's latest block height.
+func (c *chain) latestHeight(ctx context.Context) (int64, error) {
+	stat, err := c.rpc.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query status of %s: %w", c.cfg.ChainID, err)
+	}
+	return stat.SyncInfo.LatestBlockHeight, nil
+}
+
+// ibcHeight converts a block height of this chain to an IBC height.
+func (c *chain) ibcHeight(h int64) clienttypes.Height {
+	return clienttypes.NewHeight(clienttypes.ParseChainID(c.cfg.ChainID), uint64(h))
+}
+
+// signedHeader returns the signed header at height h.
+func (c *chain) signedHeader(ctx context.Context, h int64) (*cmttypes.SignedHeader, error) {
+	res, err := c.rpc.Commit(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commit at height %d on %s: %w", h, c.cfg.ChainID, err)
+	}
+	return &res.SignedHeader, nil
+}
+
+// validatorSet returns the full validator set at height h.
+func (c *chain) validatorSet(ctx context.Context, h int64) (*cmttypes.ValidatorSet, error) {
+	var vals []*cmttypes.Validator
+	perPage := 100
+	for page := 1; ; page++ {
+		res, err := c.rpc.Validators(ctx, &h, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query validators at height %d on %s: %w", h, c.cfg.ChainID, err)
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+	}
+	return cmttypes.NewValidatorSet(vals), nil
+}
+
+// header returns a light client header for height h,
+// verifiable by a light client whose latest trusted height is trusted.
+func (c *chain) header(ctx context.Context, h int64, trusted clienttypes.Height) (*ibctm.Header, error) {
+	sh, err := c.signedHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := c.validatorSet(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	// The trusted validators are the next validators of the trusted header.
+	trustedVals, err := c.validatorSet(ctx, int64(trusted.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	valsProto, err := vals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	trustedValsProto, err := trustedVals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ibctm.Header{
+		SignedHeader:      sh.ToProto(),
+		ValidatorSet:      valsProto,
+		TrustedHeight:     trusted,
+		TrustedValidators: trustedValsProto,
+	}, nil
+}
+
+// queryProof returns the value and the marshaled merkle proof of key in the IBC store,
+// as of the state committed in the header at proofHeight.
+// A nil value with a valid proof proves the key's absence.
+func (c *chain) queryProof(ctx context.Context, key []byte, proofHeight int64) ([]byte, []byte, error) {
+	res, err := c.rpc.ABCIQueryWithOptions(ctx, "store/"+ibcexported.StoreKey+"/key", key, rpcclient.ABCIQueryOptions{
+		Height: proofQueryHeight(proofHeight),
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query proof of %s on %s: %w", key, c.cfg.ChainID, err)
+	}
+	if res.Response.Code != 0 {
+		return nil, nil, fmt.Errorf("proof query of %s on %s failed (code: %d): %s", key, c.cfg.ChainID, res.Response.Code, res.Response.Log)
+	}
+
+	merkleProof, err := commitmenttypes.ConvertProofs(res.Response.ProofOps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert proof of %s on %s: %w", key, c.cfg.ChainID, err)
+	}
+	proof, err := c.enc.Codec.Marshal(&merkleProof)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Response.Value, proof, nil
+}
+
+// proofQueryHeight returns the height at which to query the store for a proof verified against the header
+// at proofHeight. The app hash in the header at height h commits to the state after height h-1.
+func proofQueryHeight(proofHeight int64) int64 {
+	return proofHeight - 1
+}
+
+// searchTxs returns the transactions matching the given event query, oldest first.
+func (c *chain) searchTxs(ctx context.Context, query string) ([]*coretypes.ResultTx, error) {
+	var txs []*coretypes.ResultTx
+	perPage := 100
+	for page := 1; ; page++ {
+		res, err := c.rpc.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, fmt.Errorf("failed to search txs on %s: %w", c.cfg.ChainID, err)
+		}
+		txs = append(txs, res.Txs...)
+		if len(txs) >= res.TotalCount || len(res.Txs) == 0 {
+			return txs, nil
+		}
+	}
+}
+
+func (c *chain) close() error {
+	return c.grpc.Close()
+}
+
+// eventAttribute returns the value of the first attribute with the given key
+// in the first event of the given type.
+func eventAttribute(events []abcitypes.Event, eventType, key string) (string, error) {
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if attr.Key == key {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no %s attribute in %s event", key, eventType)
+}
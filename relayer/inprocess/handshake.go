@@ -0,0 +1,354 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v11/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v11/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v11/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v11/modules/core/24-host"
+	ibcexported "github.com/cosmos/ibc-go/v11/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v11/modules/light-clients/07-tendermint"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+const defaultMaxClockDrift = 10 * time.Second
+
+// pathEnd is the relayer's view of one side of a path.
+type pathEnd struct {
+	chainID      string
+	clientID     string
+	connectionID string
+}
+
+// path is a pair of chains relayed between, with the clients and connection linking them once created.
+type path struct {
+	src, dst pathEnd
+	filter   *ibc.ChannelFilter
+}
+
+// merklePrefix is the commitment prefix of the IBC store on every chain.
+var merklePrefix = commitmenttypes.NewMerklePrefix([]byte(ibcexported.StoreKey))
+
+// createClient creates a light client on dst tracking src, and returns its ID.
+func createClient(ctx context.Context, src, dst *chain, opts ibc.CreateClientOptions) (string, error) {
+	params, err := stakingtypes.NewQueryClient(src.grpc).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to query unbonding period of %s: %w", src.cfg.ChainID, err)
+	}
+	unbonding := params.Params.UnbondingTime
+
+	trustingPeriod := unbonding * 2 / 3
+	switch {
+	case opts.TrustingPeriod != "":
+		if trustingPeriod, err = time.ParseDuration(opts.TrustingPeriod); err != nil {
+			return "", err
+		}
+	case src.cfg.TrustingPeriod != "":
+		if trustingPeriod, err = time.ParseDuration(src.cfg.TrustingPeriod); err != nil {
+			return "", err
+		}
+	case opts.TrustingPeriodPercentage > 0:
+		trustingPeriod = unbonding * time.Duration(opts.TrustingPeriodPercentage) / 100
+	}
+
+	maxClockDrift := defaultMaxClockDrift
+	if opts.MaxClockDrift != "" {
+		if maxClockDrift, err = time.ParseDuration(opts.MaxClockDrift); err != nil {
+			return "", err
+		}
+	}
+
+	h, err := src.latestHeight(ctx)
+	if err != nil {
+		return "", err
+	}
+	sh, err := src.signedHeader(ctx, h)
+	if err != nil {
+		return "", err
+	}
+
+	clientState := ibctm.NewClientState(
+		src.cfg.ChainID,
+		ibctm.DefaultTrustLevel,
+		trustingPeriod,
+		unbonding,
+		maxClockDrift,
+		src.ibcHeight(h),
+		commitmenttypes.GetSDKSpecs(),
+		[]string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState},
+	)
+	consensusState := ibctm.NewConsensusState(
+		sh.Time,
+		commitmenttypes.NewMerkleRoot(sh.AppHash),
+		sh.NextValidatorsHash,
+	)
+
+	signer, err := dst.signer()
+	if err != nil {
+		return "", err
+	}
+	msg, err := clienttypes.NewMsgCreateClient(clientState, consensusState, signer)
+	if err != nil {
+		return "", err
+	}
+
+	events, err := dst.sendMsgs(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client on %s tracking %s: %w", dst.cfg.ChainID, src.cfg.ChainID, err)
+	}
+	return eventAttribute(events, clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID)
+}
+
+// clientState returns the tendermint client state of clientID on c.
+func (c *chain) clientState(ctx context.Context, clientID string) (*ibctm.ClientState, error) {
+	res, err := clienttypes.NewQueryClient(c.grpc).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client %s on %s: %w", clientID, c.cfg.ChainID, err)
+	}
+	cs, err := clienttypes.UnpackClientState(res.ClientState)
+	if err != nil {
+		return nil, err
+	}
+	tmcs, ok := cs.(*ibctm.ClientState)
+	if !ok {
+		return nil, fmt.Errorf("client %s on %s is a %s client, only tendermint clients are supported", clientID, c.cfg.ChainID, cs.ClientType())
+	}
+	return tmcs, nil
+}
+
+// updateClient returns a height of src at which proofs can be verified by the client of src on dst,
+// along with a MsgUpdateClient advancing that client to the height if it is not there yet.
+// The returned message is nil if no update is needed.
+// Proofs for the returned height are obtained with src.queryProof.
+func updateClient(ctx context.Context, src, dst *chain, dstClientID string) (clienttypes.Height, sdk.Msg, error) {
+	cs, err := dst.clientState(ctx, dstClientID)
+	if err != nil {
+		return clienttypes.Height{}, nil, err
+	}
+
+	h, err := src.latestHeight(ctx)
+	if err != nil {
+		return clienttypes.Height{}, nil, err
+	}
+
+	trusted := cs.LatestHeight
+	proofHeight, ok := updateHeight(trusted, src.ibcHeight(h))
+	if !ok {
+		return proofHeight, nil, nil
+	}
+
+	header, err := src.header(ctx, int64(proofHeight.RevisionHeight), trusted)
+	if err != nil {
+		return clienttypes.Height{}, nil, err
+	}
+
+	signer, err := dst.signer()
+	if err != nil {
+		return clienttypes.Height{}, nil, err
+	}
+	msg, err := clienttypes.NewMsgUpdateClient(dstClientID, header, signer)
+	if err != nil {
+		return clienttypes.Height{}, nil, err
+	}
+	return proofHeight, msg, nil
+}
+
+// updateHeight returns the height at which proofs should be taken for a client whose latest trusted height
+// is trusted, when the latest height of the tracked chain is latest, and whether the client must first be
+// updated to that height.
+// If another relayer updated the client past our view of the latest height, the trusted height is used as is.
+func updateHeight(trusted, latest clienttypes.Height) (clienttypes.Height, bool) {
+	if trusted.GTE(latest) {
+		return trusted, false
+	}
+	return latest, true
+}
+
+// sendWithUpdate sends msgs to dst, preceded by the client update in update if it is non-nil.
+func sendWithUpdate(ctx context.Context, dst *chain, update sdk.Msg, msgs ...sdk.Msg) ([]abcitypes.Event, error) {
+	if update != nil {
+		msgs = append([]sdk.Msg{update}, msgs...)
+	}
+	return dst.sendMsgs(ctx, msgs...)
+}
+
+// createConnection performs the connection handshake between the clients in p,
+// and records the resulting connection IDs in p.
+func createConnection(ctx context.Context, src, dst *chain, p *path) error {
+	srcSigner, err := src.signer()
+	if err != nil {
+		return err
+	}
+	dstSigner, err := dst.signer()
+	if err != nil {
+		return err
+	}
+
+	// Init on src.
+	events, err := src.sendMsgs(ctx, conntypes.NewMsgConnectionOpenInit(
+		p.src.clientID, p.dst.clientID, merklePrefix, conntypes.DefaultIBCVersion, 0, srcSigner,
+	))
+	if err != nil {
+		return fmt.Errorf("connection open init on %s: %w", src.cfg.ChainID, err)
+	}
+	srcConnID, err := eventAttribute(events, conntypes.EventTypeConnectionOpenInit, conntypes.AttributeKeyConnectionID)
+	if err != nil {
+		return err
+	}
+
+	// Try on dst.
+	proofHeight, update, err := updateClient(ctx, src, dst, p.dst.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofInit, err := src.queryProof(ctx, host.ConnectionKey(srcConnID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	events, err = sendWithUpdate(ctx, dst, update, conntypes.NewMsgConnectionOpenTry(
+		p.dst.clientID, srcConnID, p.src.clientID, merklePrefix,
+		conntypes.GetCompatibleVersions(), 0, proofInit, proofHeight, dstSigner,
+	))
+	if err != nil {
+		return fmt.Errorf("connection open try on %s: %w", dst.cfg.ChainID, err)
+	}
+	dstConnID, err := eventAttribute(events, conntypes.EventTypeConnectionOpenTry, conntypes.AttributeKeyConnectionID)
+	if err != nil {
+		return err
+	}
+
+	// Ack on src.
+	proofHeight, update, err = updateClient(ctx, dst, src, p.src.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofTry, err := dst.queryProof(ctx, host.ConnectionKey(dstConnID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	if _, err := sendWithUpdate(ctx, src, update, conntypes.NewMsgConnectionOpenAck(
+		srcConnID, dstConnID, proofTry, proofHeight, conntypes.DefaultIBCVersion, srcSigner,
+	)); err != nil {
+		return fmt.Errorf("connection open ack on %s: %w", src.cfg.ChainID, err)
+	}
+
+	// Confirm on dst.
+	proofHeight, update, err = updateClient(ctx, src, dst, p.dst.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofAck, err := src.queryProof(ctx, host.ConnectionKey(srcConnID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	if _, err := sendWithUpdate(ctx, dst, update, conntypes.NewMsgConnectionOpenConfirm(
+		dstConnID, proofAck, proofHeight, dstSigner,
+	)); err != nil {
+		return fmt.Errorf("connection open confirm on %s: %w", dst.cfg.ChainID, err)
+	}
+
+	p.src.connectionID = srcConnID
+	p.dst.connectionID = dstConnID
+	return nil
+}
+
+// createChannel performs the channel handshake over the connection in p.
+func createChannel(ctx context.Context, src, dst *chain, p *path, opts ibc.CreateChannelOptions) error {
+	srcSigner, err := src.signer()
+	if err != nil {
+		return err
+	}
+	dstSigner, err := dst.signer()
+	if err != nil {
+		return err
+	}
+
+	order := chantypes.UNORDERED
+	if opts.Order == ibc.Ordered {
+		order = chantypes.ORDERED
+	}
+
+	// Init on src.
+	events, err := src.sendMsgs(ctx, chantypes.NewMsgChannelOpenInit(
+		opts.SourcePortName, opts.Version, order, []string{p.src.connectionID}, opts.DestPortName, srcSigner,
+	))
+	if err != nil {
+		return fmt.Errorf("channel open init on %s: %w", src.cfg.ChainID, err)
+	}
+	srcChanID, err := eventAttribute(events, chantypes.EventTypeChannelOpenInit, chantypes.AttributeKeyChannelID)
+	if err != nil {
+		return err
+	}
+
+	// Try on dst.
+	proofHeight, update, err := updateClient(ctx, src, dst, p.dst.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofInit, err := src.queryProof(ctx, host.ChannelKey(opts.SourcePortName, srcChanID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	events, err = sendWithUpdate(ctx, dst, update, chantypes.NewMsgChannelOpenTry(
+		opts.DestPortName, opts.Version, order, []string{p.dst.connectionID},
+		opts.SourcePortName, srcChanID, opts.Version, proofInit, proofHeight, dstSigner,
+	))
+	if err != nil {
+		return fmt.Errorf("channel open try on %s: %w", dst.cfg.ChainID, err)
+	}
+	dstChanID, err := eventAttribute(events, chantypes.EventTypeChannelOpenTry, chantypes.AttributeKeyChannelID)
+	if err != nil {
+		return err
+	}
+
+	// The application on dst may have negotiated a different version.
+	dstChan, err := chantypes.NewQueryClient(dst.grpc).Channel(ctx, &chantypes.QueryChannelRequest{
+		PortId: opts.DestPortName, ChannelId: dstChanID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query channel %s on %s: %w", dstChanID, dst.cfg.ChainID, err)
+	}
+
+	// Ack on src.
+	proofHeight, update, err = updateClient(ctx, dst, src, p.src.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofTry, err := dst.queryProof(ctx, host.ChannelKey(opts.DestPortName, dstChanID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	if _, err := sendWithUpdate(ctx, src, update, chantypes.NewMsgChannelOpenAck(
+		opts.SourcePortName, srcChanID, dstChanID, dstChan.Channel.Version, proofTry, proofHeight, srcSigner,
+	)); err != nil {
+		return fmt.Errorf("channel open ack on %s: %w", src.cfg.ChainID, err)
+	}
+
+	// Confirm on dst.
+	proofHeight, update, err = updateClient(ctx, src, dst, p.dst.clientID)
+	if err != nil {
+		return err
+	}
+	_, proofAck, err := src.queryProof(ctx, host.ChannelKey(opts.SourcePortName, srcChanID), int64(proofHeight.RevisionHeight))
+	if err != nil {
+		return err
+	}
+	if _, err := sendWithUpdate(ctx, dst, update, chantypes.NewMsgChannelOpenConfirm(
+		opts.DestPortName, dstChanID, proofAck, proofHeight, dstSigner,
+	)); err != nil {
+		return fmt.Errorf("channel open confirm on %s: %w", dst.cfg.ChainID, err)
+	}
+
+	return nil
+}
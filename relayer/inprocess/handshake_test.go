@@ -0,0 +1,50 @@
+package inprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+func TestUpdateHeight(t *testing.T) {
+	// A client behind the latest height is updated to it, and proofs are taken there.
+	h, update := updateHeight(clienttypes.NewHeight(1, 10), clienttypes.NewHeight(1, 20))
+	require.True(t, update)
+	require.Equal(t, clienttypes.NewHeight(1, 20), h)
+
+	// A client already at the latest height is not updated.
+	h, update = updateHeight(clienttypes.NewHeight(1, 20), clienttypes.NewHeight(1, 20))
+	require.False(t, update)
+	require.Equal(t, clienttypes.NewHeight(1, 20), h)
+
+	// A client updated by another relayer past our view of the chain keeps its trusted height.
+	h, update = updateHeight(clienttypes.NewHeight(1, 25), clienttypes.NewHeight(1, 20))
+	require.False(t, update)
+	require.Equal(t, clienttypes.NewHeight(1, 25), h)
+
+	// Revisions take precedence over block heights.
+	h, update = updateHeight(clienttypes.NewHeight(0, 100), clienttypes.NewHeight(1, 5))
+	require.True(t, update)
+	require.Equal(t, clienttypes.NewHeight(1, 5), h)
+}
+
+func TestProofQueryHeight(t *testing.T) {
+	// Proofs verified against the header at height h are of the state committed at height h-1.
+	require.EqualValues(t, 19, proofQueryHeight(20))
+}
+
+func TestIBCHeight(t *testing.T) {
+	for chainID, revision := range map[string]uint64{
+		"chain":       0,
+		"chain1":      0,
+		"chain-1":     1,
+		"cosmoshub-4": 4,
+	} {
+		c := &chain{cfg: ibc.ChainConfig{ChainID: chainID}}
+		require.Equal(t, clienttypes.NewHeight(revision, 42), c.ibcHeight(42), chainID)
+	}
+}
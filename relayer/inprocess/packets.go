@@ -0,0 +1,372 @@
+package inprocess
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v11/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v11/modules/core/24-host"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// channel is an open channel end on the source chain of a relay direction, with its counterparty.
+type channel struct {
+	portID, channelID     string
+	cpPortID, cpChannelID string
+	ordered               bool
+}
+
+// openChannels returns the open channels on c over connectionID that pass filter.
+func openChannels(ctx context.Context, c *chain, connectionID string, filter *ibc.ChannelFilter) ([]channel, error) {
+	res, err := chantypes.NewQueryClient(c.grpc).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{
+		Connection: connectionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels of %s on %s: %w", connectionID, c.cfg.ChainID, err)
+	}
+
+	var out []channel
+	for _, ch := range res.Channels {
+		if ch.State != chantypes.OPEN || !channelAllowed(filter, ch.ChannelId) {
+			continue
+		}
+		out = append(out, channel{
+			portID:      ch.PortId,
+			channelID:   ch.ChannelId,
+			cpPortID:    ch.Counterparty.PortId,
+			cpChannelID: ch.Counterparty.ChannelId,
+			ordered:     ch.Ordering == chantypes.ORDERED,
+		})
+	}
+	return out, nil
+}
+
+// channelAllowed reports whether filter permits relaying on channelID.
+// A nil filter permits every channel.
+func channelAllowed(filter *ibc.ChannelFilter, channelID string) bool {
+	if filter == nil {
+		return true
+	}
+	listed := slices.Contains(filter.ChannelList, channelID)
+	switch filter.Rule {
+	case "allowlist":
+		return listed
+	case "denylist":
+		return !listed
+	default:
+		return true
+	}
+}
+
+// reverse returns the same channel as seen from the counterparty chain.
+func (ch channel) reverse() channel {
+	return channel{
+		portID:      ch.cpPortID,
+		channelID:   ch.cpChannelID,
+		cpPortID:    ch.portID,
+		cpChannelID: ch.channelID,
+		ordered:     ch.ordered,
+	}
+}
+
+// relayPackets delivers the packets sent over ch from src that dst has not yet received.
+// Packets that can no longer be received because they timed out are timed out on src instead.
+// If sequences is non-empty, only those packets are considered.
+// It returns the sequences of the relayed packets, whether received or timed out.
+func relayPackets(ctx context.Context, src, dst *chain, srcClientID, dstClientID string, ch channel, sequences []uint64) ([]uint64, error) {
+	commitments, err := chantypes.NewQueryClient(src.grpc).PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+		PortId: ch.portID, ChannelId: ch.channelID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query packet commitments on %s: %w", src.cfg.ChainID, err)
+	}
+	var seqs []uint64
+	for _, c := range commitments.Commitments {
+		if len(sequences) == 0 || slices.Contains(sequences, c.Sequence) {
+			seqs = append(seqs, c.Sequence)
+		}
+	}
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+
+	unreceived, err := chantypes.NewQueryClient(dst.grpc).UnreceivedPackets(ctx, &chantypes.QueryUnreceivedPacketsRequest{
+		PortId: ch.cpPortID, ChannelId: ch.cpChannelID, PacketCommitmentSequences: seqs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreceived packets on %s: %w", dst.cfg.ChainID, err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return nil, nil
+	}
+
+	// Whether a packet timed out is decided by the counterparty's latest header.
+	dstHeight, err := dst.latestHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dstHeader, err := dst.signedHeader(ctx, dstHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRecv, toTimeout []chantypes.Packet
+	for _, seq := range unreceived.Sequences {
+		packet, _, err := findPacket(ctx, src, chantypes.EventTypeSendPacket, chantypes.AttributeKeySrcPort, chantypes.AttributeKeySrcChannel, ch.portID, ch.channelID, seq)
+		if err != nil {
+			return nil, err
+		}
+
+		if packetTimedOut(packet, dst.ibcHeight(dstHeight), dstHeader.Time) {
+			toTimeout = append(toTimeout, packet)
+		} else {
+			toRecv = append(toRecv, packet)
+		}
+	}
+
+	var relayed []uint64
+	if len(toRecv) > 0 {
+		if err := recvPackets(ctx, src, dst, dstClientID, toRecv); err != nil {
+			return relayed, err
+		}
+		for _, p := range toRecv {
+			relayed = append(relayed, p.Sequence)
+		}
+	}
+	if len(toTimeout) > 0 {
+		if err := timeoutPackets(ctx, src, dst, srcClientID, ch, toTimeout); err != nil {
+			return relayed, err
+		}
+		for _, p := range toTimeout {
+			relayed = append(relayed, p.Sequence)
+		}
+	}
+	return relayed, nil
+}
+
+// recvPackets proves the commitments of packets on src and submits them to dst.
+func recvPackets(ctx context.Context, src, dst *chain, dstClientID string, packets []chantypes.Packet) error {
+	signer, err := dst.signer()
+	if err != nil {
+		return err
+	}
+	proofHeight, update, err := updateClient(ctx, src, dst, dstClientID)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]sdk.Msg, len(packets))
+	for i, p := range packets {
+		_, proof, err := src.queryProof(ctx, host.PacketCommitmentKey(p.SourcePort, p.SourceChannel, p.Sequence), int64(proofHeight.RevisionHeight))
+		if err != nil {
+			return err
+		}
+		msgs[i] = chantypes.NewMsgRecvPacket(p, proof, proofHeight, signer)
+	}
+
+	if _, err := sendWithUpdate(ctx, dst, update, msgs...); err != nil {
+		return fmt.Errorf("failed to receive packets on %s: %w", dst.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// timeoutPackets proves that dst did not receive packets and times them out on src.
+func timeoutPackets(ctx context.Context, src, dst *chain, srcClientID string, ch channel, packets []chantypes.Packet) error {
+	signer, err := src.signer()
+	if err != nil {
+		return err
+	}
+	proofHeight, update, err := updateClient(ctx, dst, src, srcClientID)
+	if err != nil {
+		return err
+	}
+	h := int64(proofHeight.RevisionHeight)
+
+	msgs := make([]sdk.Msg, len(packets))
+	for i, p := range packets {
+		var (
+			nextSeqRecv = p.Sequence
+			proof       []byte
+		)
+		if ch.ordered {
+			var value []byte
+			value, proof, err = dst.queryProof(ctx, host.NextSequenceRecvKey(p.DestinationPort, p.DestinationChannel), h)
+			if err == nil {
+				nextSeqRecv = sdk.BigEndianToUint64(value)
+			}
+		} else {
+			_, proof, err = dst.queryProof(ctx, host.PacketReceiptKey(p.DestinationPort, p.DestinationChannel, p.Sequence), h)
+		}
+		if err != nil {
+			return err
+		}
+		msgs[i] = chantypes.NewMsgTimeout(p, nextSeqRecv, proof, proofHeight, signer)
+	}
+
+	if _, err := sendWithUpdate(ctx, src, update, msgs...); err != nil {
+		return fmt.Errorf("failed to time out packets on %s: %w", src.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// relayAcks delivers to src the acknowledgements written by dst for packets sent over ch from src.
+// If sequences is non-empty, only the acknowledgements of those packets are considered.
+// It returns the sequences of the packets whose acknowledgements were relayed.
+func relayAcks(ctx context.Context, src, dst *chain, srcClientID string, ch channel, sequences []uint64) ([]uint64, error) {
+	acks, err := chantypes.NewQueryClient(dst.grpc).PacketAcknowledgements(ctx, &chantypes.QueryPacketAcknowledgementsRequest{
+		PortId: ch.cpPortID, ChannelId: ch.cpChannelID, PacketCommitmentSequences: sequences,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query packet acknowledgements on %s: %w", dst.cfg.ChainID, err)
+	}
+	if len(acks.Acknowledgements) == 0 {
+		return nil, nil
+	}
+	seqs := make([]uint64, len(acks.Acknowledgements))
+	for i, a := range acks.Acknowledgements {
+		seqs[i] = a.Sequence
+	}
+
+	unreceived, err := chantypes.NewQueryClient(src.grpc).UnreceivedAcks(ctx, &chantypes.QueryUnreceivedAcksRequest{
+		PortId: ch.portID, ChannelId: ch.channelID, PacketAckSequences: seqs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreceived acknowledgements on %s: %w", src.cfg.ChainID, err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return nil, nil
+	}
+
+	signer, err := src.signer()
+	if err != nil {
+		return nil, err
+	}
+	proofHeight, update, err := updateClient(ctx, dst, src, srcClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]sdk.Msg, len(unreceived.Sequences))
+	for i, seq := range unreceived.Sequences {
+		_, attrs, err := findPacket(ctx, dst, chantypes.EventTypeWriteAck, chantypes.AttributeKeyDstPort, chantypes.AttributeKeyDstChannel, ch.cpPortID, ch.cpChannelID, seq)
+		if err != nil {
+			return nil, err
+		}
+		packet, ack, err := ackFromAttributes(attrs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid acknowledgement of packet %d on %s: %w", seq, dst.cfg.ChainID, err)
+		}
+
+		_, proof, err := dst.queryProof(ctx, host.PacketAcknowledgementKey(ch.cpPortID, ch.cpChannelID, seq), int64(proofHeight.RevisionHeight))
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = chantypes.NewMsgAcknowledgement(packet, ack, proof, proofHeight, signer)
+	}
+
+	if _, err := sendWithUpdate(ctx, src, update, msgs...); err != nil {
+		return nil, fmt.Errorf("failed to acknowledge packets on %s: %w", src.cfg.ChainID, err)
+	}
+	return unreceived.Sequences, nil
+}
+
+// packetTimedOut reports whether packet can no longer be received by the counterparty,
+// whose latest header is at height with timestamp t.
+func packetTimedOut(packet chantypes.Packet, height clienttypes.Height, t time.Time) bool {
+	if !packet.TimeoutHeight.IsZero() && height.GTE(packet.TimeoutHeight) {
+		return true
+	}
+	return packet.TimeoutTimestamp != 0 && uint64(t.UnixNano()) >= packet.TimeoutTimestamp
+}
+
+// findPacket searches the transactions on c for the event of the given type describing packet seq
+// on the given port and channel, and returns the packet along with all of the event's attributes.
+// Only packets emitted by transactions are found, not those emitted by block hooks.
+func findPacket(ctx context.Context, c *chain, eventType, portKey, channelKey, portID, channelID string, seq uint64) (chantypes.Packet, map[string]string, error) {
+	query := fmt.Sprintf("%s.%s='%s' AND %s.%s='%s' AND %s.%s='%d'",
+		eventType, portKey, portID,
+		eventType, channelKey, channelID,
+		eventType, chantypes.AttributeKeySequence, seq,
+	)
+	txs, err := c.searchTxs(ctx, query)
+	if err != nil {
+		return chantypes.Packet{}, nil, err
+	}
+
+	for _, tx := range txs {
+		for _, e := range tx.TxResult.Events {
+			if e.Type != eventType {
+				continue
+			}
+			attrs := eventAttributes(e)
+			if attrs[portKey] != portID || attrs[channelKey] != channelID || attrs[chantypes.AttributeKeySequence] != strconv.FormatUint(seq, 10) {
+				continue
+			}
+			packet, err := packetFromAttributes(attrs)
+			return packet, attrs, err
+		}
+	}
+	return chantypes.Packet{}, nil, fmt.Errorf("no %s event for packet %d on %s/%s on %s", eventType, seq, portID, channelID, c.cfg.ChainID)
+}
+
+func eventAttributes(e abcitypes.Event) map[string]string {
+	attrs := make(map[string]string, len(e.Attributes))
+	for _, a := range e.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	return attrs
+}
+
+// packetFromAttributes reconstructs a packet from the attributes of a send_packet or write_acknowledgement event.
+func packetFromAttributes(attrs map[string]string) (chantypes.Packet, error) {
+	seq, err := strconv.ParseUint(attrs[chantypes.AttributeKeySequence], 10, 64)
+	if err != nil {
+		return chantypes.Packet{}, fmt.Errorf("invalid packet sequence: %w", err)
+	}
+	data, err := hex.DecodeString(attrs[chantypes.AttributeKeyDataHex])
+	if err != nil {
+		return chantypes.Packet{}, fmt.Errorf("invalid packet data: %w", err)
+	}
+	timeoutHeight, err := clienttypes.ParseHeight(attrs[chantypes.AttributeKeyTimeoutHeight])
+	if err != nil {
+		return chantypes.Packet{}, fmt.Errorf("invalid packet timeout height: %w", err)
+	}
+	timeoutTimestamp, err := strconv.ParseUint(attrs[chantypes.AttributeKeyTimeoutTimestamp], 10, 64)
+	if err != nil {
+		return chantypes.Packet{}, fmt.Errorf("invalid packet timeout timestamp: %w", err)
+	}
+
+	return chantypes.NewPacket(
+		data, seq,
+		attrs[chantypes.AttributeKeySrcPort], attrs[chantypes.AttributeKeySrcChannel],
+		attrs[chantypes.AttributeKeyDstPort], attrs[chantypes.AttributeKeyDstChannel],
+		timeoutHeight, timeoutTimestamp,
+	), nil
+}
+
+// ackFromAttributes reconstructs a packet and its acknowledgement from the attributes of a write_acknowledgement event.
+func ackFromAttributes(attrs map[string]string) (chantypes.Packet, []byte, error) {
+	packet, err := packetFromAttributes(attrs)
+	if err != nil {
+		return chantypes.Packet{}, nil, err
+	}
+	ack, err := hex.DecodeString(attrs[chantypes.AttributeKeyAckHex])
+	if err != nil {
+		return chantypes.Packet{}, nil, fmt.Errorf("invalid acknowledgement: %w", err)
+	}
+	if len(ack) == 0 {
+		return chantypes.Packet{}, nil, errors.New("empty acknowledgement")
+	}
+	return packet, ack, nil
+}
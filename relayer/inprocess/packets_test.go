@@ -0,0 +1,141 @@
+package inprocess
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v11/modules/core/04-channel/types"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// packetAttributes returns the attributes of a send_packet or write_acknowledgement event for packet.
+func packetAttributes(packet chantypes.Packet) map[string]string {
+	return map[string]string{
+		chantypes.AttributeKeySequence:         strconv.FormatUint(packet.Sequence, 10),
+		chantypes.AttributeKeyDataHex:          hex.EncodeToString(packet.Data),
+		chantypes.AttributeKeyTimeoutHeight:    packet.TimeoutHeight.String(),
+		chantypes.AttributeKeyTimeoutTimestamp: strconv.FormatUint(packet.TimeoutTimestamp, 10),
+		chantypes.AttributeKeySrcPort:          packet.SourcePort,
+		chantypes.AttributeKeySrcChannel:       packet.SourceChannel,
+		chantypes.AttributeKeyDstPort:          packet.DestinationPort,
+		chantypes.AttributeKeyDstChannel:       packet.DestinationChannel,
+	}
+}
+
+func TestPacketFromAttributes(t *testing.T) {
+	packet := chantypes.NewPacket(
+		[]byte(`{"amount":"100"}`), 7,
+		"transfer", "channel-0",
+		"transfer", "channel-3",
+		clienttypes.NewHeight(1, 500), 1_700_000_000_000_000_000,
+	)
+
+	got, err := packetFromAttributes(packetAttributes(packet))
+	require.NoError(t, err)
+	require.Equal(t, packet, got)
+	require.NoError(t, got.ValidateBasic())
+
+	// A packet without a timeout height has a zero timeout height.
+	packet.TimeoutHeight = clienttypes.ZeroHeight()
+	got, err = packetFromAttributes(packetAttributes(packet))
+	require.NoError(t, err)
+	require.True(t, got.TimeoutHeight.IsZero())
+
+	for _, key := range []string{
+		chantypes.AttributeKeySequence,
+		chantypes.AttributeKeyTimeoutHeight,
+		chantypes.AttributeKeyTimeoutTimestamp,
+	} {
+		attrs := packetAttributes(packet)
+		delete(attrs, key)
+		_, err := packetFromAttributes(attrs)
+		require.Error(t, err, key)
+	}
+
+	attrs := packetAttributes(packet)
+	attrs[chantypes.AttributeKeyDataHex] = "not hex"
+	_, err = packetFromAttributes(attrs)
+	require.Error(t, err)
+}
+
+func TestAckFromAttributes(t *testing.T) {
+	packet := chantypes.NewPacket(
+		[]byte("data"), 3,
+		"transfer", "channel-1",
+		"transfer", "channel-2",
+		clienttypes.ZeroHeight(), 1_700_000_000_000_000_000,
+	)
+	ack := chantypes.NewResultAcknowledgement([]byte{1}).Acknowledgement()
+
+	attrs := packetAttributes(packet)
+	attrs[chantypes.AttributeKeyAckHex] = hex.EncodeToString(ack)
+	gotPacket, gotAck, err := ackFromAttributes(attrs)
+	require.NoError(t, err)
+	require.Equal(t, packet, gotPacket)
+	require.Equal(t, ack, gotAck)
+
+	// The acknowledgement message built from the event is valid.
+	msg := chantypes.NewMsgAcknowledgement(gotPacket, gotAck, []byte("proof"), clienttypes.NewHeight(0, 10), "cosmos1signer")
+	require.Equal(t, ack, msg.Acknowledgement)
+	require.Equal(t, packet, msg.Packet)
+
+	attrs[chantypes.AttributeKeyAckHex] = ""
+	_, _, err = ackFromAttributes(attrs)
+	require.Error(t, err)
+
+	attrs[chantypes.AttributeKeyAckHex] = "zz"
+	_, _, err = ackFromAttributes(attrs)
+	require.Error(t, err)
+}
+
+func TestPacketTimedOut(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	height := clienttypes.NewHeight(1, 100)
+
+	newPacket := func(timeoutHeight clienttypes.Height, timeoutTimestamp uint64) chantypes.Packet {
+		return chantypes.NewPacket([]byte("data"), 1, "transfer", "channel-0", "transfer", "channel-0", timeoutHeight, timeoutTimestamp)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		packet   chantypes.Packet
+		timedOut bool
+	}{
+		{"height before timeout", newPacket(clienttypes.NewHeight(1, 101), 0), false},
+		{"height at timeout", newPacket(clienttypes.NewHeight(1, 100), 0), true},
+		{"height after timeout", newPacket(clienttypes.NewHeight(1, 99), 0), true},
+		{"earlier revision", newPacket(clienttypes.NewHeight(0, 1_000), 0), true},
+		{"later revision", newPacket(clienttypes.NewHeight(2, 1), 0), false},
+		{"time before timeout", newPacket(clienttypes.ZeroHeight(), uint64(now.Add(time.Second).UnixNano())), false},
+		{"time at timeout", newPacket(clienttypes.ZeroHeight(), uint64(now.UnixNano())), true},
+		{"either timeout", newPacket(clienttypes.NewHeight(1, 1_000), uint64(now.Add(-time.Second).UnixNano())), true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.timedOut, packetTimedOut(tt.packet, height, now))
+		})
+	}
+}
+
+func TestChannelAllowed(t *testing.T) {
+	require.True(t, channelAllowed(nil, "channel-0"))
+
+	allow := &ibc.ChannelFilter{Rule: "allowlist", ChannelList: []string{"channel-1"}}
+	require.True(t, channelAllowed(allow, "channel-1"))
+	require.False(t, channelAllowed(allow, "channel-0"))
+
+	deny := &ibc.ChannelFilter{Rule: "denylist", ChannelList: []string{"channel-1"}}
+	require.False(t, channelAllowed(deny, "channel-1"))
+	require.True(t, channelAllowed(deny, "channel-0"))
+}
+
+func TestChannelReverse(t *testing.T) {
+	ch := channel{portID: "transfer", channelID: "channel-0", cpPortID: "icahost", cpChannelID: "channel-5", ordered: true}
+	require.Equal(t, channel{portID: "icahost", channelID: "channel-5", cpPortID: "transfer", cpChannelID: "channel-0", ordered: true}, ch.reverse())
+	require.Equal(t, ch, ch.reverse().reverse())
+}
@@ -0,0 +1,649 @@
+// Package inprocess provides an ibc.Relayer implemented in Go and run in the test process.
+//
+// The relayer talks to each chain through its host-exposed RPC and gRPC ports,
+// so it needs no Docker image and can be stepped through in a debugger.
+// It supports chains using 07-tendermint light clients and secp256k1 keys,
+// and relays channel packets only: IBC v2 packets, routed by client IDs, are not relayed.
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	clienttypes "github.com/cosmos/ibc-go/v11/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v11/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v11/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v11/modules/light-clients/07-tendermint"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/relayer"
+)
+
+// Name is the name of the in-process relayer, as reported in test reports.
+const Name = "inprocess"
+
+const defaultPollInterval = time.Second
+
+var _ ibc.Relayer = (*Relayer)(nil)
+
+// Relayer is an ibc.Relayer that runs in the test process.
+type Relayer struct {
+	log      *zap.Logger
+	testName string
+
+	pollInterval time.Duration
+
+	mu     sync.Mutex
+	chains map[string]*chain // Keyed by chain ID.
+	paths  map[string]*path  // Keyed by path name.
+
+	// Set while the background relayer started by StartRelayer is running.
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	paused atomic.Bool
+}
+
+// Option configures a Relayer.
+type Option func(*Relayer)
+
+// WithPollInterval sets how often the background relayer checks for packets to relay.
+// Defaults to one second.
+func WithPollInterval(d time.Duration) Option {
+	return func(r *Relayer) {
+		r.pollInterval = d
+	}
+}
+
+// NewRelayer returns an in-process relayer.
+// "testName" is only used to identify the relayer in logs.
+func NewRelayer(log *zap.Logger, testName string, opts ...Option) *Relayer {
+	r := &Relayer{
+		log:          log.With(zap.String("relayer", Name), zap.String("test", testName)),
+		testName:     testName,
+		pollInterval: defaultPollInterval,
+		chains:       make(map[string]*chain),
+		paths:        make(map[string]*path),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Capabilities returns the set of capabilities of the in-process relayer.
+func Capabilities() map[relayer.Capability]bool {
+	return relayer.FullCapabilities()
+}
+
+// track reports an operation to rep as if it were a relayer command.
+// It is intended to be deferred, with errp pointing at the operation's named error result, if any.
+func track(rep ibc.RelayerExecReporter, start time.Time, errp *error, cmd ...string) {
+	var err error
+	if errp != nil {
+		err = *errp
+	}
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+	rep.TrackRelayerExec("", append([]string{Name}, cmd...), "", "", exitCode, start, time.Now(), err)
+}
+
+func (r *Relayer) chain(chainID string) (*chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("chain %s is not configured", chainID)
+	}
+	return c, nil
+}
+
+// path returns the named path along with its source and destination chains.
+// The returned path is a copy; use setPath to persist changes.
+func (r *Relayer) path(pathName string) (path, *chain, *chain, error) {
+	r.mu.Lock()
+	p, ok := r.paths[pathName]
+	r.mu.Unlock()
+	if !ok {
+		return path{}, nil, nil, fmt.Errorf("path %s not found", pathName)
+	}
+
+	src, err := r.chain(p.src.chainID)
+	if err != nil {
+		return path{}, nil, nil, err
+	}
+	dst, err := r.chain(p.dst.chainID)
+	if err != nil {
+		return path{}, nil, nil, err
+	}
+	return *p, src, dst, nil
+}
+
+func (r *Relayer) setPath(pathName string, p path) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[pathName] = &p
+}
+
+func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (err error) {
+	defer track(rep, time.Now(), &err, "chains", "add", chainConfig.ChainID, rpcAddr, grpcAddr)
+
+	c, err := newChain(chainConfig, rpcAddr, grpcAddr)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.chains[chainConfig.ChainID]; ok {
+		_ = old.close()
+	}
+	r.chains[chainConfig.ChainID] = c
+	return nil
+}
+
+func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, keyName, mnemonic string) (err error) {
+	defer track(rep, time.Now(), &err, "keys", "restore", cfg.ChainID, keyName)
+
+	c, err := r.chain(cfg.ChainID)
+	if err != nil {
+		return err
+	}
+	_, err = c.importKey(keyName, mnemonic, cfg.CoinType, cfg.SigningAlgorithm)
+	return err
+}
+
+func (r *Relayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, coinType, signingAlgorithm string) (_ ibc.Wallet, err error) {
+	defer track(rep, time.Now(), &err, "keys", "add", chainID, keyName)
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.importKey(keyName, "", coinType, signingAlgorithm)
+}
+
+func (r *Relayer) GetWallet(chainID string) (ibc.Wallet, bool) {
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, false
+	}
+	wallet := c.relayerWallet()
+	if wallet == nil {
+		return nil, false
+	}
+	return wallet, true
+}
+
+func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+	defer track(rep, time.Now(), nil, "paths", "new", srcChainID, dstChainID, pathName)
+
+	r.setPath(pathName, path{
+		src: pathEnd{chainID: srcChainID},
+		dst: pathEnd{chainID: dstChainID},
+	})
+	return nil
+}
+
+func (r *Relayer) UpdatePath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.PathUpdateOptions) error {
+	defer track(rep, time.Now(), nil, "paths", "update", pathName)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.paths[pathName]
+	if !ok {
+		return fmt.Errorf("path %s not found", pathName)
+	}
+
+	if opts.ChannelFilter != nil {
+		p.filter = opts.ChannelFilter
+	}
+	for _, u := range []struct {
+		field *string
+		value *string
+	}{
+		{&p.src.chainID, opts.SrcChainID},
+		{&p.src.clientID, opts.SrcClientID},
+		{&p.src.connectionID, opts.SrcConnID},
+		{&p.dst.chainID, opts.DstChainID},
+		{&p.dst.clientID, opts.DstClientID},
+		{&p.dst.connectionID, opts.DstConnID},
+	} {
+		if u.value != nil {
+			*u.field = *u.value
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, channelOpts ibc.CreateChannelOptions, clientOpts ibc.CreateClientOptions) error {
+	if err := r.CreateClients(ctx, rep, pathName, clientOpts); err != nil {
+		return err
+	}
+	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+	return r.CreateChannel(ctx, rep, pathName, channelOpts)
+}
+
+func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateClientOptions) error {
+	p, _, _, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if err := r.CreateClient(ctx, rep, p.src.chainID, p.dst.chainID, pathName, opts); err != nil {
+		return err
+	}
+	return r.CreateClient(ctx, rep, p.dst.chainID, p.src.chainID, pathName, opts)
+}
+
+// CreateClient creates a light client on srcChainID tracking dstChainID,
+// and records it as the client of the corresponding end of the path.
+func (r *Relayer) CreateClient(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.CreateClientOptions) (err error) {
+	defer track(rep, time.Now(), &err, "tx", "client", srcChainID, dstChainID, pathName)
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	p, _, _, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	host, err := r.chain(srcChainID)
+	if err != nil {
+		return err
+	}
+	tracked, err := r.chain(dstChainID)
+	if err != nil {
+		return err
+	}
+
+	clientID, err := createClient(ctx, tracked, host, opts)
+	if err != nil {
+		return err
+	}
+
+	switch srcChainID {
+	case p.src.chainID:
+		p.src.clientID = clientID
+	case p.dst.chainID:
+		p.dst.clientID = clientID
+	default:
+		return fmt.Errorf("chain %s is not part of path %s", srcChainID, pathName)
+	}
+	r.setPath(pathName, p)
+	return nil
+}
+
+func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	defer track(rep, time.Now(), &err, "tx", "connection", pathName)
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.src.clientID == "" || p.dst.clientID == "" {
+		return fmt.Errorf("path %s has no clients", pathName)
+	}
+	if err := createConnection(ctx, src, dst, &p); err != nil {
+		return err
+	}
+	r.setPath(pathName, p)
+	return nil
+}
+
+func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (err error) {
+	defer track(rep, time.Now(), &err, "tx", "channel", pathName, opts.SourcePortName, opts.DestPortName)
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.src.connectionID == "" || p.dst.connectionID == "" {
+		return fmt.Errorf("path %s has no connection", pathName)
+	}
+	return createChannel(ctx, src, dst, &p, opts)
+}
+
+func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	defer track(rep, time.Now(), &err, "tx", "update-clients", pathName)
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	for _, u := range []struct {
+		tracked, host *chain
+		clientID      string
+	}{
+		{src, dst, p.dst.clientID},
+		{dst, src, p.src.clientID},
+	} {
+		_, msg, err := updateClient(ctx, u.tracked, u.host, u.clientID)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			continue
+		}
+		if _, err := u.host.sendMsgs(ctx, msg); err != nil {
+			return fmt.Errorf("failed to update client %s on %s: %w", u.clientID, u.host.cfg.ChainID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (_ []ibc.ChannelOutput, err error) {
+	defer track(rep, time.Now(), &err, "query", "channels", chainID)
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := chantypes.NewQueryClient(c.grpc).Channels(ctx, &chantypes.QueryChannelsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels on %s: %w", chainID, err)
+	}
+
+	out := make([]ibc.ChannelOutput, len(res.Channels))
+	for i, ch := range res.Channels {
+		out[i] = ibc.ChannelOutput{
+			State:    ch.State.String(),
+			Ordering: ch.Ordering.String(),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.Counterparty.PortId,
+				ChannelID: ch.Counterparty.ChannelId,
+			},
+			ConnectionHops: ch.ConnectionHops,
+			Version:        ch.Version,
+			PortID:         ch.PortId,
+			ChannelID:      ch.ChannelId,
+		}
+	}
+	return out, nil
+}
+
+func (r *Relayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (_ ibc.ConnectionOutputs, err error) {
+	defer track(rep, time.Now(), &err, "query", "connections", chainID)
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := conntypes.NewQueryClient(c.grpc).Connections(ctx, &conntypes.QueryConnectionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections on %s: %w", chainID, err)
+	}
+
+	out := make(ibc.ConnectionOutputs, len(res.Connections))
+	for i, conn := range res.Connections {
+		counterparty := conn.Counterparty
+		out[i] = &ibc.ConnectionOutput{
+			ID:           conn.Id,
+			ClientID:     conn.ClientId,
+			Versions:     conn.Versions,
+			State:        conn.State.String(),
+			Counterparty: &counterparty,
+			DelayPeriod:  fmt.Sprint(conn.DelayPeriod),
+		}
+	}
+	return out, nil
+}
+
+func (r *Relayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (_ ibc.ClientOutputs, err error) {
+	defer track(rep, time.Now(), &err, "query", "clients", chainID)
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := clienttypes.NewQueryClient(c.grpc).ClientStates(ctx, &clienttypes.QueryClientStatesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clients on %s: %w", chainID, err)
+	}
+
+	var out ibc.ClientOutputs
+	for _, ics := range res.ClientStates {
+		cs, err := clienttypes.UnpackClientState(ics.ClientState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack client %s on %s: %w", ics.ClientId, chainID, err)
+		}
+		var trackedChainID string
+		if tmcs, ok := cs.(*ibctm.ClientState); ok {
+			trackedChainID = tmcs.ChainId
+		}
+		out = append(out, &ibc.ClientOutput{
+			ClientID:    ics.ClientId,
+			ClientState: ibc.ClientState{ChainID: trackedChainID},
+		})
+	}
+	return out, nil
+}
+
+// relayPath relays every pending packet and acknowledgement in both directions
+// over the channels of the path, or only over channelID on the path's source chain if it is not empty.
+func (r *Relayer) relayPath(ctx context.Context, pathName, channelID string) error {
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.src.connectionID == "" {
+		return fmt.Errorf("path %s has no connection", pathName)
+	}
+
+	filter := p.filter
+	if channelID != "" {
+		filter = &ibc.ChannelFilter{Rule: "allowlist", ChannelList: []string{channelID}}
+	}
+	channels, err := openChannels(ctx, src, p.src.connectionID, filter)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ch := range channels {
+		// Deliver packets first, so that synchronous acknowledgements can be relayed in the same pass.
+		if _, err := relayPackets(ctx, src, dst, p.src.clientID, p.dst.clientID, ch, nil); err != nil {
+			errs = append(errs, err)
+		}
+		if _, err := relayPackets(ctx, dst, src, p.dst.clientID, p.src.clientID, ch.reverse(), nil); err != nil {
+			errs = append(errs, err)
+		}
+		if _, err := relayAcks(ctx, src, dst, p.src.clientID, ch, nil); err != nil {
+			errs = append(errs, err)
+		}
+		if _, err := relayAcks(ctx, dst, src, p.dst.clientID, ch.reverse(), nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Relayer) Flush(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	defer track(rep, time.Now(), &err, "tx", "flush", pathName, channelID)
+	return r.relayPath(ctx, pathName, channelID)
+}
+
+// direction is one direction of relaying over a channel of a path.
+type direction struct {
+	src, dst                 *chain
+	srcClientID, dstClientID string
+
+	// The channel as seen from src.
+	ch channel
+}
+
+// direction returns the direction of relaying for packets sent from srcChainID over channelID.
+func (r *Relayer) direction(ctx context.Context, pathName, srcChainID, channelID string) (direction, error) {
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return direction{}, err
+	}
+
+	d := direction{src: src, dst: dst, srcClientID: p.src.clientID, dstClientID: p.dst.clientID}
+	connectionID := p.src.connectionID
+	switch srcChainID {
+	case p.src.chainID:
+	case p.dst.chainID:
+		d = direction{src: dst, dst: src, srcClientID: p.dst.clientID, dstClientID: p.src.clientID}
+		connectionID = p.dst.connectionID
+	default:
+		return direction{}, fmt.Errorf("chain %s is not part of path %s", srcChainID, pathName)
+	}
+	if connectionID == "" {
+		return direction{}, fmt.Errorf("path %s has no connection", pathName)
+	}
+
+	channels, err := openChannels(ctx, d.src, connectionID, nil)
+	if err != nil {
+		return direction{}, err
+	}
+	for _, ch := range channels {
+		if ch.channelID == channelID {
+			d.ch = ch
+			return d, nil
+		}
+	}
+	return direction{}, fmt.Errorf("no open channel %s on %s for path %s", channelID, srcChainID, pathName)
+}
+
+func (r *Relayer) RelayPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) (_ []uint64, err error) {
+	defer track(rep, time.Now(), &err, "tx", "relay-packets", pathName, srcChainID, channelID, fmt.Sprint(sequences))
+
+	d, err := r.direction(ctx, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return relayPackets(ctx, d.src, d.dst, d.srcClientID, d.dstClientID, d.ch, sequences)
+}
+
+func (r *Relayer) RelayAcks(ctx context.Context, rep ibc.RelayerExecReporter, pathName, srcChainID, channelID string, sequences ...uint64) (_ []uint64, err error) {
+	defer track(rep, time.Now(), &err, "tx", "relay-acks", pathName, srcChainID, channelID, fmt.Sprint(sequences))
+
+	d, err := r.direction(ctx, pathName, srcChainID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	return relayAcks(ctx, d.src, d.dst, d.srcClientID, d.ch, sequences)
+}
+
+func (r *Relayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	defer track(rep, time.Now(), nil, append([]string{"start"}, pathNames...)...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return fmt.Errorf("tried to start relayer again without stopping first")
+	}
+	for _, name := range pathNames {
+		if _, ok := r.paths[name]; !ok {
+			return fmt.Errorf("path %s not found", name)
+		}
+	}
+
+	// The relayer keeps running after the caller's context is done, until StopRelayer.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	r.cancel = cancel
+	r.paused.Store(false)
+
+	for _, name := range pathNames {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.run(runCtx, name)
+		}()
+	}
+	return nil
+}
+
+// run relays pathName every poll interval until ctx is done.
+func (r *Relayer) run(ctx context.Context, pathName string) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if r.paused.Load() {
+			continue
+		}
+		if err := r.relayPath(ctx, pathName, ""); err != nil && ctx.Err() == nil {
+			// Transient failures, such as a packet timing out while being received, are retried next tick.
+			r.log.Info("Failed to relay path", zap.String("path", pathName), zap.Error(err))
+		}
+	}
+}
+
+func (r *Relayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	defer track(rep, time.Now(), nil, "stop")
+
+	r.mu.Lock()
+	cancel := r.cancel
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	r.wg.Wait()
+	return nil
+}
+
+func (r *Relayer) PauseRelayer(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel == nil {
+		return fmt.Errorf("relayer not running")
+	}
+	r.paused.Store(true)
+	return nil
+}
+
+func (r *Relayer) ResumeRelayer(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel == nil {
+		return fmt.Errorf("relayer not running")
+	}
+	r.paused.Store(false)
+	return nil
+}
+
+// UseDockerNetwork reports false, as the relayer runs on the host
+// and reaches chains through their host-exposed ports.
+func (r *Relayer) UseDockerNetwork() bool {
+	return false
+}
+
+// Exec is not supported, as the in-process relayer has no command line.
+func (r *Relayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string, env []string) ibc.RelayerExecResult {
+	err := fmt.Errorf("%s relayer does not support Exec", Name)
+	track(rep, time.Now(), &err, cmd...)
+	return ibc.RelayerExecResult{Err: err}
+}
+
+// SetClientContractHash is not supported, as the in-process relayer only creates 07-tendermint clients.
+func (r *Relayer) SetClientContractHash(ctx context.Context, rep ibc.RelayerExecReporter, cfg ibc.ChainConfig, hash string) error {
+	return fmt.Errorf("%s relayer does not support 08-wasm clients", Name)
+}
+
+// ContainerImage returns an empty image, as the in-process relayer does not run in a container.
+func (r *Relayer) ContainerImage() ibc.DockerImage {
+	return ibc.DockerImage{}
+}
@@ -75,8 +75,10 @@ const (
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	caps := relayer.FullCapabilities()
+	// rly relays every pending packet on a channel and does not report sequences.
+	caps[relayer.RelayPacketSequences] = false
+	return caps
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
@@ -222,6 +224,20 @@ func (commander) Flush(pathName, channelID, homeDir string) []string {
 	return cmd
 }
 
+func (commander) RelayPackets(pathName, channelID, homeDir string) []string {
+	return []string{
+		"rly", "tx", "relay-packets", pathName, channelID,
+		"--home", homeDir,
+	}
+}
+
+func (commander) RelayAcks(pathName, channelID, homeDir string) []string {
+	return []string{
+		"rly", "tx", "relay-acknowledgements", pathName, channelID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
 	return []string{
 		"rly", "paths", "new", srcChainID, dstChainID, pathName,
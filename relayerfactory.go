@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/interchaintest/v11/ibc"
 	"github.com/cosmos/interchaintest/v11/relayer"
 	"github.com/cosmos/interchaintest/v11/relayer/hermes"
+	"github.com/cosmos/interchaintest/v11/relayer/inprocess"
 	"github.com/cosmos/interchaintest/v11/relayer/rly"
 )
 
@@ -73,6 +74,9 @@ func (f *builtinRelayerFactory) Build(
 	case ibc.Hermes:
 		r := hermes.NewHermesRelayer(f.log, t.Name(), cli, networkID, f.options...)
 		return r
+	case ibc.InProcess:
+		// Options for Docker relayers do not apply.
+		return inprocess.NewRelayer(f.log, t.Name())
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -98,6 +102,8 @@ func (f *builtinRelayerFactory) Name() string {
 			return "hermes@" + f.version
 		}
 		return "hermes@" + hermes.DefaultContainerVersion
+	case ibc.InProcess:
+		return inprocess.Name
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -112,6 +118,8 @@ func (f *builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.Hermes:
 		// TODO: specify capability for hermes.
 		return rly.Capabilities()
+	case ibc.InProcess:
+		return inprocess.Capabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
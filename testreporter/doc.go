@@ -73,4 +73,15 @@
 //
 // If you use a plain require.NoError(t, err) call,
 // the report will note that the test failed, but the report will not include the error line.
+//
+// The reporter can also profile the gas used by transactions.
+// Pass the reporter's GasProfiler to the interchain build options,
+// and the gas used per test, chain and message type is included as a "GasSummaryMessage" when the reporter is closed.
+// A GasProfiler can also be used on its own and written to a JSON file,
+// so that CI can compare it against a previous run with CompareGas.
+//
+//	ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+//	  // ...
+//	  GasProfiler: reporter.GasProfiler(),
+//	})
 package testreporter
@@ -0,0 +1,181 @@
+package testreporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GasRecord is the gas consumed by a single committed transaction.
+type GasRecord struct {
+	TestName string
+	ChainID  string
+	TxHash   string
+
+	// MsgTypeURLs are the type URLs of the transaction's messages, in order.
+	MsgTypeURLs []string
+
+	GasWanted, GasUsed int64
+}
+
+// MsgType is the key the record is aggregated under:
+// the message's type URL, or the comma separated type URLs of a multi-message transaction.
+func (r GasRecord) MsgType() string {
+	if len(r.MsgTypeURLs) == 0 {
+		return "unknown"
+	}
+	return strings.Join(r.MsgTypeURLs, ",")
+}
+
+// GasSummary aggregates the gas consumed by transactions of one message type, on one chain, in one test.
+type GasSummary struct {
+	TestName string
+	ChainID  string
+	MsgType  string
+
+	Count int
+
+	// Totals across all Count transactions.
+	GasWanted, GasUsed int64
+
+	MinGasUsed, MaxGasUsed, MeanGasUsed int64
+}
+
+func (s GasSummary) key() string {
+	return s.TestName + "\x00" + s.ChainID + "\x00" + s.MsgType
+}
+
+// GasProfiler collects the gas consumed by transactions across a test run.
+// It is safe for concurrent use, and a nil *GasProfiler discards everything it is given.
+//
+// Chains which support profiling record their transactions once a GasProfiler is set
+// through InterchainBuildOptions or the chain's SetGasProfiler method.
+type GasProfiler struct {
+	mu      sync.Mutex
+	records []GasRecord
+}
+
+// NewGasProfiler returns an empty GasProfiler.
+func NewGasProfiler() *GasProfiler {
+	return &GasProfiler{}
+}
+
+// Track records the gas consumed by a committed transaction.
+func (p *GasProfiler) Track(rec GasRecord) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, rec)
+}
+
+// Records returns a copy of every record tracked so far, in the order they were tracked.
+func (p *GasProfiler) Records() []GasRecord {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]GasRecord(nil), p.records...)
+}
+
+// Summary aggregates the tracked records per test, chain and message type,
+// sorted by those fields.
+func (p *GasProfiler) Summary() []GasSummary {
+	byKey := make(map[string]*GasSummary)
+	for _, rec := range p.Records() {
+		s := GasSummary{TestName: rec.TestName, ChainID: rec.ChainID, MsgType: rec.MsgType()}
+		agg, ok := byKey[s.key()]
+		if !ok {
+			s.MinGasUsed = rec.GasUsed
+			agg = &s
+			byKey[s.key()] = agg
+		}
+		agg.Count++
+		agg.GasWanted += rec.GasWanted
+		agg.GasUsed += rec.GasUsed
+		agg.MinGasUsed = min(agg.MinGasUsed, rec.GasUsed)
+		agg.MaxGasUsed = max(agg.MaxGasUsed, rec.GasUsed)
+	}
+
+	summaries := make([]GasSummary, 0, len(byKey))
+	for _, s := range byKey {
+		s.MeanGasUsed = s.GasUsed / int64(s.Count)
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].key() < summaries[j].key()
+	})
+	return summaries
+}
+
+// WriteJSON writes the profiler's Summary to w as a JSON array.
+func (p *GasProfiler) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p.Summary())
+}
+
+// WriteFile writes the profiler's Summary to the JSON file at path,
+// which can later be read back with ReadGasSummary.
+func (p *GasProfiler) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create gas summary file: %w", err)
+	}
+	if err := p.WriteJSON(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write gas summary: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadGasSummary reads a summary previously written by (*GasProfiler).WriteJSON.
+func ReadGasSummary(r io.Reader) ([]GasSummary, error) {
+	var summaries []GasSummary
+	if err := json.NewDecoder(r).Decode(&summaries); err != nil {
+		return nil, fmt.Errorf("decode gas summary: %w", err)
+	}
+	return summaries, nil
+}
+
+// GasRegression is a message type whose mean gas used grew between two runs.
+type GasRegression struct {
+	Base, Head GasSummary
+
+	// Increase is the relative increase of the mean gas used, e.g. 0.1 for 10%.
+	Increase float64
+}
+
+func (r GasRegression) String() string {
+	return fmt.Sprintf("%s on %s in %s: mean gas used %d -> %d (+%.1f%%)",
+		r.Head.MsgType, r.Head.ChainID, r.Head.TestName, r.Base.MeanGasUsed, r.Head.MeanGasUsed, r.Increase*100)
+}
+
+// CompareGas returns the entries of head whose mean gas used increased by more than threshold
+// relative to the matching entry of base, e.g. a threshold of 0.05 flags increases above 5%.
+// Entries only present in one of the runs are ignored.
+func CompareGas(base, head []GasSummary, threshold float64) []GasRegression {
+	baseByKey := make(map[string]GasSummary, len(base))
+	for _, s := range base {
+		baseByKey[s.key()] = s
+	}
+
+	var regressions []GasRegression
+	for _, h := range head {
+		b, ok := baseByKey[h.key()]
+		if !ok || b.MeanGasUsed <= 0 {
+			continue
+		}
+		increase := float64(h.MeanGasUsed-b.MeanGasUsed) / float64(b.MeanGasUsed)
+		if increase > threshold {
+			regressions = append(regressions, GasRegression{Base: b, Head: h, Increase: increase})
+		}
+	}
+	return regressions
+}
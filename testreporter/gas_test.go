@@ -0,0 +1,72 @@
+package testreporter_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/interchaintest/v11/testreporter"
+)
+
+const msgSend = "/cosmos.bank.v1beta1.MsgSend"
+
+func TestGasProfiler_Summary(t *testing.T) {
+	t.Parallel()
+
+	p := testreporter.NewGasProfiler()
+	p.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{msgSend}, GasWanted: 200, GasUsed: 100})
+	p.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{msgSend}, GasWanted: 200, GasUsed: 150})
+	p.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-2", MsgTypeURLs: []string{msgSend, msgSend}, GasWanted: 400, GasUsed: 300})
+	p.Track(testreporter.GasRecord{TestName: "b", ChainID: "chain-1"})
+
+	require.Equal(t, []testreporter.GasSummary{
+		{TestName: "a", ChainID: "chain-1", MsgType: msgSend, Count: 2, GasWanted: 400, GasUsed: 250, MinGasUsed: 100, MaxGasUsed: 150, MeanGasUsed: 125},
+		{TestName: "a", ChainID: "chain-2", MsgType: msgSend + "," + msgSend, Count: 1, GasWanted: 400, GasUsed: 300, MinGasUsed: 300, MaxGasUsed: 300, MeanGasUsed: 300},
+		{TestName: "b", ChainID: "chain-1", MsgType: "unknown", Count: 1},
+	}, p.Summary())
+
+	var nilProfiler *testreporter.GasProfiler
+	nilProfiler.Track(testreporter.GasRecord{GasUsed: 1})
+	require.Empty(t, nilProfiler.Summary())
+}
+
+func TestGasProfiler_CompareRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	base := testreporter.NewGasProfiler()
+	base.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{msgSend}, GasUsed: 100})
+	base.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{"/cosmos.staking.v1beta1.MsgDelegate"}, GasUsed: 100})
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, base.WriteJSON(buf))
+	baseSummary, err := testreporter.ReadGasSummary(buf)
+	require.NoError(t, err)
+	require.Equal(t, base.Summary(), baseSummary)
+
+	head := testreporter.NewGasProfiler()
+	head.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{msgSend}, GasUsed: 120})
+	head.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{"/cosmos.staking.v1beta1.MsgDelegate"}, GasUsed: 104})
+	head.Track(testreporter.GasRecord{TestName: "a", ChainID: "chain-1", MsgTypeURLs: []string{"/cosmos.gov.v1.MsgVote"}, GasUsed: 1000})
+
+	regressions := testreporter.CompareGas(baseSummary, head.Summary(), 0.05)
+	require.Len(t, regressions, 1)
+	require.Equal(t, msgSend, regressions[0].Head.MsgType)
+	require.InDelta(t, 0.2, regressions[0].Increase, 1e-9)
+}
+
+func TestReporter_GasSummary(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+	r.GasProfiler().Track(testreporter.GasRecord{TestName: "my_test", ChainID: "chain-1", MsgTypeURLs: []string{msgSend}, GasUsed: 100})
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 3)
+
+	gasMsg := msgs[1].(testreporter.GasSummaryMessage)
+	require.Equal(t, r.GasProfiler().Summary(), gasMsg.Summaries)
+	require.IsType(t, testreporter.FinishSuiteMessage{}, msgs[2])
+}
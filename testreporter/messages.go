@@ -133,6 +133,16 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// GasSummaryMessage is tracked when the Reporter is closed,
+// if its GasProfiler recorded any transactions.
+type GasSummaryMessage struct {
+	Summaries []GasSummary
+}
+
+func (m GasSummaryMessage) typ() string {
+	return "GasSummary"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -199,6 +209,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "GasSummary":
+		x := GasSummaryMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
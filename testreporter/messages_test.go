@@ -40,6 +40,13 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.GasSummaryMessage{
+				Summaries: []testreporter.GasSummary{
+					{TestName: "foo", ChainID: "chain-1", MsgType: "/cosmos.bank.v1beta1.MsgSend", Count: 1, GasWanted: 200, GasUsed: 100, MinGasUsed: 100, MaxGasUsed: 100, MeanGasUsed: 100},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tcs {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -27,6 +28,9 @@ type Reporter struct {
 	in chan Message
 
 	writerDone chan error
+
+	gasOnce     sync.Once
+	gasProfiler *GasProfiler
 }
 
 func NewReporter(w io.WriteCloser) *Reporter {
@@ -62,6 +66,9 @@ func (r *Reporter) write() {
 // Close closes the reporter and blocks until its results are flushed
 // to the underlying writer.
 func (r *Reporter) Close() error {
+	if summaries := r.gasProfiler.Summary(); len(summaries) > 0 {
+		r.in <- GasSummaryMessage{Summaries: summaries}
+	}
 	r.in <- FinishSuiteMessage{
 		FinishedAt: time.Now(),
 	}
@@ -118,6 +125,15 @@ func (r *Reporter) TrackSkip(t T, format string, args ...any) {
 	t.Skip(msg)
 }
 
+// GasProfiler returns the reporter's GasProfiler.
+// Its summary is tracked as a GasSummaryMessage when the reporter is closed.
+func (r *Reporter) GasProfiler() *GasProfiler {
+	r.gasOnce.Do(func() {
+		r.gasProfiler = NewGasProfiler()
+	})
+	return r.gasProfiler
+}
+
 // RelayerExecReporter returns a RelayerExecReporter associated with t.
 func (r *Reporter) RelayerExecReporter(t T) *RelayerExecReporter {
 	return &RelayerExecReporter{r: r, testName: t.Name()}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
@@ -57,34 +58,38 @@ type ChainAcker interface {
 	Acknowledgements(ctx context.Context, height int64) ([]ibc.PacketAcknowledgement, error)
 }
 
+// ChainAckerV2 is a chain that can get its IBC v2 acknowledgements at a specified height.
+type ChainAckerV2 interface {
+	ChainHeighter
+	AcknowledgementsV2(ctx context.Context, height int64) ([]ibc.PacketAcknowledgementV2, error)
+}
+
 // PollForAck attempts to find an acknowledgement containing a packet equal to the packet argument.
 // Polling starts at startHeight and continues until maxHeight. It is safe to call this function even if
 // the chain has yet to produce blocks for the target min/max height range. Polling delays until heights exist
 // on the chain. Returns an error if acknowledgement not found or problems getting height or acknowledgements.
-func PollForAck(ctx context.Context, chain ChainAcker, startHeight, maxHeight int64, packet ibc.Packet) (ibc.PacketAcknowledgement, error) {
-	var zero ibc.PacketAcknowledgement
-	pollError := &packetPollError{targetPacket: packet}
-	poll := func(ctx context.Context, height int64) (ibc.PacketAcknowledgement, error) {
-		acks, err := chain.Acknowledgements(ctx, height)
-		if err != nil {
-			return zero, err
+//
+// The packet may be an ibc.Packet or an ibc.PacketV2. Polling for an ibc.PacketV2 requires chain to also
+// implement ChainAckerV2, and the packet to be relayed by the test, as no relayer relays IBC v2 packets yet.
+func PollForAck[P ibc.AnyPacket](ctx context.Context, chain ChainAcker, startHeight, maxHeight int64, packet P) (ibc.AckOf[P], error) {
+	var find func(ctx context.Context, height int64) ([]ibc.AckOf[P], error)
+	switch any(packet).(type) {
+	case ibc.PacketV2:
+		v2, ok := chain.(ChainAckerV2)
+		if !ok {
+			return ibc.AckOf[P]{}, fmt.Errorf("chain %T cannot get IBC v2 acknowledgements", chain)
 		}
-		for _, ack := range acks {
-			pollError.PushSearched(ack)
-			if ack.Packet.Equal(packet) {
-				return ack, nil
-			}
+		find = func(ctx context.Context, height int64) ([]ibc.AckOf[P], error) {
+			acks, err := v2.AcknowledgementsV2(ctx, height)
+			return any(acks).([]ibc.AckOf[P]), err
+		}
+	default:
+		find = func(ctx context.Context, height int64) ([]ibc.AckOf[P], error) {
+			acks, err := chain.Acknowledgements(ctx, height)
+			return any(acks).([]ibc.AckOf[P]), err
 		}
-		return zero, ErrNotFound
-	}
-
-	poller := BlockPoller[ibc.PacketAcknowledgement]{CurrentHeight: chain.Height, PollFunc: poll}
-	found, err := poller.DoPoll(ctx, startHeight, maxHeight)
-	if err != nil {
-		pollError.SetErr(err)
-		return zero, pollError
 	}
-	return found, nil
+	return pollForPacket(ctx, chain, startHeight, maxHeight, packet, find, func(ack ibc.AckOf[P]) P { return ack.Packet })
 }
 
 // ChainTimeouter is a chain that can get its timeouts at a specified height.
@@ -93,26 +98,61 @@ type ChainTimeouter interface {
 	Timeouts(ctx context.Context, height int64) ([]ibc.PacketTimeout, error)
 }
 
+// ChainTimeouterV2 is a chain that can get its IBC v2 timeouts at a specified height.
+type ChainTimeouterV2 interface {
+	ChainHeighter
+	TimeoutsV2(ctx context.Context, height int64) ([]ibc.PacketTimeoutV2, error)
+}
+
 // PollForTimeout attempts to find a timeout containing a packet equal to the packet argument.
 // Otherwise, works identically to PollForAck.
-func PollForTimeout(ctx context.Context, chain ChainTimeouter, startHeight, maxHeight int64, packet ibc.Packet) (ibc.PacketTimeout, error) {
+func PollForTimeout[P ibc.AnyPacket](ctx context.Context, chain ChainTimeouter, startHeight, maxHeight int64, packet P) (ibc.TimeoutOf[P], error) {
+	var find func(ctx context.Context, height int64) ([]ibc.TimeoutOf[P], error)
+	switch any(packet).(type) {
+	case ibc.PacketV2:
+		v2, ok := chain.(ChainTimeouterV2)
+		if !ok {
+			return ibc.TimeoutOf[P]{}, fmt.Errorf("chain %T cannot get IBC v2 timeouts", chain)
+		}
+		find = func(ctx context.Context, height int64) ([]ibc.TimeoutOf[P], error) {
+			timeouts, err := v2.TimeoutsV2(ctx, height)
+			return any(timeouts).([]ibc.TimeoutOf[P]), err
+		}
+	default:
+		find = func(ctx context.Context, height int64) ([]ibc.TimeoutOf[P], error) {
+			timeouts, err := chain.Timeouts(ctx, height)
+			return any(timeouts).([]ibc.TimeoutOf[P]), err
+		}
+	}
+	return pollForPacket(ctx, chain, startHeight, maxHeight, packet, find, func(t ibc.TimeoutOf[P]) P { return t.Packet })
+}
+
+// pollForPacket polls find at each height until it returns a result whose packet equals the target packet.
+func pollForPacket[P ibc.AnyPacket, T any](
+	ctx context.Context,
+	chain ChainHeighter,
+	startHeight, maxHeight int64,
+	packet P,
+	find func(ctx context.Context, height int64) ([]T, error),
+	packetOf func(T) P,
+) (T, error) {
+	var zero T
 	pollError := &packetPollError{targetPacket: packet}
-	var zero ibc.PacketTimeout
-	poll := func(ctx context.Context, height int64) (ibc.PacketTimeout, error) {
-		timeouts, err := chain.Timeouts(ctx, height)
+	poll := func(ctx context.Context, height int64) (T, error) {
+		results, err := find(ctx, height)
 		if err != nil {
 			return zero, err
 		}
-		for _, t := range timeouts {
-			pollError.PushSearched(t)
-			if t.Packet.Equal(packet) {
-				return t, nil
+		for _, r := range results {
+			pollError.PushSearched(r)
+			if reflect.DeepEqual(packetOf(r), packet) {
+				return r, nil
 			}
 		}
 		return zero, ErrNotFound
 	}
 
-	poller := BlockPoller[ibc.PacketTimeout]{CurrentHeight: chain.Height, PollFunc: poll}
+	poller := BlockPoller[T]{CurrentHeight: chain.Height, PollFunc: poll}
 	found, err := poller.DoPoll(ctx, startHeight, maxHeight)
 	if err != nil {
 		pollError.SetErr(err)
@@ -123,7 +163,7 @@ func PollForTimeout(ctx context.Context, chain ChainTimeouter, startHeight, maxH
 
 type packetPollError struct {
 	error
-	targetPacket    ibc.Packet
+	targetPacket    any
 	searchedPackets []string
 }
 
@@ -23,6 +23,9 @@ type mockChain struct {
 
 	FoundTimeouts []ibc.PacketTimeout
 	TimeoutErr    error
+
+	FoundAcksV2     []ibc.PacketAcknowledgementV2
+	FoundTimeoutsV2 []ibc.PacketTimeoutV2
 }
 
 func (m *mockChain) Height(ctx context.Context) (int64, error) {
@@ -50,6 +53,22 @@ func (m *mockChain) Timeouts(ctx context.Context, height int64) ([]ibc.PacketTim
 	return m.FoundTimeouts, m.TimeoutErr
 }
 
+func (m *mockChain) AcknowledgementsV2(ctx context.Context, height int64) ([]ibc.PacketAcknowledgementV2, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotHeights = append(m.GotHeights, height)
+	return m.FoundAcksV2, m.AckErr
+}
+
+func (m *mockChain) TimeoutsV2(ctx context.Context, height int64) ([]ibc.PacketTimeoutV2, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	m.GotHeights = append(m.GotHeights, height)
+	return m.FoundTimeoutsV2, m.TimeoutErr
+}
+
 func TestPollForAck(t *testing.T) {
 	ctx := context.Background()
 
@@ -108,6 +127,27 @@ func TestPollForAck(t *testing.T) {
 			_, _ = PollForAck(ctx, &mockChain{}, 10, 1, ibc.Packet{})
 		})
 	})
+
+	t.Run("v2 happy path", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundAcksV2: []ibc.PacketAcknowledgementV2{
+			{Packet: ibc.PacketV2{Sequence: 44, SourceClient: "other"}},
+			{Packet: ibc.PacketV2{Sequence: 33, SourceClient: "found"}, AppAcknowledgements: [][]byte{[]byte(`ack`)}},
+		}}
+		got, err := PollForAck(ctx, &chain, 3, 5, ibc.PacketV2{Sequence: 33, SourceClient: "found"})
+
+		require.NoError(t, err)
+		require.Equal(t, "found", got.Packet.SourceClient)
+		require.Equal(t, [][]byte{[]byte(`ack`)}, got.AppAcknowledgements)
+		require.Equal(t, []int64{3}, chain.GotHeights)
+	})
+
+	t.Run("v2 unsupported", func(t *testing.T) {
+		chain := struct{ ChainAcker }{&mockChain{CurrentHeight: 1}}
+		_, err := PollForAck(ctx, chain, 1, 3, ibc.PacketV2{Sequence: 1})
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot get IBC v2 acknowledgements")
+	})
 }
 
 func TestPollForTimeout(t *testing.T) {
@@ -160,4 +200,24 @@ func TestPollForTimeout(t *testing.T) {
 			_, _ = PollForTimeout(ctx, &mockChain{}, 10, 1, ibc.Packet{})
 		})
 	})
+
+	t.Run("v2 happy path", func(t *testing.T) {
+		chain := mockChain{CurrentHeight: 1, FoundTimeoutsV2: []ibc.PacketTimeoutV2{
+			{Packet: ibc.PacketV2{Sequence: 44, SourceClient: "other"}},
+			{Packet: ibc.PacketV2{Sequence: 33, SourceClient: "found"}},
+		}}
+		got, err := PollForTimeout(ctx, &chain, 3, 5, ibc.PacketV2{Sequence: 33, SourceClient: "found"})
+
+		require.NoError(t, err)
+		require.Equal(t, "found", got.Packet.SourceClient)
+		require.Equal(t, []int64{3}, chain.GotHeights)
+	})
+
+	t.Run("v2 unsupported", func(t *testing.T) {
+		chain := struct{ ChainTimeouter }{&mockChain{CurrentHeight: 1}}
+		_, err := PollForTimeout(ctx, chain, 1, 3, ibc.PacketV2{Sequence: 1})
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot get IBC v2 timeouts")
+	})
 }
@@ -0,0 +1,306 @@
+package interchaintest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/interchaintest/v11/ibc"
+)
+
+// TopologyEdge is a single IBC connection between two chains in a Topology.
+type TopologyEdge struct {
+	Chain1, Chain2 ibc.Chain
+
+	// Relayers for this edge. If empty, the Topology's Relayers are used.
+	// The first relayer creates the clients, connections and channel;
+	// every other relayer is given a path over the same identifiers,
+	// so that redundant relayers compete to relay the same packets.
+	Relayers []ibc.Relayer
+
+	// If set, these options will be used when creating the client in the path link step.
+	// If a zero value initialization is used, e.g. CreateClientOptions{},
+	// then the default values will be used via ibc.DefaultClientOpts.
+	CreateClientOpts ibc.CreateClientOptions
+
+	// If set, these options will be used when creating the channel in the path link step.
+	// If a zero value initialization is used, e.g. CreateChannelOptions{},
+	// then the default values will be used via ibc.DefaultChannelOpts.
+	CreateChannelOpts ibc.CreateChannelOptions
+}
+
+// Topology declaratively describes a set of IBC connections between chains.
+// Use StarTopology, LineTopology, or MeshTopology for the common shapes,
+// or populate Edges directly for an arbitrary graph,
+// then pass it to (*Interchain).AddTopology.
+type Topology struct {
+	Edges []TopologyEdge
+
+	// Relayers to use for any edge that does not specify its own.
+	Relayers []ibc.Relayer
+
+	// Optional prefix for the generated relayer path names.
+	// Path names are otherwise "<chain1 ID>-<chain2 ID>".
+	PathPrefix string
+}
+
+// StarTopology returns a Topology connecting hub to each of the spokes.
+func StarTopology(hub ibc.Chain, spokes ...ibc.Chain) Topology {
+	var t Topology
+	for _, s := range spokes {
+		t.Edges = append(t.Edges, TopologyEdge{Chain1: hub, Chain2: s})
+	}
+	return t
+}
+
+// LineTopology returns a Topology connecting each chain to the next, in order.
+func LineTopology(chains ...ibc.Chain) Topology {
+	var t Topology
+	for i := 1; i < len(chains); i++ {
+		t.Edges = append(t.Edges, TopologyEdge{Chain1: chains[i-1], Chain2: chains[i]})
+	}
+	return t
+}
+
+// MeshTopology returns a Topology connecting every chain to every other chain.
+func MeshTopology(chains ...ibc.Chain) Topology {
+	var t Topology
+	for i := range chains {
+		for j := i + 1; j < len(chains); j++ {
+			t.Edges = append(t.Edges, TopologyEdge{Chain1: chains[i], Chain2: chains[j]})
+		}
+	}
+	return t
+}
+
+// WithRelayers returns a copy of t whose default relayers are rs.
+func (t Topology) WithRelayers(rs ...ibc.Relayer) Topology {
+	t.Relayers = rs
+	return t
+}
+
+// WithPathPrefix returns a copy of t whose generated path names begin with prefix.
+func (t Topology) WithPathPrefix(prefix string) Topology {
+	t.PathPrefix = prefix
+	return t
+}
+
+// PathName returns the relayer path name that AddTopology uses for the given edge.
+func (t Topology) PathName(e TopologyEdge) string {
+	return t.PathPrefix + e.Chain1.Config().ChainID + "-" + e.Chain2.Config().ChainID
+}
+
+// AddTopology expands the topology into links and adds them to the Interchain.
+// Every chain and relayer referenced by the topology must already have been added.
+// If any validation fails, AddTopology panics.
+func (ic *Interchain) AddTopology(t Topology) *Interchain {
+	for _, e := range t.Edges {
+		relayers := e.Relayers
+		if len(relayers) == 0 {
+			relayers = t.Relayers
+		}
+		if len(relayers) == 0 {
+			panic(fmt.Errorf(
+				"no relayer for topology edge between %s and %s",
+				e.Chain1.Config().ChainID, e.Chain2.Config().ChainID,
+			))
+		}
+
+		primary := relayerPath{Relayer: relayers[0], Path: t.PathName(e)}
+		for i, r := range relayers {
+			ic.AddLink(InterchainLink{
+				Chain1:            e.Chain1,
+				Chain2:            e.Chain2,
+				Relayer:           r,
+				Path:              primary.Path,
+				CreateClientOpts:  e.CreateClientOpts,
+				CreateChannelOpts: e.CreateChannelOpts,
+			})
+
+			if i == 0 {
+				continue
+			}
+			key := relayerPath{Relayer: r, Path: primary.Path}
+			link := ic.links[key]
+			link.sharedFrom = &primary
+			ic.links[key] = link
+		}
+	}
+	return ic
+}
+
+// IBCHop describes one direction of an IBC channel between two chains.
+type IBCHop struct {
+	Src ibc.PathEnd `json:"src"`
+	Dst ibc.PathEnd `json:"dst"`
+
+	// Names of the relayers, as given to AddRelayer, with a path over this channel.
+	Relayers []string `json:"relayers"`
+}
+
+// Reverse returns the same channel as seen from the destination chain.
+func (h IBCHop) Reverse() IBCHop {
+	return IBCHop{Src: h.Dst, Dst: h.Src, Relayers: h.Relayers}
+}
+
+type hopKey struct {
+	SrcChainID, DstChainID, SrcPortID, SrcChannelID string
+}
+
+func (h IBCHop) key() hopKey {
+	return hopKey{SrcChainID: h.Src.ChainID, DstChainID: h.Dst.ChainID, SrcPortID: h.Src.PortID, SrcChannelID: h.Src.ChannelID}
+}
+
+// IBCGraph is a queryable view of the IBC channels between chains,
+// so that tests can look up identifiers for each hop instead of hard-coding them.
+type IBCGraph struct {
+	hops map[hopKey]IBCHop
+}
+
+// NewIBCGraph returns a graph containing the given hops and their reverse direction.
+func NewIBCGraph(hops ...IBCHop) *IBCGraph {
+	g := &IBCGraph{hops: make(map[hopKey]IBCHop, 2*len(hops))}
+	for _, h := range hops {
+		g.add(h)
+	}
+	return g
+}
+
+func (g *IBCGraph) add(h IBCHop) {
+	if existing, ok := g.hops[h.key()]; ok {
+		// Another relayer sharing the same channel.
+		h.Relayers = append(append([]string(nil), existing.Relayers...), h.Relayers...)
+		sort.Strings(h.Relayers)
+	}
+	g.hops[h.key()] = h
+
+	r := h.Reverse()
+	g.hops[r.key()] = r
+}
+
+// Hop returns the transfer channel hop from srcChainID to dstChainID.
+// If there are several transfer channels between the chains, the one with the lowest channel ID
+// on srcChainID is returned.
+func (g *IBCGraph) Hop(srcChainID, dstChainID string) (IBCHop, bool) {
+	return g.PortHop(srcChainID, dstChainID, ibc.DefaultChannelOpts().SourcePortName)
+}
+
+// PortHop returns the hop from srcChainID to dstChainID over the channel bound to portID on the source chain.
+// If there are several such channels, the one with the lowest channel ID on srcChainID is returned.
+func (g *IBCGraph) PortHop(srcChainID, dstChainID, portID string) (IBCHop, bool) {
+	hops := g.PortHops(srcChainID, dstChainID, portID)
+	if len(hops) == 0 {
+		return IBCHop{}, false
+	}
+	return hops[0], true
+}
+
+// PortHops returns every hop from srcChainID to dstChainID over a channel bound to portID on the source chain,
+// sorted by their channel ID on srcChainID.
+func (g *IBCGraph) PortHops(srcChainID, dstChainID, portID string) []IBCHop {
+	var out []IBCHop
+	for k, h := range g.hops {
+		if k.SrcChainID == srcChainID && k.DstChainID == dstChainID && k.SrcPortID == portID {
+			out = append(out, h)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].Src.ChannelID, out[j].Src.ChannelID
+		// Channel IDs end in a sequence number, so compare shorter IDs first.
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	return out
+}
+
+// ChannelHop returns the hop from srcChainID over the channel channelID on srcChainID, bound to portID.
+func (g *IBCGraph) ChannelHop(srcChainID, portID, channelID string) (IBCHop, bool) {
+	for k, h := range g.hops {
+		if k.SrcChainID == srcChainID && k.SrcPortID == portID && k.SrcChannelID == channelID {
+			return h, true
+		}
+	}
+	return IBCHop{}, false
+}
+
+// Neighbors returns the sorted IDs of chains with a transfer channel to chainID.
+func (g *IBCGraph) Neighbors(chainID string) []string {
+	port := ibc.DefaultChannelOpts().SourcePortName
+	seen := make(map[string]bool)
+	var out []string
+	for k := range g.hops {
+		if k.SrcChainID == chainID && k.SrcPortID == port && !seen[k.DstChainID] {
+			seen[k.DstChainID] = true
+			out = append(out, k.DstChainID)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Route returns the transfer channel hops visiting chainIDs in order,
+// e.g. to build a packet-forward-middleware memo.
+func (g *IBCGraph) Route(chainIDs ...string) ([]IBCHop, error) {
+	if len(chainIDs) < 2 {
+		return nil, fmt.Errorf("route requires at least 2 chains, got %d", len(chainIDs))
+	}
+	hops := make([]IBCHop, 0, len(chainIDs)-1)
+	for i := 1; i < len(chainIDs); i++ {
+		h, ok := g.Hop(chainIDs[i-1], chainIDs[i])
+		if !ok {
+			return nil, fmt.Errorf("no transfer channel from %s to %s", chainIDs[i-1], chainIDs[i])
+		}
+		hops = append(hops, h)
+	}
+	return hops, nil
+}
+
+// ShortestRoute returns the transfer channel hops of a shortest route from srcChainID to dstChainID.
+// Ties are broken by chain ID, so the result is deterministic.
+func (g *IBCGraph) ShortestRoute(srcChainID, dstChainID string) ([]IBCHop, error) {
+	prev := map[string]string{srcChainID: ""}
+	queue := []string{srcChainID}
+	for len(queue) > 0 && dstChainID != srcChainID {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == dstChainID {
+			break
+		}
+		for _, n := range g.Neighbors(cur) {
+			if _, seen := prev[n]; seen {
+				continue
+			}
+			prev[n] = cur
+			queue = append(queue, n)
+		}
+	}
+	if _, ok := prev[dstChainID]; !ok || srcChainID == dstChainID {
+		return nil, fmt.Errorf("no route from %s to %s", srcChainID, dstChainID)
+	}
+
+	route := []string{dstChainID}
+	for c := prev[dstChainID]; c != ""; c = prev[c] {
+		route = append([]string{c}, route...)
+	}
+	return g.Route(route...)
+}
+
+// IBCGraph queries every relayer path in the Interchain and returns the resulting graph.
+// It may only be called after Build.
+func (ic *Interchain) IBCGraph(ctx context.Context, rep ibc.RelayerExecReporter) (*IBCGraph, error) {
+	if !ic.built {
+		return nil, fmt.Errorf("Interchain.IBCGraph called before Build")
+	}
+
+	g := NewIBCGraph()
+	for rp, link := range ic.links {
+		src, dst, err := ibc.GetPathEnds(ctx, rp.Relayer, rep, ic.chains[link.chains[0]], ic.chains[link.chains[1]], link.portID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to query path %s on relayer %s: %w", rp.Path, ic.relayers[rp.Relayer], err)
+		}
+		g.add(IBCHop{Src: src, Dst: dst, Relayers: []string{ic.relayers[rp.Relayer]}})
+	}
+	return g, nil
+}
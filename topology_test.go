@@ -0,0 +1,163 @@
+package interchaintest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/interchaintest/v11"
+	"github.com/cosmos/interchaintest/v11/ibc"
+	"github.com/cosmos/interchaintest/v11/relayer/rly"
+	"github.com/cosmos/interchaintest/v11/testutil"
+)
+
+func topologyTestChains(t *testing.T, n int) []ibc.Chain {
+	t.Helper()
+
+	var specs []*interchaintest.ChainSpec
+	for i := range n {
+		specs = append(specs, &interchaintest.ChainSpec{
+			Name: testutil.TestSimd, ChainName: fmt.Sprintf("c%d", i), Version: testutil.SimdVersion,
+			ChainConfig:   ibc.ChainConfig{ChainID: fmt.Sprintf("chain-%d", i)},
+			NumValidators: &numVals, NumFullNodes: &numFullNodesZero,
+		})
+	}
+
+	chains, err := interchaintest.NewBuiltinChainFactory(zap.NewNop(), specs).Chains(t.Name())
+	require.NoError(t, err)
+	return chains
+}
+
+func edgePathNames(topo interchaintest.Topology) []string {
+	var names []string
+	for _, e := range topo.Edges {
+		names = append(names, topo.PathName(e))
+	}
+	return names
+}
+
+func TestTopology_Shapes(t *testing.T) {
+	chains := topologyTestChains(t, 4)
+
+	require.Equal(t,
+		[]string{"chain-0-chain-1", "chain-0-chain-2", "chain-0-chain-3"},
+		edgePathNames(interchaintest.StarTopology(chains[0], chains[1:]...)),
+	)
+
+	require.Equal(t,
+		[]string{"pfm-chain-0-chain-1", "pfm-chain-1-chain-2", "pfm-chain-2-chain-3"},
+		edgePathNames(interchaintest.LineTopology(chains...).WithPathPrefix("pfm-")),
+	)
+
+	require.Equal(t,
+		[]string{
+			"chain-0-chain-1", "chain-0-chain-2", "chain-0-chain-3",
+			"chain-1-chain-2", "chain-1-chain-3",
+			"chain-2-chain-3",
+		},
+		edgePathNames(interchaintest.MeshTopology(chains...)),
+	)
+}
+
+func TestInterchain_AddTopology(t *testing.T) {
+	chains := topologyTestChains(t, 3)
+
+	t.Run("redundant relayers", func(t *testing.T) {
+		var r1, r2 rly.CosmosRelayer
+		ic := interchaintest.NewInterchain().AddRelayer(&r1, "r1").AddRelayer(&r2, "r2")
+		for _, c := range chains {
+			ic.AddChain(c)
+		}
+
+		ic.AddTopology(interchaintest.LineTopology(chains...).WithRelayers(&r1, &r2))
+
+		// Each relayer now owns a path per edge, so adding it again must conflict.
+		require.Panics(t, func() {
+			ic.AddLink(interchaintest.InterchainLink{Chain1: chains[1], Chain2: chains[2], Relayer: &r2, Path: "chain-1-chain-2"})
+		})
+	})
+
+	t.Run("missing relayer", func(t *testing.T) {
+		ic := interchaintest.NewInterchain()
+		for _, c := range chains {
+			ic.AddChain(c)
+		}
+
+		require.PanicsWithError(t, "no relayer for topology edge between chain-0 and chain-1", func() {
+			ic.AddTopology(interchaintest.LineTopology(chains...))
+		})
+	})
+}
+
+func TestIBCGraph(t *testing.T) {
+	hop := func(a, b string, aChan, bChan int, relayer string) interchaintest.IBCHop {
+		return interchaintest.IBCHop{
+			Src: ibc.PathEnd{
+				ChainID: a, ClientID: fmt.Sprintf("07-tendermint-%d", aChan),
+				ConnectionID: fmt.Sprintf("connection-%d", aChan), PortID: "transfer", ChannelID: fmt.Sprintf("channel-%d", aChan),
+			},
+			Dst: ibc.PathEnd{
+				ChainID: b, ClientID: fmt.Sprintf("07-tendermint-%d", bChan),
+				ConnectionID: fmt.Sprintf("connection-%d", bChan), PortID: "transfer", ChannelID: fmt.Sprintf("channel-%d", bChan),
+			},
+			Relayers: []string{relayer},
+		}
+	}
+
+	// a - b - c - d, plus a shortcut a - d relayed by two relayers.
+	g := interchaintest.NewIBCGraph(
+		hop("a", "b", 0, 0, "r1"),
+		hop("b", "c", 1, 0, "r1"),
+		hop("c", "d", 1, 0, "r1"),
+		hop("a", "d", 1, 1, "r2"),
+		hop("d", "a", 1, 1, "r1"),
+	)
+
+	h, ok := g.Hop("b", "a")
+	require.True(t, ok)
+	require.Equal(t, "channel-0", h.Src.ChannelID)
+	require.Equal(t, "a", h.Dst.ChainID)
+
+	h, ok = g.Hop("a", "d")
+	require.True(t, ok)
+	require.Equal(t, []string{"r1", "r2"}, h.Relayers)
+
+	_, ok = g.Hop("a", "c")
+	require.False(t, ok)
+
+	// A second channel between the same chains and ports is kept alongside the first.
+	g2 := interchaintest.NewIBCGraph(hop("a", "b", 0, 0, "r1"), hop("a", "b", 10, 2, "r2"), hop("a", "b", 2, 1, "r1"))
+	hops := g2.PortHops("b", "a", "transfer")
+	require.Len(t, hops, 3)
+	require.Equal(t, []string{"channel-0", "channel-1", "channel-2"}, []string{hops[0].Src.ChannelID, hops[1].Src.ChannelID, hops[2].Src.ChannelID})
+	h, ok = g2.Hop("a", "b")
+	require.True(t, ok)
+	require.Equal(t, "channel-0", h.Src.ChannelID)
+	h, ok = g2.ChannelHop("a", "transfer", "channel-10")
+	require.True(t, ok)
+	require.Equal(t, []string{"r2"}, h.Relayers)
+	require.Equal(t, "channel-2", h.Dst.ChannelID)
+	require.Equal(t, []string{"b"}, g2.Neighbors("a"))
+
+	require.Equal(t, []string{"a", "c"}, g.Neighbors("b"))
+
+	route, err := g.Route("a", "b", "c")
+	require.NoError(t, err)
+	require.Len(t, route, 2)
+	require.Equal(t, "channel-0", route[0].Src.ChannelID)
+	require.Equal(t, "channel-1", route[1].Src.ChannelID)
+
+	_, err = g.Route("a", "c")
+	require.EqualError(t, err, "no transfer channel from a to c")
+
+	route, err = g.ShortestRoute("b", "d")
+	require.NoError(t, err)
+	require.Len(t, route, 2)
+	require.Equal(t, "a", route[0].Dst.ChainID) // Ties are broken by chain ID.
+	require.Equal(t, "d", route[1].Dst.ChainID)
+
+	_, err = g.ShortestRoute("a", "z")
+	require.EqualError(t, err, "no route from a to z")
+}